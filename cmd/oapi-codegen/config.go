@@ -0,0 +1,163 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// configuration is the content of the file given with -config, which saves
+// repeating the same flags on every run. Flags given on the command line take
+// precedence over it.
+//
+//	package: petstore
+//	generate: [types, client, server]
+//	output-dir: api
+//	output-suffix: _oapi.gen.go
+//	output-files:
+//	  client: zz_generated_client.go
+//	max-types-per-file: 500
+//	external-examples-lock: examples.lock
+//	runtime-package: corp.example.com/mirror/oapi-codegen/pkg/runtime
+//	profiles:
+//	  sdk:
+//	    generate: [types, client]
+//	    output-dir: sdk
+//	  server:
+//	    generate: [types, server, spec]
+//	    output: server.gen.go
+//
+// The profiles are named sets of settings, selected with -profile, which
+// override those given at the top level.
+type configuration struct {
+	PackageName     string            `json:"package"`
+	Generate        []string          `json:"generate"`
+	Output          string            `json:"output"`
+	OutputDir       string            `json:"output-dir"`
+	OutputSuffix    string            `json:"output-suffix"`
+	OutputFiles     map[string]string `json:"output-files"`
+	MaxTypesPerFile int               `json:"max-types-per-file"`
+	IncludeTags     []string          `json:"include-tags"`
+	ExcludeTags     []string          `json:"exclude-tags"`
+
+	ExternalExamplesLock string `json:"external-examples-lock"`
+	RuntimePackage       string `json:"runtime-package"`
+
+	Profiles map[string]*configuration `json:"profiles"`
+}
+
+func loadConfiguration(path string) (*configuration, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config configuration
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", path, err)
+	}
+	for name, profile := range config.Profiles {
+		if profile == nil {
+			return nil, fmt.Errorf("error parsing %s: profile %s is empty", path, name)
+		}
+		if len(profile.Profiles) != 0 {
+			return nil, fmt.Errorf("error parsing %s: profile %s can't have profiles", path, name)
+		}
+	}
+	return &config, nil
+}
+
+// loadProfile loads the configuration at path, with the settings of the named
+// profile applied, if any. It returns nil when there's no configuration.
+func loadProfile(path string, profile string) (*configuration, error) {
+	if path == "" {
+		if profile != "" {
+			return nil, fmt.Errorf("-profile requires a config file")
+		}
+		return nil, nil
+	}
+	config, err := loadConfiguration(path)
+	if err != nil || profile == "" {
+		return config, err
+	}
+	return config.withProfile(profile)
+}
+
+// withProfile returns the configuration with the settings of the named
+// profile applied on top of it.
+func (c *configuration) withProfile(name string) (*configuration, error) {
+	profile, found := c.Profiles[name]
+	if !found {
+		names := make([]string, 0, len(c.Profiles))
+		for n := range c.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown profile %q, expected one of: %s", name, strings.Join(names, ", "))
+	}
+	merged := *c
+	merged.Profiles = nil
+	if profile.PackageName != "" {
+		merged.PackageName = profile.PackageName
+	}
+	if len(profile.Generate) != 0 {
+		merged.Generate = profile.Generate
+	}
+	// The output file and directory exclude each other, so a profile giving
+	// either replaces both.
+	if profile.Output != "" || profile.OutputDir != "" {
+		merged.Output = profile.Output
+		merged.OutputDir = profile.OutputDir
+	}
+	if profile.OutputSuffix != "" {
+		merged.OutputSuffix = profile.OutputSuffix
+	}
+	if len(profile.OutputFiles) != 0 {
+		merged.OutputFiles = profile.OutputFiles
+	}
+	if profile.MaxTypesPerFile != 0 {
+		merged.MaxTypesPerFile = profile.MaxTypesPerFile
+	}
+	if len(profile.IncludeTags) != 0 {
+		merged.IncludeTags = profile.IncludeTags
+	}
+	if len(profile.ExcludeTags) != 0 {
+		merged.ExcludeTags = profile.ExcludeTags
+	}
+	if profile.ExternalExamplesLock != "" {
+		merged.ExternalExamplesLock = profile.ExternalExamplesLock
+	}
+	if profile.RuntimePackage != "" {
+		merged.RuntimePackage = profile.RuntimePackage
+	}
+	return &merged, nil
+}
+
+// parseOutputFiles parses the -output-files flag, a comma-separated list of
+// target=file pairs.
+func parseOutputFiles(input string) (map[string]string, error) {
+	files := map[string]string{}
+	for _, pair := range splitCSVArg(input) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("output file %q isn't of the form target=file", pair)
+		}
+		files[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return files, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesConfig = `
+package: petstore
+generate: [types]
+output-dir: api
+output-suffix: _oapi.gen.go
+include-tags: [pets]
+profiles:
+  sdk:
+    generate: [types, client]
+    package: sdk
+  server:
+    output: server.gen.go
+  split:
+    output-dir: split
+  empty: {}
+`
+
+func TestLoadProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oapi-codegen-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(profilesConfig), 0644))
+
+	base := configuration{
+		PackageName:  "petstore",
+		Generate:     []string{"types"},
+		OutputDir:    "api",
+		OutputSuffix: "_oapi.gen.go",
+		IncludeTags:  []string{"pets"},
+	}
+	profile := func(edit func(c *configuration)) *configuration {
+		c := base
+		edit(&c)
+		return &c
+	}
+	tests := []struct {
+		profile  string
+		expected *configuration
+	}{
+		{"sdk", profile(func(c *configuration) {
+			c.PackageName = "sdk"
+			c.Generate = []string{"types", "client"}
+		})},
+		// Giving output clears output-dir, and the reverse.
+		{"server", profile(func(c *configuration) {
+			c.Output = "server.gen.go"
+			c.OutputDir = ""
+		})},
+		{"split", profile(func(c *configuration) {
+			c.OutputDir = "split"
+		})},
+		{"empty", &base},
+	}
+	for _, test := range tests {
+		t.Run(test.profile, func(t *testing.T) {
+			config, err := loadProfile(path, test.profile)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, config)
+		})
+	}
+
+	config, err := loadProfile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "api", config.OutputDir)
+	assert.Len(t, config.Profiles, 4)
+
+	config, err = loadProfile("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oapi-codegen-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		config  string
+		profile string
+		err     string
+	}{
+		{"unknown profile", profilesConfig, "docs", `unknown profile "docs", expected one of: empty, sdk, server, split`},
+		{"null profile", "profiles:\n  sdk:\n", "sdk", "profile sdk is empty"},
+		{"nested profiles", "profiles:\n  sdk:\n    profiles:\n      client: {}\n", "sdk", "profile sdk can't have profiles"},
+		{"no config", "", "sdk", "-profile requires a config file"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var path string
+			if test.config != "" {
+				path = filepath.Join(dir, filepath.Base(t.Name())+".yaml")
+				require.NoError(t, ioutil.WriteFile(path, []byte(test.config), 0644))
+			}
+			_, err := loadProfile(path, test.profile)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
+}
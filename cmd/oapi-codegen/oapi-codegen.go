@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/shawnhankim/oapi-codegen/pkg/codegen"
 	"github.com/shawnhankim/oapi-codegen/pkg/util"
 )
@@ -37,13 +38,66 @@ func main() {
 		outputFile  string
 		includeTags string
 		excludeTags string
+		configFile  string
+		profile     string
+
+		outputDir       string
+		outputSuffix    string
+		outputFiles     string
+		maxTypesPerFile int
+
+		externalExamplesLock string
+		runtimePackage       string
+
+		responseContentTypeMatching string
+		unexpectedContentTypeErrors bool
+		typedErrors                 bool
+		dateTimeUTC                 bool
+		dateTimeLayout              string
+		shardSpecByTag              bool
+		omitSpec                    bool
+		swagger2Extensions          bool
+		fieldPresence               bool
+		gatewayFormat               string
+		gatewayUpstream             string
 	)
 	flag.StringVar(&packageName, "package", "", "The package name for generated code")
 	flag.StringVar(&generate, "generate", "types,client,server,spec",
-		`Comma-separated list of code to generate; valid options: "types", "client", "chi-server", "server", "skip-fmt", "spec"`)
+		`Comma-separated list of code to generate; valid options: "types", "client", "tag-clients", "fake-client", "in-memory-client", "example-tests", "fuzz-tests", "chi-server", "server", "skip-fmt", "spec", "provenance", "manifest", "gateway-config", "schema-export", "audit", "slo", "deprecation"`)
 	flag.StringVar(&outputFile, "o", "", "Where to output generated code, stdout is default")
+	flag.StringVar(&configFile, "config", "", "A YAML file holding the package, generate, output, output-dir, output-suffix, output-files, max-types-per-file, external-examples-lock, runtime-package, include-tags and exclude-tags settings, which flags override")
+	flag.StringVar(&profile, "profile", "", "The profile of the config file whose settings override its top-level ones")
+	flag.StringVar(&outputDir, "output-dir", "", "Split the generated code in one file per target, written to this directory, instead of a single file")
+	flag.StringVar(&outputSuffix, "output-suffix", codegen.DefaultOutputSuffix, "With -output-dir, the suffix of the files, after the name of their target")
+	flag.StringVar(&outputFiles, "output-files", "", "With -output-dir, comma-separated list of target=file pairs, naming the files of some targets, eg, client=zz_generated_client.go")
+	flag.IntVar(&maxTypesPerFile, "max-types-per-file", 0, "With -output-dir, shard the component types in files of at most this many types, sorted by name")
+	flag.StringVar(&externalExamplesLock, "external-examples-lock", "",
+		"Fetch the examples given by externalValue and embed them in the spec, checking their content against the hashes in this lock file, which is created or updated")
+	flag.StringVar(&runtimePackage, "runtime-package", codegen.DefaultRuntimePackage,
+		"The import path of pkg/runtime in the generated code, eg, that of a mirror or vendored copy, whose directory also holds the other imported packages, such as types")
 	flag.StringVar(&includeTags, "include-tags", "", "Only include operations with the given tags. Comma-separated list of tags.")
 	flag.StringVar(&excludeTags, "exclude-tags", "", "Exclude operations that are tagged with the given tags. Comma-separated list of tags.")
+	flag.StringVar(&responseContentTypeMatching, "response-content-type-matching", codegen.ContentTypeMatchingLenient,
+		`How the client matches the Content-Type of responses; valid options: "lenient", "strict", "custom"`)
+	flag.BoolVar(&unexpectedContentTypeErrors, "unexpected-content-type-errors", false,
+		"Return a *runtime.UnexpectedContentTypeError from Parse functions for responses with undeclared content types")
+	flag.BoolVar(&typedErrors, "typed-errors", false,
+		"Generate an error type for each JSON error response, which Parse functions return along with the response")
+	flag.BoolVar(&shardSpecByTag, "shard-spec-by-tag", false,
+		"Split the embedded spec per tag, so that GetSwaggerForTags only decompresses the parts it needs")
+	flag.BoolVar(&omitSpec, "omit-spec", false,
+		"Leave the embedded spec and GetSwagger out of the generated code, even when the spec target is given, as it is by default")
+	flag.BoolVar(&dateTimeUTC, "date-time-utc", false, "Convert date-time values to UTC when marshaling and parsing them")
+	flag.StringVar(&dateTimeLayout, "date-time-layout", "",
+		`Layout of date-time values; "RFC3339", "RFC3339Nano" or a Go time layout`)
+	flag.BoolVar(&swagger2Extensions, "swagger2-extensions", false,
+		"Interpret the x-nullable, x-isnullable and x-omitempty extensions of properties, as Swagger 2 toolchains did")
+	flag.BoolVar(&fieldPresence, "field-presence", false,
+		"Make the types of the component schemas record which properties were present in the JSON they were unmarshaled from")
+	flag.StringVar(&gatewayFormat, "gateway-format", codegen.GatewayFormatKong,
+		`Format of the gateway-config target; valid options: "kong", "nginx"`)
+	flag.StringVar(&gatewayUpstream, "gateway-upstream", "",
+		"URL of the service behind the gateway, which defaults to the first server of the spec")
 	flag.Parse()
 
 	if flag.NArg() < 1 {
@@ -51,6 +105,60 @@ func main() {
 		os.Exit(1)
 	}
 
+	files, err := parseOutputFiles(outputFiles)
+	if err != nil {
+		errExit("error parsing -output-files: %s\n", err)
+	}
+
+	config, err := loadProfile(configFile, profile)
+	if err != nil {
+		errExit("error loading config: %s\n", err)
+	}
+	if config != nil {
+		setFlags := map[string]bool{}
+		flag.Visit(func(f *flag.Flag) {
+			setFlags[f.Name] = true
+		})
+		if !setFlags["package"] && config.PackageName != "" {
+			packageName = config.PackageName
+		}
+		if !setFlags["generate"] && len(config.Generate) != 0 {
+			generate = strings.Join(config.Generate, ",")
+		}
+		if !setFlags["o"] && !setFlags["output-dir"] {
+			outputFile = config.Output
+			outputDir = config.OutputDir
+		}
+		if !setFlags["output-suffix"] && config.OutputSuffix != "" {
+			outputSuffix = config.OutputSuffix
+		}
+		if !setFlags["output-files"] && len(config.OutputFiles) != 0 {
+			files = config.OutputFiles
+		}
+		if !setFlags["max-types-per-file"] && config.MaxTypesPerFile != 0 {
+			maxTypesPerFile = config.MaxTypesPerFile
+		}
+		if !setFlags["external-examples-lock"] && config.ExternalExamplesLock != "" {
+			externalExamplesLock = config.ExternalExamplesLock
+		}
+		if !setFlags["runtime-package"] && config.RuntimePackage != "" {
+			runtimePackage = config.RuntimePackage
+		}
+		if !setFlags["include-tags"] && len(config.IncludeTags) != 0 {
+			includeTags = strings.Join(config.IncludeTags, ",")
+		}
+		if !setFlags["exclude-tags"] && len(config.ExcludeTags) != 0 {
+			excludeTags = strings.Join(config.ExcludeTags, ",")
+		}
+	}
+
+	if outputFile != "" && outputDir != "" {
+		errExit("can not specify both an output file and an output directory\n")
+	}
+	if maxTypesPerFile != 0 && outputDir == "" {
+		errExit("-max-types-per-file requires an output directory\n")
+	}
+
 	// If the package name has not been specified, we will use the name of the
 	// swagger file.
 	if packageName == "" {
@@ -66,6 +174,16 @@ func main() {
 		switch g {
 		case "client":
 			opts.GenerateClient = true
+		case "tag-clients":
+			opts.GenerateTagClients = true
+		case "fake-client":
+			opts.GenerateFakeClient = true
+		case "in-memory-client":
+			opts.GenerateInMemory = true
+		case "example-tests":
+			opts.GenerateExamples = true
+		case "fuzz-tests":
+			opts.GenerateFuzzTests = true
 		case "chi-server":
 			opts.GenerateChiServer = true
 		case "server":
@@ -74,6 +192,20 @@ func main() {
 			opts.GenerateTypes = true
 		case "spec":
 			opts.EmbedSpec = true
+		case "provenance":
+			opts.GenerateProvenance = true
+		case "manifest":
+			opts.GenerateManifest = true
+		case "gateway-config":
+			opts.GenerateGateway = true
+		case "schema-export":
+			opts.GenerateSchemaInfo = true
+		case "audit":
+			opts.GenerateAudit = true
+		case "slo":
+			opts.GenerateSLO = true
+		case "deprecation":
+			opts.GenerateDeprecation = true
 		case "skip-fmt":
 			opts.SkipFmt = true
 		default:
@@ -85,6 +217,22 @@ func main() {
 
 	opts.IncludeTags = splitCSVArg(includeTags)
 	opts.ExcludeTags = splitCSVArg(excludeTags)
+	opts.ResponseContentTypeMatching = responseContentTypeMatching
+	opts.UnexpectedContentTypeErrors = unexpectedContentTypeErrors
+	opts.TypedErrors = typedErrors
+	opts.ShardSpecByTag = shardSpecByTag
+	opts.OmitSpec = omitSpec
+	opts.DateTimeUTC = dateTimeUTC
+	opts.DateTimeLayout = dateTimeLayout
+	opts.Swagger2Extensions = swagger2Extensions
+	opts.FieldPresence = fieldPresence
+	opts.GatewayFormat = gatewayFormat
+	opts.GatewayUpstream = gatewayUpstream
+	opts.OutputSuffix = outputSuffix
+	opts.OutputFiles = files
+	opts.MaxTypesPerFile = maxTypesPerFile
+	opts.RuntimePackage = runtimePackage
+	opts.CommandLine = os.Args[1:]
 
 	if opts.GenerateEchoServer && opts.GenerateChiServer {
 		errExit("can not specify both server and chi-server targets simultaneously")
@@ -95,6 +243,31 @@ func main() {
 		errExit("error loading swagger spec\n: %s", err)
 	}
 
+	if externalExamplesLock != "" {
+		err = embedExternalExamples(swagger, filepath.Dir(flag.Arg(0)), externalExamplesLock)
+		if err != nil {
+			errExit("error embedding external examples: %s\n", err)
+		}
+	}
+
+	if outputDir != "" {
+		files, err := codegen.GenerateFiles(swagger, packageName, opts)
+		if err != nil {
+			errExit("error generating code: %s\n", err)
+		}
+		err = os.MkdirAll(outputDir, 0755)
+		if err != nil {
+			errExit("error creating output directory: %s\n", err)
+		}
+		for name, code := range files {
+			err = ioutil.WriteFile(filepath.Join(outputDir, name), []byte(code), 0644)
+			if err != nil {
+				errExit("error writing generated code to file: %s", err)
+			}
+		}
+		return
+	}
+
 	code, err := codegen.Generate(swagger, packageName, opts)
 	if err != nil {
 		errExit("error generating code: %s\n", err)
@@ -110,6 +283,27 @@ func main() {
 	}
 }
 
+// embedExternalExamples embeds the external examples of the spec, fetched
+// relative to specDir, and saves their hashes in the lock file.
+func embedExternalExamples(swagger *openapi3.Swagger, specDir string, lockFile string) error {
+	lock := codegen.ExampleLock{}
+	data, err := ioutil.ReadFile(lockFile)
+	if err == nil {
+		lock, err = codegen.ParseExampleLock(data)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %s", lockFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	lock, err = codegen.EmbedExternalExamples(swagger, codegen.NewExampleFetcher(specDir), lock)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(lockFile, lock.Bytes(), 0644)
+}
+
 func splitCSVArg(input string) []string {
 	input = strings.TrimSpace(input)
 	if len(input) == 0 {
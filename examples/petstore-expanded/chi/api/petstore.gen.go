@@ -13,6 +13,7 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -62,6 +63,12 @@ type AddPetJSONBody NewPet
 // AddPetRequestBody defines body for AddPet for application/json ContentType.
 type AddPetJSONRequestBody AddPetJSONBody
 
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b AddPetJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
 type ServerInterface interface {
 	// Returns all pets (GET /pets)
 	FindPets(w http.ResponseWriter, r *http.Request)
@@ -95,7 +102,7 @@ func FindPetsCtx(next http.Handler) http.Handler {
 
 		err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter tags: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "tags", err), "tags", "tags to filter by"), http.StatusBadRequest)
 			return
 		}
 
@@ -106,7 +113,7 @@ func FindPetsCtx(next http.Handler) http.Handler {
 
 		err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "limit", err), "limit", "maximum number of results to return"), http.StatusBadRequest)
 			return
 		}
 
@@ -135,9 +142,14 @@ func DeletePetCtx(next http.Handler) http.Handler {
 		// ------------- Path parameter "id" -------------
 		var id int64
 
-		err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		if paramValue := chi.URLParam(r, "id"); paramValue != "" {
+			id, err = strconv.ParseInt(paramValue, 10, 64)
+			if err != nil {
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "id", err), "id", "ID of pet to delete"), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "id"), "id", "ID of pet to delete"), http.StatusBadRequest)
 			return
 		}
 
@@ -157,9 +169,14 @@ func FindPetByIdCtx(next http.Handler) http.Handler {
 		// ------------- Path parameter "id" -------------
 		var id int64
 
-		err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		if paramValue := chi.URLParam(r, "id"); paramValue != "" {
+			id, err = strconv.ParseInt(paramValue, 10, 64)
+			if err != nil {
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "id", err), "id", "ID of pet to fetch"), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "id"), "id", "ID of pet to fetch"), http.StatusBadRequest)
 			return
 		}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -49,7 +50,7 @@ func (w *ServerInterfaceWrapper) FindPets(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "tags", ctx.QueryParams(), &params.Tags)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tags: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "tags", err), "tags", "tags to filter by"))
 	}
 
 	// ------------- Optional query parameter "limit" -------------
@@ -59,7 +60,7 @@ func (w *ServerInterfaceWrapper) FindPets(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "limit", err), "limit", "maximum number of results to return"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -82,9 +83,13 @@ func (w *ServerInterfaceWrapper) DeletePet(ctx echo.Context) error {
 	// ------------- Path parameter "id" -------------
 	var id int64
 
-	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	if paramValue := ctx.Param("id"); paramValue != "" {
+		id, err = strconv.ParseInt(paramValue, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "id", err), "id", "ID of pet to delete"))
+		}
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgEmptyParam, "id"), "id", "ID of pet to delete"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -98,9 +103,13 @@ func (w *ServerInterfaceWrapper) FindPetById(ctx echo.Context) error {
 	// ------------- Path parameter "id" -------------
 	var id int64
 
-	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	if paramValue := ctx.Param("id"); paramValue != "" {
+		id, err = strconv.ParseInt(paramValue, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "id", err), "id", "ID of pet to fetch"))
+		}
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgEmptyParam, "id"), "id", "ID of pet to fetch"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -132,6 +141,30 @@ func RegisterHandlers(router interface {
 
 }
 
+// RegisterHandlersForHosts adds each server route to e for the hosts of the
+// servers of the spec, under their base paths, so that one echo instance can
+// serve several APIs based on the Host header. The middlewares m apply to
+// these routes only. The routes are added for:
+//
+//	petstore.swagger.io/api
+func RegisterHandlersForHosts(e *echo.Echo, si ServerInterface, m ...echo.MiddlewareFunc) {
+	hosts := []struct {
+		host      string
+		basePaths []string
+	}{
+		{"petstore.swagger.io", []string{"/api"}},
+	}
+	for _, h := range hosts {
+		// Routers are per host, so each host is only created once.
+		router := e.Host(h.host)
+		for _, basePath := range h.basePaths {
+			group := router.Group(basePath)
+			group.Use(m...)
+			RegisterHandlers(group, si)
+		}
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
@@ -3,6 +3,10 @@
 // Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
 package api
 
+import (
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
 // Error defines model for Error.
 type Error struct {
 
@@ -48,3 +52,9 @@ type AddPetJSONBody NewPet
 
 // AddPetRequestBody defines body for AddPet for application/json ContentType.
 type AddPetJSONRequestBody AddPetJSONBody
+
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b AddPetJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
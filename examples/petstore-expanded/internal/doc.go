@@ -18,3 +18,4 @@ package internal
 
 // Run oapi-codegen to regenerate the petstore boilerplate
 //go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=petstore --generate types,client -o ../petstore-client.gen.go ../petstore-expanded.yaml
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=petstore --generate fake-client -o ../petstore-fake-client.gen.go ../petstore-expanded.yaml
@@ -62,8 +62,22 @@ type AddPetJSONBody NewPet
 // AddPetRequestBody defines body for AddPet for application/json ContentType.
 type AddPetJSONRequestBody AddPetJSONBody
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(req *http.Request, ctx context.Context) error
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b AddPetJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
 
 // Doer performs HTTP requests.
 //
@@ -73,6 +87,10 @@ type HttpRequestDoer interface {
 }
 
 // Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
 	// https://api.deepmap.com for example.
@@ -82,9 +100,21 @@ type Client struct {
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
 
-	// A callback for modifying requests which are generated before sending over
-	// the network.
-	RequestEditor RequestEditorFn
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -109,6 +139,29 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	return &client, nil
 }
 
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
 // WithHTTPClient allows overriding the default Doer, which is
 // automatically created using http.Client. This is useful for tests.
 func WithHTTPClient(doer HttpRequestDoer) ClientOption {
@@ -120,103 +173,234 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
 	return func(c *Client) error {
-		c.RequestEditor = fn
+		c.RequestEditors = append(c.RequestEditors, editors...)
 		return nil
 	}
 }
 
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
+		return nil
+	}
+}
+
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// FindPets request
-	FindPets(ctx context.Context, params *FindPetsParams) (*http.Response, error)
+	FindPets(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// AddPet request  with any body
-	AddPetWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	AddPet(ctx context.Context, body AddPetJSONRequestBody) (*http.Response, error)
+	AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeletePet request
-	DeletePet(ctx context.Context, id int64) (*http.Response, error)
+	DeletePet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// FindPetById request
-	FindPetById(ctx context.Context, id int64) (*http.Response, error)
+	FindPetById(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) FindPets(ctx context.Context, params *FindPetsParams) (*http.Response, error) {
+func (c *Client) FindPets(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewFindPetsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody) (*http.Response, error) {
+func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewAddPetRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) DeletePet(ctx context.Context, id int64) (*http.Response, error) {
+func (c *Client) DeletePet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeletePetRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) FindPetById(ctx context.Context, id int64) (*http.Response, error) {
+func (c *Client) FindPetById(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewFindPetByIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
 // NewFindPetsRequest generates requests for FindPets
@@ -487,8 +671,8 @@ func (r findPetByIdResponse) StatusCode() int {
 }
 
 // FindPetsWithResponse request returning *FindPetsResponse
-func (c *ClientWithResponses) FindPetsWithResponse(ctx context.Context, params *FindPetsParams) (*findPetsResponse, error) {
-	rsp, err := c.FindPets(ctx, params)
+func (c *ClientWithResponses) FindPetsWithResponse(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*findPetsResponse, error) {
+	rsp, err := c.FindPets(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -496,16 +680,16 @@ func (c *ClientWithResponses) FindPetsWithResponse(ctx context.Context, params *
 }
 
 // AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
-func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*addPetResponse, error) {
-	rsp, err := c.AddPetWithBody(ctx, contentType, body)
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*addPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseAddPetResponse(rsp)
 }
 
-func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody) (*addPetResponse, error) {
-	rsp, err := c.AddPet(ctx, body)
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*addPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -513,8 +697,8 @@ func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPe
 }
 
 // DeletePetWithResponse request returning *DeletePetResponse
-func (c *ClientWithResponses) DeletePetWithResponse(ctx context.Context, id int64) (*deletePetResponse, error) {
-	rsp, err := c.DeletePet(ctx, id)
+func (c *ClientWithResponses) DeletePetWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*deletePetResponse, error) {
+	rsp, err := c.DeletePet(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -522,8 +706,8 @@ func (c *ClientWithResponses) DeletePetWithResponse(ctx context.Context, id int6
 }
 
 // FindPetByIdWithResponse request returning *FindPetByIdResponse
-func (c *ClientWithResponses) FindPetByIdWithResponse(ctx context.Context, id int64) (*findPetByIdResponse, error) {
-	rsp, err := c.FindPetById(ctx, id)
+func (c *ClientWithResponses) FindPetByIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*findPetByIdResponse, error) {
+	rsp, err := c.FindPetById(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -550,6 +734,9 @@ func ParseFindPetsResponse(rsp *http.Response) (*findPetsResponse, error) {
 			return nil, err
 		}
 
+	case rsp.StatusCode == 200:
+		break // Declared status codes aren't parsed as a less specific response
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
 		response.JSONDefault = &Error{}
 		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
@@ -581,6 +768,9 @@ func ParseAddPetResponse(rsp *http.Response) (*addPetResponse, error) {
 			return nil, err
 		}
 
+	case rsp.StatusCode == 200:
+		break // Declared status codes aren't parsed as a less specific response
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
 		response.JSONDefault = &Error{}
 		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
@@ -606,6 +796,9 @@ func ParseDeletePetResponse(rsp *http.Response) (*deletePetResponse, error) {
 	}
 
 	switch {
+	case rsp.StatusCode == 204:
+		break // Declared status codes aren't parsed as a less specific response
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
 		response.JSONDefault = &Error{}
 		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
@@ -637,6 +830,9 @@ func ParseFindPetByIdResponse(rsp *http.Response) (*findPetByIdResponse, error)
 			return nil, err
 		}
 
+	case rsp.StatusCode == 200:
+		break // Declared status codes aren't parsed as a less specific response
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
 		response.JSONDefault = &Error{}
 		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
@@ -0,0 +1,192 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shawnhankim/oapi-codegen/internal/test/adapter/chi"
+)
+
+// orderError is a domain error of the order service, which knows its status.
+type orderError struct {
+	status  int
+	message string
+}
+
+func (e orderError) Error() string   { return e.message }
+func (e orderError) StatusCode() int { return e.status }
+
+// orderStore is an existing business service, which isn't aware of HTTP.
+type orderStore struct {
+	orders map[int]string
+	dryRun bool
+}
+
+func (s *orderStore) create(reference string, dryRun bool) (int, error) {
+	for _, r := range s.orders {
+		if r == reference {
+			return 0, orderError{http.StatusConflict, "reference " + reference + " exists"}
+		}
+	}
+	s.dryRun = dryRun
+	id := len(s.orders) + 1
+	if !dryRun {
+		s.orders[id] = reference
+	}
+	return id, nil
+}
+
+func (s *orderStore) get(id int) (string, error) {
+	reference, found := s.orders[id]
+	if !found {
+		return "", fmt.Errorf("getting order: %w", orderError{http.StatusNotFound, fmt.Sprintf("order %d not found", id)})
+	}
+	return reference, nil
+}
+
+func (s *orderStore) cancel(id int) error {
+	if id == 42 {
+		return errors.New("database unavailable")
+	}
+	if _, err := s.get(id); err != nil {
+		return err
+	}
+	delete(s.orders, id)
+	return nil
+}
+
+type echoOrders struct{ *orderStore }
+
+func (s echoOrders) Create(ctx context.Context, params CreateOrderParams, body CreateOrderJSONRequestBody) (Order, error) {
+	id, err := s.create(body.Reference, params.DryRun != nil && *params.DryRun)
+	return Order{Id: id, Reference: body.Reference}, err
+}
+
+func (s echoOrders) Get(ctx context.Context, id int) (Order, error) {
+	reference, err := s.get(id)
+	return Order{Id: id, Reference: reference}, err
+}
+
+func (s echoOrders) Cancel(ctx context.Context, id int) error {
+	return s.cancel(id)
+}
+
+type chiOrders struct{ *orderStore }
+
+func (s chiOrders) Create(ctx context.Context, params chi.CreateOrderParams, body chi.CreateOrderJSONRequestBody) (chi.Order, error) {
+	id, err := s.create(body.Reference, params.DryRun != nil && *params.DryRun)
+	return chi.Order{Id: id, Reference: body.Reference}, err
+}
+
+func (s chiOrders) Get(ctx context.Context, id int) (chi.Order, error) {
+	reference, err := s.get(id)
+	return chi.Order{Id: id, Reference: reference}, err
+}
+
+func (s chiOrders) Cancel(ctx context.Context, id int) error {
+	return s.cancel(id)
+}
+
+type echoHealth struct{}
+
+func (echoHealth) GetHealth(ctx echo.Context) error { return ctx.NoContent(http.StatusOK) }
+
+// The other operations are served by the adapter.
+func (echoHealth) CreateOrder(ctx echo.Context, params CreateOrderParams) error { return nil }
+func (echoHealth) GetOrder(ctx echo.Context, id int) error                      { return nil }
+func (echoHealth) CancelOrder(ctx echo.Context, id int) error                   { return nil }
+
+type chiHealth struct{}
+
+func (chiHealth) GetHealth(w http.ResponseWriter, r *http.Request)   { w.WriteHeader(http.StatusOK) }
+func (chiHealth) CreateOrder(w http.ResponseWriter, r *http.Request) {}
+func (chiHealth) GetOrder(w http.ResponseWriter, r *http.Request)    {}
+func (chiHealth) CancelOrder(w http.ResponseWriter, r *http.Request) {}
+
+func TestServiceAdapter(t *testing.T) {
+	handlers := map[string]func(store *orderStore) http.Handler{
+		"echo": func(store *orderStore) http.Handler {
+			e := echo.New()
+			RegisterHandlers(e, &ServiceAdapter{ServerInterface: echoHealth{}, OrderService: echoOrders{store}})
+			return e
+		},
+		"chi": func(store *orderStore) http.Handler {
+			return chi.Handler(&chi.ServiceAdapter{ServerInterface: chiHealth{}, OrderService: chiOrders{store}})
+		},
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			store := &orderStore{orders: map[int]string{1: "first"}}
+			h := handler(store)
+			do := func(method, path, body string) (int, string) {
+				req := httptest.NewRequest(method, path, strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+				return rec.Code, strings.TrimSpace(rec.Body.String())
+			}
+
+			status, body := do("POST", "/orders?dryRun=true", `{"reference": "second"}`)
+			assert.Equal(t, http.StatusCreated, status)
+			assert.JSONEq(t, `{"id": 2, "reference": "second"}`, body)
+			assert.True(t, store.dryRun)
+			assert.Len(t, store.orders, 1)
+
+			status, body = do("POST", "/orders", `{"reference": "second"}`)
+			assert.Equal(t, http.StatusCreated, status)
+			assert.JSONEq(t, `{"id": 2, "reference": "second"}`, body)
+			assert.False(t, store.dryRun)
+
+			status, body = do("GET", "/orders/2", "")
+			assert.Equal(t, http.StatusOK, status)
+			assert.JSONEq(t, `{"id": 2, "reference": "second"}`, body)
+
+			status, _ = do("POST", "/orders", `{"reference": `)
+			assert.Equal(t, http.StatusBadRequest, status)
+
+			// Domain errors result in the responses declared by the spec.
+			status, body = do("POST", "/orders", `{"reference": "first"}`)
+			assert.Equal(t, http.StatusConflict, status)
+			assert.Contains(t, body, "reference first exists")
+			status, body = do("GET", "/orders/3", "")
+			assert.Equal(t, http.StatusNotFound, status)
+			assert.Contains(t, body, "getting order: order 3 not found")
+
+			status, _ = do("DELETE", "/orders/2", "")
+			assert.Equal(t, http.StatusNoContent, status)
+			// Cancelling declares a default response, so any status goes.
+			status, _ = do("DELETE", "/orders/2", "")
+			assert.Equal(t, http.StatusNotFound, status)
+			// Errors without a status don't leak their message.
+			status, body = do("DELETE", "/orders/42", "")
+			assert.Equal(t, http.StatusInternalServerError, status)
+			assert.NotContains(t, body, "database")
+
+			// Operations without x-go-impl are served by the embedded server.
+			status, _ = do("GET", "/health", "")
+			assert.Equal(t, http.StatusOK, status)
+		})
+	}
+}
+
+func TestServiceAdapterErrorHandler(t *testing.T) {
+	e := echo.New()
+	RegisterHandlers(e, &ServiceAdapter{
+		OrderService: echoOrders{&orderStore{orders: map[int]string{}}},
+		ErrorHandler: func(ctx echo.Context, err error) error {
+			return ctx.JSON(http.StatusTeapot, map[string]string{"error": err.Error()})
+		},
+	})
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/orders/1", nil))
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.JSONEq(t, `{"error": "getting order: order 1 not found"}`, rec.Body.String())
+}
@@ -0,0 +1,257 @@
+// Package chi provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package chi
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"net/http"
+	"strconv"
+)
+
+// NewOrder defines model for NewOrder.
+type NewOrder struct {
+	Reference string `json:"reference"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	Id        int    `json:"id"`
+	Reference string `json:"reference"`
+}
+
+// CreateOrderJSONBody defines parameters for CreateOrder.
+type CreateOrderJSONBody NewOrder
+
+// CreateOrderParams defines parameters for CreateOrder.
+type CreateOrderParams struct {
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+// CreateOrderRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody CreateOrderJSONBody
+
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b CreateOrderJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
+type ServerInterface interface {
+	//  (GET /health)
+	GetHealth(w http.ResponseWriter, r *http.Request)
+	//  (POST /orders)
+	CreateOrder(w http.ResponseWriter, r *http.Request)
+	//  (DELETE /orders/{id})
+	CancelOrder(w http.ResponseWriter, r *http.Request)
+	//  (GET /orders/{id})
+	GetOrder(w http.ResponseWriter, r *http.Request)
+}
+
+// GetHealth operation middleware
+func GetHealthCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ParamsForCreateOrder operation parameters from context
+func ParamsForCreateOrder(ctx context.Context) *CreateOrderParams {
+	return ctx.Value("CreateOrderParams").(*CreateOrderParams)
+}
+
+// CreateOrder operation middleware
+func CreateOrderCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var err error
+
+		// Parameter object where we will unmarshal all parameters from the context
+		var params CreateOrderParams
+
+		// ------------- Optional query parameter "dryRun" -------------
+		if paramValue := r.URL.Query().Get("dryRun"); paramValue != "" {
+
+		}
+
+		err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+		if err != nil {
+			http.Error(w, runtime.Message(r, runtime.MsgInvalidParamFormat, "dryRun", err), http.StatusBadRequest)
+			return
+		}
+
+		ctx = context.WithValue(ctx, "CreateOrderParams", &params)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// CancelOrder operation middleware
+func CancelOrderCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var err error
+
+		// ------------- Path parameter "id" -------------
+		var id int
+
+		if paramValue := chi.URLParam(r, "id"); paramValue != "" {
+			id, err = strconv.Atoi(paramValue)
+			if err != nil {
+				http.Error(w, runtime.Message(r, runtime.MsgInvalidParamFormat, "id", err), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Message(r, runtime.MsgEmptyParam, "id"), http.StatusBadRequest)
+			return
+		}
+
+		ctx = context.WithValue(ctx, "id", id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetOrder operation middleware
+func GetOrderCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var err error
+
+		// ------------- Path parameter "id" -------------
+		var id int
+
+		if paramValue := chi.URLParam(r, "id"); paramValue != "" {
+			id, err = strconv.Atoi(paramValue)
+			if err != nil {
+				http.Error(w, runtime.Message(r, runtime.MsgInvalidParamFormat, "id", err), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Message(r, runtime.MsgEmptyParam, "id"), http.StatusBadRequest)
+			return
+		}
+
+		ctx = context.WithValue(ctx, "id", id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerFromMux(si, chi.NewRouter())
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	r.Group(func(r chi.Router) {
+		r.Use(GetHealthCtx)
+		r.Get("/health", si.GetHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(CreateOrderCtx)
+		r.Post("/orders", si.CreateOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(CancelOrderCtx)
+		r.Delete("/orders/{id}", si.CancelOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(GetOrderCtx)
+		r.Get("/orders/{id}", si.GetOrder)
+	})
+
+	return r
+}
+
+// OrderService is an existing business service, which ServiceAdapter delegates
+// operations to, per their x-go-impl extension.
+type OrderService interface {
+	// Create implements CreateOrder (POST /orders)
+	Create(ctx context.Context, params CreateOrderParams, body CreateOrderJSONRequestBody) (Order, error)
+	// Cancel implements CancelOrder (DELETE /orders/{id})
+	Cancel(ctx context.Context, id int) error
+	// Get implements GetOrder (GET /orders/{id})
+	Get(ctx context.Context, id int) (Order, error)
+}
+
+// ServiceAdapter implements ServerInterface on top of existing business
+// services, converting the bound parameters and body of each operation with
+// x-go-impl into the arguments of its method, and its results into the
+// success response, or into an error response. Other operations are served
+// by the embedded ServerInterface.
+type ServiceAdapter struct {
+	ServerInterface
+
+	OrderService OrderService
+
+	// ErrorHandler, when set, writes the response to the errors returned by
+	// the services. By default, errors implementing runtime.StatusCoder
+	// result in their status, when the operation declares it, and others in
+	// an internal server error. See runtime.ServiceErrorResponse.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// CreateOrder calls OrderService.Create.
+func (a *ServiceAdapter) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params := *ParamsForCreateOrder(ctx)
+	var body CreateOrderJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, runtime.Message(r, runtime.MsgInvalidBody, err), http.StatusBadRequest)
+		return
+	}
+	result, err := a.OrderService.Create(ctx, params, body)
+	if err != nil {
+		a.serviceError(w, r, err, []int{409}, false)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// CancelOrder calls OrderService.Cancel.
+func (a *ServiceAdapter) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := ctx.Value("id").(int)
+	err := a.OrderService.Cancel(ctx, id)
+	if err != nil {
+		a.serviceError(w, r, err, nil, true)
+		return
+	}
+	w.WriteHeader(204)
+}
+
+// GetOrder calls OrderService.Get.
+func (a *ServiceAdapter) GetOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := ctx.Value("id").(int)
+	result, err := a.OrderService.Get(ctx, id)
+	if err != nil {
+		a.serviceError(w, r, err, []int{404}, false)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// serviceError writes the response to an error returned by a service.
+func (a *ServiceAdapter) serviceError(w http.ResponseWriter, r *http.Request, err error, declared []int, hasDefault bool) {
+	if a.ErrorHandler != nil {
+		a.ErrorHandler(w, r, err)
+		return
+	}
+	status, message := runtime.ServiceErrorResponse(err, declared, hasDefault)
+	http.Error(w, message, status)
+}
@@ -0,0 +1,3 @@
+package adapter
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=adapter --generate=types,server -o adapter.gen.go adapter.yaml
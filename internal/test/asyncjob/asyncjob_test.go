@@ -0,0 +1,88 @@
+package asyncjob
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// sequence returns a client receiving the given JSON bodies in turn, and
+// the last one from then on.
+func sequence(t *testing.T, bodies ...string) *ClientWithResponses {
+	calls := 0
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		body := bodies[len(bodies)-1]
+		if calls < len(bodies) {
+			body = bodies[calls]
+		}
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	client, err := NewClientWithResponses("http://example.com", WithHTTPClient(doer))
+	require.NoError(t, err)
+	return client
+}
+
+func TestJobWatcher(t *testing.T) {
+	client := sequence(t,
+		`{"id":"e1","status":"pending"}`,
+		`{"id":"e1","status":"running"}`,
+		`{"id":"e1","status":"succeeded","file":{"url":"https://example.com/e1.csv"}}`)
+	watcher := NewExportWatcher(client, runtime.WithJobInterval(time.Millisecond))
+
+	var statuses []ExportStatus
+	export, err := watcher.Wait(context.Background(), "e1", func(job *Export) {
+		statuses = append(statuses, ExportStatus(job.Status))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []ExportStatus{ExportStatusPending, ExportStatusRunning, ExportStatusSucceeded}, statuses)
+	assert.True(t, ExportStatus(export.Status).Succeeded())
+	assert.False(t, ExportStatusRunning.IsTerminal())
+
+	client = sequence(t, `{"id":"e2","status":"succeeded","file":{"url":"https://example.com/e2.csv"}}`)
+	file, err := NewExportWatcher(client).Result(context.Background(), "e2")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/e2.csv", file.Url)
+}
+
+func TestJobWatcherFailure(t *testing.T) {
+	// The status type of the job comes from the components, with its own
+	// terminal statuses.
+	client := sequence(t, `{"status":"queued"}`, `{"status":"in_progress","rows":10}`, `{"status":"cancelled","rows":20}`)
+	watcher := NewImportJobWatcher(client, runtime.WithJobInterval(time.Millisecond))
+	job, err := watcher.Wait(context.Background(), 1, &GetImportParams{}, nil)
+	var failed *runtime.JobFailedError
+	require.True(t, errors.As(err, &failed))
+	assert.Equal(t, string(ImportStatusCancelled), failed.Status)
+	assert.Equal(t, 20, *job.Rows)
+	assert.True(t, ImportStatusCancelled.Failed())
+	assert.True(t, ImportStatusDone.Succeeded())
+}
+
+func TestJobWatcherContext(t *testing.T) {
+	client := sequence(t, `{"id":"e3","status":"running"}`)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	job, err := NewExportWatcher(client, runtime.WithJobInterval(5*time.Millisecond)).Wait(ctx, "e3", nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, "running", job.Status)
+}
@@ -0,0 +1,3 @@
+package asyncjob
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=asyncjob --generate=types,client -o asyncjob.gen.go asyncjob.yaml
@@ -0,0 +1,200 @@
+// Package audit provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package audit
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"net/http"
+)
+
+// GetAccountParams defines parameters for GetAccount.
+type GetAccountParams struct {
+	Fields  *string `json:"fields,omitempty"`
+	Pin     *int    `json:"pin,omitempty"`
+	XApiKey string  `json:"X-Api-Key"`
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (DELETE /accounts/{accountId})
+	CloseAccount(ctx echo.Context, accountId string) error
+
+	// (GET /accounts/{accountId})
+	GetAccount(ctx echo.Context, accountId string, params GetAccountParams) error
+
+	// (GET /health)
+	Health(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// CloseAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) CloseAccount(ctx echo.Context) error {
+	var err error
+	auditing := runtime.AuditOperation(ctx.Request().Context(), "closeAccount")
+	// ------------- Path parameter "accountId" -------------
+	var accountId string
+
+	if paramValue := ctx.Param("accountId"); paramValue != "" {
+		accountId = paramValue
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "accountId", err))
+		}
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgEmptyParam, "accountId"))
+	}
+
+	if auditing {
+		runtime.AuditParams(ctx.Request().Context(), map[string]interface{}{
+			"accountId": accountId,
+		})
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CloseAccount(ctx, accountId)
+	return err
+}
+
+// GetAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAccount(ctx echo.Context) error {
+	var err error
+	auditing := runtime.AuditOperation(ctx.Request().Context(), "getAccount")
+	// ------------- Path parameter "accountId" -------------
+	var accountId string
+
+	if paramValue := ctx.Param("accountId"); paramValue != "" {
+		accountId = paramValue
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "accountId", err))
+		}
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgEmptyParam, "accountId"))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAccountParams
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := ctx.QueryParam("fields"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", ctx.QueryParams(), &params.Fields)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "fields", err))
+	}
+
+	// ------------- Optional query parameter "pin" -------------
+	if paramValue := ctx.QueryParam("pin"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "pin", ctx.QueryParams(), &params.Pin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "pin", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Api-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Api-Key")]; found {
+		var XApiKey string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Api-Key", n))
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "X-Api-Key", valueList[0], &XApiKey)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Api-Key", err))
+		}
+
+		params.XApiKey = XApiKey
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgRequiredHeaderParam, "X-Api-Key"))
+	}
+
+	if auditing {
+		runtime.AuditParams(ctx.Request().Context(), map[string]interface{}{
+			"accountId": accountId,
+			"fields":    params.Fields,
+			"pin":       params.Pin,
+			"X-Api-Key": params.XApiKey,
+		})
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetAccount(ctx, accountId, params)
+	return err
+}
+
+// Health converts echo context to params.
+func (w *ServerInterfaceWrapper) Health(ctx echo.Context) error {
+	var err error
+	runtime.AuditOperation(ctx.Request().Context(), "health")
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.Health(ctx)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}, si ServerInterface) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.DELETE("/accounts/:accountId", wrapper.CloseAccount)
+	router.GET("/accounts/:accountId", wrapper.GetAccount)
+	router.GET("/health", wrapper.Health)
+
+}
+
+// AuditDescriptors describes the operations for audit logging, by operation
+// ID.
+var AuditDescriptors = map[string]runtime.AuditDescriptor{
+	"closeAccount": {
+		OperationID: "closeAccount",
+		Method:      "DELETE",
+		Path:        "/accounts/{accountId}",
+		Resource:    "account",
+		Action:      "close",
+		Sensitivity: "high",
+	},
+	"getAccount": {
+		OperationID: "getAccount",
+		Method:      "GET",
+		Path:        "/accounts/{accountId}",
+		Resource:    "accounts",
+		Action:      "read",
+		Sensitive:   []string{"pin", "X-Api-Key"},
+	},
+	"health": {
+		OperationID: "health",
+		Method:      "GET",
+		Path:        "/health",
+		Resource:    "health",
+		Action:      "read",
+	},
+}
+
+// AuditMiddleware returns an echo middleware which calls emit with an audit
+// event for every request to one of the operations.
+func AuditMiddleware(emit runtime.AuditEmitter) echo.MiddlewareFunc {
+	return runtime.AuditMiddleware(AuditDescriptors, emit)
+}
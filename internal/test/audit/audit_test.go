@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) CloseAccount(ctx echo.Context, accountId string) error {
+	return echo.NewHTTPError(http.StatusConflict, "account has a balance")
+}
+
+func (server) GetAccount(ctx echo.Context, accountId string, params GetAccountParams) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) Health(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func TestAuditEvents(t *testing.T) {
+	var events []runtime.AuditEvent
+	e := echo.New()
+	e.Use(AuditMiddleware(func(ctx context.Context, event runtime.AuditEvent) {
+		events = append(events, event)
+	}))
+	RegisterHandlers(e, server{})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/acc-1?fields=owner&pin=1234", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "getAccount", event.OperationID)
+	assert.Equal(t, "accounts", event.Resource)
+	assert.Equal(t, "read", event.Action)
+	assert.Equal(t, http.StatusOK, event.Status)
+	assert.Equal(t, map[string]interface{}{
+		"accountId": "acc-1",
+		"fields":    "owner",
+		"pin":       runtime.AuditRedacted,
+		"X-Api-Key": runtime.AuditRedacted,
+	}, event.Params)
+
+	// Parameters which aren't passed are left out.
+	req = httptest.NewRequest(http.MethodGet, "/accounts/acc-2", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	require.Len(t, events, 2)
+	assert.Equal(t, map[string]interface{}{
+		"accountId": "acc-2",
+		"X-Api-Key": runtime.AuditRedacted,
+	}, events[1].Params)
+
+	// Requests with invalid parameters are audited too.
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/acc-3", nil))
+	require.Len(t, events, 3)
+	assert.Equal(t, "getAccount", events[2].OperationID)
+	assert.Equal(t, http.StatusBadRequest, events[2].Status)
+	assert.Error(t, events[2].Err)
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/accounts/acc-1", nil))
+	require.Len(t, events, 4)
+	event = events[3]
+	assert.Equal(t, "closeAccount", event.OperationID)
+	assert.Equal(t, "account", event.Resource)
+	assert.Equal(t, "close", event.Action)
+	assert.Equal(t, "high", event.Sensitivity)
+	assert.Equal(t, http.StatusConflict, event.Status)
+
+	// Requests which aren't routed to an operation aren't audited.
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	assert.Len(t, events, 4)
+}
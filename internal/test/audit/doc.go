@@ -0,0 +1,3 @@
+package audit
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=audit --generate=types,server,audit -o audit.gen.go audit.yaml
@@ -1,6 +1,10 @@
 // Package client provides primitives to interact the openapi HTTP API.
 //
 // Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+//
+// To regenerate this code, run:
+//
+//	oapi-codegen --package=client --generate=types,client,server,spec,provenance,manifest -o client.gen.go client.yaml
 package client
 
 import (
@@ -12,6 +16,7 @@ import (
 	"fmt"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -19,6 +24,22 @@ import (
 	"strings"
 )
 
+// The provenance of this code, which lets builds be traced back to the version
+// of the contract they were generated from.
+const (
+	// GeneratorVersion is the version of oapi-codegen which generated this code.
+	GeneratorVersion = "(devel)"
+
+	// GeneratorOptions are the options oapi-codegen was run with.
+	GeneratorOptions = "-generate=types,client,server,spec,provenance,manifest"
+
+	// SpecVersion is the version of the OpenAPI spec, from its info object.
+	SpecVersion = "1.0.0"
+
+	// SpecHash is the SHA-256 digest of the JSON encoding of the OpenAPI spec.
+	SpecHash = "sha256:b7a48267cc5bb23d817ce6ac720f0b65f7b8aae8aed953b4dba54f81b67f2a64"
+)
+
 // SchemaObject defines model for SchemaObject.
 type SchemaObject struct {
 	FirstName string `json:"firstName"`
@@ -28,17 +49,52 @@ type SchemaObject struct {
 // PostBothJSONBody defines parameters for PostBoth.
 type PostBothJSONBody SchemaObject
 
+// PostHashedJSONBody defines parameters for PostHashed.
+type PostHashedJSONBody struct {
+	Hash *string `json:"hash,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
 // PostJsonJSONBody defines parameters for PostJson.
 type PostJsonJSONBody SchemaObject
 
 // PostBothRequestBody defines body for PostBoth for application/json ContentType.
 type PostBothJSONRequestBody PostBothJSONBody
 
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b PostBothJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
+// PostHashedRequestBody defines body for PostHashed for application/json ContentType.
+type PostHashedJSONRequestBody PostHashedJSONBody
+
+// RequestHash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b PostHashedJSONRequestBody) RequestHash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
 // PostJsonRequestBody defines body for PostJson for application/json ContentType.
 type PostJsonJSONRequestBody PostJsonJSONBody
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(req *http.Request, ctx context.Context) error
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b PostJsonJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
 
 // Doer performs HTTP requests.
 //
@@ -48,6 +104,10 @@ type HttpRequestDoer interface {
 }
 
 // Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
 	// https://api.deepmap.com for example.
@@ -57,9 +117,21 @@ type Client struct {
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
 
-	// A callback for modifying requests which are generated before sending over
-	// the network.
-	RequestEditor RequestEditorFn
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -84,6 +156,29 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	return &client, nil
 }
 
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
 // WithHTTPClient allows overriding the default Doer, which is
 // automatically created using http.Client. This is useful for tests.
 func WithHTTPClient(doer HttpRequestDoer) ClientOption {
@@ -95,174 +190,298 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, editors...)
+		return nil
+	}
+}
+
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
+		return nil
+	}
+}
+
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
 	return func(c *Client) error {
-		c.RequestEditor = fn
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
 		return nil
 	}
 }
 
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// PostBoth request  with any body
-	PostBothWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+	PostBothWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	PostBoth(ctx context.Context, body PostBothJSONRequestBody) (*http.Response, error)
+	PostBoth(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetBoth request
-	GetBoth(ctx context.Context) (*http.Response, error)
+	GetBoth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostHashed request  with any body
+	PostHashedWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostHashed(ctx context.Context, body PostHashedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PostJson request  with any body
-	PostJsonWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+	PostJsonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	PostJson(ctx context.Context, body PostJsonJSONRequestBody) (*http.Response, error)
+	PostJson(ctx context.Context, body PostJsonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetJson request
-	GetJson(ctx context.Context) (*http.Response, error)
+	GetJson(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PostOther request  with any body
-	PostOtherWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+	PostOtherWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetOther request
-	GetOther(ctx context.Context) (*http.Response, error)
+	GetOther(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetJsonWithTrailingSlash request
-	GetJsonWithTrailingSlash(ctx context.Context) (*http.Response, error)
+	GetJsonWithTrailingSlash(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) PostBothWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+func (c *Client) PostBothWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostBothRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) PostBoth(ctx context.Context, body PostBothJSONRequestBody) (*http.Response, error) {
+func (c *Client) PostBoth(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostBothRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetBoth(ctx context.Context) (*http.Response, error) {
+func (c *Client) GetBoth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetBothRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) PostHashedWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostHashedRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) PostJsonWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
-	req, err := NewPostJsonRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostHashed(ctx context.Context, body PostHashedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostHashedRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) PostJsonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostJsonRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) PostJson(ctx context.Context, body PostJsonJSONRequestBody) (*http.Response, error) {
+func (c *Client) PostJson(ctx context.Context, body PostJsonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostJsonRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetJson(ctx context.Context) (*http.Response, error) {
+func (c *Client) GetJson(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetJsonRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, runtime.SecurityRequirements{{"OpenId": {"json.read", "json.admin"}}}, reqEditors)
 }
 
-func (c *Client) PostOtherWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+func (c *Client) PostOtherWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostOtherRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetOther(ctx context.Context) (*http.Response, error) {
+func (c *Client) GetOther(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetOtherRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetJsonWithTrailingSlash(ctx context.Context) (*http.Response, error) {
+func (c *Client) GetJsonWithTrailingSlash(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetJsonWithTrailingSlashRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, runtime.SecurityRequirements{{"OpenId": {"json.read", "json.admin"}}}, reqEditors)
 }
 
 // NewPostBothRequest calls the generic PostBoth builder with application/json body
@@ -319,6 +538,39 @@ func NewGetBothRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewPostHashedRequest calls the generic PostHashed builder with application/json body
+func NewPostHashedRequest(server string, body PostHashedJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostHashedRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostHashedRequestWithBody generates requests for PostHashed with any type of body
+func NewPostHashedRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/with_hash_body"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
 // NewPostJsonRequest calls the generic PostJson builder with application/json body
 func NewPostJsonRequest(server string, body PostJsonJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -509,6 +761,27 @@ func (r getBothResponse) StatusCode() int {
 	return 0
 }
 
+type postHashedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r postHashedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r postHashedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type postJsonResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -615,16 +888,16 @@ func (r getJsonWithTrailingSlashResponse) StatusCode() int {
 }
 
 // PostBothWithBodyWithResponse request with arbitrary body returning *PostBothResponse
-func (c *ClientWithResponses) PostBothWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*postBothResponse, error) {
-	rsp, err := c.PostBothWithBody(ctx, contentType, body)
+func (c *ClientWithResponses) PostBothWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*postBothResponse, error) {
+	rsp, err := c.PostBothWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParsePostBothResponse(rsp)
 }
 
-func (c *ClientWithResponses) PostBothWithResponse(ctx context.Context, body PostBothJSONRequestBody) (*postBothResponse, error) {
-	rsp, err := c.PostBoth(ctx, body)
+func (c *ClientWithResponses) PostBothWithResponse(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*postBothResponse, error) {
+	rsp, err := c.PostBoth(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -632,25 +905,42 @@ func (c *ClientWithResponses) PostBothWithResponse(ctx context.Context, body Pos
 }
 
 // GetBothWithResponse request returning *GetBothResponse
-func (c *ClientWithResponses) GetBothWithResponse(ctx context.Context) (*getBothResponse, error) {
-	rsp, err := c.GetBoth(ctx)
+func (c *ClientWithResponses) GetBothWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*getBothResponse, error) {
+	rsp, err := c.GetBoth(ctx, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseGetBothResponse(rsp)
 }
 
+// PostHashedWithBodyWithResponse request with arbitrary body returning *PostHashedResponse
+func (c *ClientWithResponses) PostHashedWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*postHashedResponse, error) {
+	rsp, err := c.PostHashedWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostHashedResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostHashedWithResponse(ctx context.Context, body PostHashedJSONRequestBody, reqEditors ...RequestEditorFn) (*postHashedResponse, error) {
+	rsp, err := c.PostHashed(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostHashedResponse(rsp)
+}
+
 // PostJsonWithBodyWithResponse request with arbitrary body returning *PostJsonResponse
-func (c *ClientWithResponses) PostJsonWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*postJsonResponse, error) {
-	rsp, err := c.PostJsonWithBody(ctx, contentType, body)
+func (c *ClientWithResponses) PostJsonWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*postJsonResponse, error) {
+	rsp, err := c.PostJsonWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParsePostJsonResponse(rsp)
 }
 
-func (c *ClientWithResponses) PostJsonWithResponse(ctx context.Context, body PostJsonJSONRequestBody) (*postJsonResponse, error) {
-	rsp, err := c.PostJson(ctx, body)
+func (c *ClientWithResponses) PostJsonWithResponse(ctx context.Context, body PostJsonJSONRequestBody, reqEditors ...RequestEditorFn) (*postJsonResponse, error) {
+	rsp, err := c.PostJson(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -658,8 +948,8 @@ func (c *ClientWithResponses) PostJsonWithResponse(ctx context.Context, body Pos
 }
 
 // GetJsonWithResponse request returning *GetJsonResponse
-func (c *ClientWithResponses) GetJsonWithResponse(ctx context.Context) (*getJsonResponse, error) {
-	rsp, err := c.GetJson(ctx)
+func (c *ClientWithResponses) GetJsonWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*getJsonResponse, error) {
+	rsp, err := c.GetJson(ctx, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -667,8 +957,8 @@ func (c *ClientWithResponses) GetJsonWithResponse(ctx context.Context) (*getJson
 }
 
 // PostOtherWithBodyWithResponse request with arbitrary body returning *PostOtherResponse
-func (c *ClientWithResponses) PostOtherWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*postOtherResponse, error) {
-	rsp, err := c.PostOtherWithBody(ctx, contentType, body)
+func (c *ClientWithResponses) PostOtherWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*postOtherResponse, error) {
+	rsp, err := c.PostOtherWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -676,8 +966,8 @@ func (c *ClientWithResponses) PostOtherWithBodyWithResponse(ctx context.Context,
 }
 
 // GetOtherWithResponse request returning *GetOtherResponse
-func (c *ClientWithResponses) GetOtherWithResponse(ctx context.Context) (*getOtherResponse, error) {
-	rsp, err := c.GetOther(ctx)
+func (c *ClientWithResponses) GetOtherWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*getOtherResponse, error) {
+	rsp, err := c.GetOther(ctx, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -685,8 +975,8 @@ func (c *ClientWithResponses) GetOtherWithResponse(ctx context.Context) (*getOth
 }
 
 // GetJsonWithTrailingSlashWithResponse request returning *GetJsonWithTrailingSlashResponse
-func (c *ClientWithResponses) GetJsonWithTrailingSlashWithResponse(ctx context.Context) (*getJsonWithTrailingSlashResponse, error) {
-	rsp, err := c.GetJsonWithTrailingSlash(ctx)
+func (c *ClientWithResponses) GetJsonWithTrailingSlashWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*getJsonWithTrailingSlashResponse, error) {
+	rsp, err := c.GetJsonWithTrailingSlash(ctx, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -731,6 +1021,25 @@ func ParseGetBothResponse(rsp *http.Response) (*getBothResponse, error) {
 	return response, nil
 }
 
+// ParsePostHashedResponse parses an HTTP response from a PostHashedWithResponse call
+func ParsePostHashedResponse(rsp *http.Response) (*postHashedResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &postHashedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
 // ParsePostJsonResponse parses an HTTP response from a PostJsonWithResponse call
 func ParsePostJsonResponse(rsp *http.Response) (*postJsonResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -835,6 +1144,9 @@ type ServerInterface interface {
 	// (GET /with_both_responses)
 	GetBoth(ctx echo.Context) error
 
+	// (POST /with_hash_body)
+	PostHashed(ctx echo.Context) error
+
 	// (POST /with_json_body)
 	PostJson(ctx echo.Context) error
 
@@ -874,6 +1186,15 @@ func (w *ServerInterfaceWrapper) GetBoth(ctx echo.Context) error {
 	return err
 }
 
+// PostHashed converts echo context to params.
+func (w *ServerInterfaceWrapper) PostHashed(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostHashed(ctx)
+	return err
+}
+
 // PostJson converts echo context to params.
 func (w *ServerInterfaceWrapper) PostJson(ctx echo.Context) error {
 	var err error
@@ -886,6 +1207,9 @@ func (w *ServerInterfaceWrapper) PostJson(ctx echo.Context) error {
 // GetJson converts echo context to params.
 func (w *ServerInterfaceWrapper) GetJson(ctx echo.Context) error {
 	var err error
+	if err := runtime.CheckSecurity(ctx.Request(), runtime.SecurityRequirements{{"OpenId": {"json.read", "json.admin"}}}); err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, runtime.Message(ctx.Request(), runtime.MsgSecurityUnsatisfied)).SetInternal(err)
+	}
 
 	ctx.Set("OpenId.Scopes", []string{"json.read", "json.admin"})
 
@@ -915,6 +1239,9 @@ func (w *ServerInterfaceWrapper) GetOther(ctx echo.Context) error {
 // GetJsonWithTrailingSlash converts echo context to params.
 func (w *ServerInterfaceWrapper) GetJsonWithTrailingSlash(ctx echo.Context) error {
 	var err error
+	if err := runtime.CheckSecurity(ctx.Request(), runtime.SecurityRequirements{{"OpenId": {"json.read", "json.admin"}}}); err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, runtime.Message(ctx.Request(), runtime.MsgSecurityUnsatisfied)).SetInternal(err)
+	}
 
 	ctx.Set("OpenId.Scopes", []string{"json.read", "json.admin"})
 
@@ -942,6 +1269,7 @@ func RegisterHandlers(router interface {
 
 	router.POST("/with_both_bodies", wrapper.PostBoth)
 	router.GET("/with_both_responses", wrapper.GetBoth)
+	router.POST("/with_hash_body", wrapper.PostHashed)
 	router.POST("/with_json_body", wrapper.PostJson)
 	router.GET("/with_json_response", wrapper.GetJson)
 	router.POST("/with_other_body", wrapper.PostOther)
@@ -950,18 +1278,41 @@ func RegisterHandlers(router interface {
 
 }
 
+// OperationsManifest describes the operations of this API, and the spec which
+// they were generated from.
+var OperationsManifest = runtime.Manifest{
+	SpecVersion: "1.0.0",
+	SpecHash:    "sha256:b7a48267cc5bb23d817ce6ac720f0b65f7b8aae8aed953b4dba54f81b67f2a64",
+	Operations: []runtime.ManifestOperation{
+		{OperationID: "PostBoth", Method: "POST", Path: "/with_both_bodies"},
+		{OperationID: "GetBoth", Method: "GET", Path: "/with_both_responses"},
+		{OperationID: "PostHashed", Method: "POST", Path: "/with_hash_body"},
+		{OperationID: "PostJson", Method: "POST", Path: "/with_json_body"},
+		{OperationID: "GetJson", Method: "GET", Path: "/with_json_response"},
+		{OperationID: "PostOther", Method: "POST", Path: "/with_other_body"},
+		{OperationID: "GetOther", Method: "GET", Path: "/with_other_response"},
+		{OperationID: "GetJsonWithTrailingSlash", Method: "GET", Path: "/with_trailing_slash/"},
+	},
+}
+
+// OperationsManifestHandler returns a handler which serves OperationsManifest
+// as JSON, for example on GET /.well-known/operations.
+func OperationsManifestHandler() http.Handler {
+	return runtime.ManifestHandler(OperationsManifest)
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xUzY4SQRB+lUnpcWRYvc1RD2ZNFCMkHpBsmp6C7s1Md1tV7GZCeHdTDcgQN8jBNXsh",
-	"1fRXle+nerZgY5diwCAM9RbYOuxMLqe5nCzv0YqeE8WEJB7z7coTyxfToR6kTwg1sJAPa9iVQLF96kJv",
-	"8OfGEzZQz/eocjBqsVOID6uozQ2yJZ/ExwA1zJznQpCFi0eH4pAKcVh8aD0GKUxoDuV3L+4bcoqBkQtD",
-	"WKwxIBnBprCRCK20/Y8AJbTeYuDMM2Qh8Pl2puzFi9KHGbIUU6QHJCjhAYn3VG5G49FYgTFhMMlDDe9G",
-	"49ENlJCMuOxP9ejF3S1j/mkOpqXI2Uo10qiu2wZq+BpZ3kdxsHcH9dT0irMxCIbcYlJqvc1N1T0rjWNY",
-	"Wr0mXEENr6pTmtUhyuosR/V3OCpaQXnDQmi685GrSJ0RqGHpg6Eeyj/CPEtTaIP5r4FwOsag89b4hPSP",
-	"eFI+wL4dj1+q5oFGpaTh9pej/aTM/0u0lwLJZI8mX8rjN91nzENpMdoNeemhnm9hkjATmIPOHRGaBsp9",
-	"bZrOB1jsFictUV//Fc5PFHe19c/1FPZsr7H+xPey9/9qgYWMb31Y33Fr2FV/2wr9ss4OLVPteKFrstv9",
-	"CgAA//91LTf11gYAAA==",
+	"H4sIAAAAAAAC/8xVT2/TThD9Ktb8fkcTp3DzEQ5QJAgikTiEqNrYk+xW9u4yM2llRf7uaDZpnYgopAKq",
+	"XqxZzx+/eW9mvYUqtDF49MJQboEri61J5jSZk+UtVqLnSCEiicPkXTli+Wxa1IN0EaEEFnJ+DX0OFJpT",
+	"DvXgj40jrKGc76Lyg1KLXkOcXwVNrpErclFc8FDCzDrOBFk4u7coFikTi9m7xqGXzPh6b35zYr8ix+AZ",
+	"OTOE2Ro9khGssyoQYSVN991DDo2r0HPC6VMj8Ol6pujFicKHGbJkU6Q7JMjhDol3UK5G49FYA0NEb6KD",
+	"Et6MxqMryCEasYmf4t6JvVmG9Kj3pMXAiUol0mhf1zWU8CWwvA1iYccO6qnuNK4KXtCnFBNj46qUVNxy",
+	"8INYav1PuIIS/isGNYudl4sjHZXfw1KhEpRXLISmPS65CtQagRKWzhvqIP9FzCM1hTaYXuyZh9JvmkZj",
+	"Dpg48G5hjSe4eI8DFQexr8fjl0pCP/RoDSe1u/NafzBssf4DtY83Ub96cgn96e3sH5sID3Q8SUnFc0GX",
+	"HxX2s0z009E/eM+N4SP+fziGCoux2pCTDsr5FiYRE4A5aN0RodE5SbapW+dh0S+GXoLeghdIMdG4i7V4",
+	"tithB/8SLYYGzovxtxZZyLjG+fUNN4Zt8bsx0V/ObJ8y1YwXOjd9/3MAkXjfsu8HAAA=",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
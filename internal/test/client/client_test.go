@@ -0,0 +1,394 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/codegen"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
+func TestProvenance(t *testing.T) {
+	swagger, err := GetSwagger()
+	require.NoError(t, err)
+	specHash, err := codegen.SpecHash(swagger)
+	require.NoError(t, err)
+	assert.Equal(t, specHash, SpecHash)
+	assert.Equal(t, swagger.Info.Version, SpecVersion)
+	assert.Equal(t, "-generate=types,client,server,spec,provenance,manifest", GeneratorOptions)
+}
+
+func TestOperationsManifest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	OperationsManifestHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/operations", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var manifest runtime.Manifest
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &manifest))
+	assert.Equal(t, SpecHash, manifest.SpecHash)
+	assert.Len(t, manifest.Operations, 8)
+	assert.Contains(t, manifest.Operations, runtime.ManifestOperation{
+		OperationID: "GetJson",
+		Method:      "GET",
+		Path:        "/with_json_response",
+	})
+}
+
+func TestClientClone(t *testing.T) {
+	client, err := NewClient("http://example.com", WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Client", "original")
+		return nil
+	}))
+	require.NoError(t, err)
+
+	clone, err := client.Clone(WithBaseURL("http://other.example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://other.example.com/", clone.Server)
+	assert.Equal(t, "http://example.com", client.Server)
+	assert.Equal(t, client.Client, clone.Client)
+	assert.Len(t, clone.RequestEditors, 1)
+
+	_, err = client.Clone(WithBaseURL(":"))
+	assert.Error(t, err)
+}
+
+// TestClientConcurrentUse calls a client from many goroutines, while others
+// derive clients from it with Clone. Run it with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Client", r.Header.Get("X-Client"))
+		_, _ = w.Write([]byte(`{"role": "admin", "firstName": "Alex"}`))
+	}))
+	defer server.Close()
+
+	editor := func(name string) ClientOption {
+		return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Client", name)
+			return nil
+		})
+	}
+	client, err := NewClient(server.URL, editor("original"))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rsp, err := client.GetJson(context.Background())
+			if err != nil {
+				errs <- err
+				return
+			}
+			rsp.Body.Close()
+			if got := rsp.Header.Get("X-Client"); got != "original" {
+				errs <- assert.AnError
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			clone, err := client.Clone(editor("clone"))
+			if err != nil {
+				errs <- err
+				return
+			}
+			rsp, err := clone.GetJson(context.Background())
+			if err != nil {
+				errs <- err
+				return
+			}
+			rsp.Body.Close()
+			if got := rsp.Header.Get("X-Client"); got != "clone" {
+				errs <- assert.AnError
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestRequestEditors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trail", r.Header.Get("X-Trail"))
+	}))
+	defer server.Close()
+
+	mark := func(name string) RequestEditorFn {
+		return func(ctx context.Context, req *http.Request) error {
+			trail := req.Header.Get("X-Trail")
+			if trail != "" {
+				trail += ","
+			}
+			req.Header.Set("X-Trail", trail+name)
+			return nil
+		}
+	}
+	trail := func(client ClientInterface, editors ...RequestEditorFn) string {
+		rsp, err := client.GetJson(context.Background(), editors...)
+		require.NoError(t, err)
+		rsp.Body.Close()
+		return rsp.Header.Get("X-Trail")
+	}
+
+	client, err := NewClient(server.URL, WithRequestEditors(mark("auth"), mark("tracing")), WithRequestEditorFn(mark("headers")))
+	require.NoError(t, err)
+	assert.Equal(t, "auth,tracing,headers", trail(client))
+	assert.Equal(t, "auth,tracing,headers,call", trail(client, mark("call")))
+
+	// Editors added to clones don't end up in the original, or in each other.
+	first, err := client.Clone(WithRequestEditorFn(mark("first")))
+	require.NoError(t, err)
+	second, err := client.Clone(WithRequestEditorFn(mark("second")))
+	require.NoError(t, err)
+	assert.Equal(t, "auth,tracing,headers,first", trail(first))
+	assert.Equal(t, "auth,tracing,headers,second", trail(second))
+	assert.Equal(t, "auth,tracing,headers", trail(client))
+
+	// The first error aborts the request.
+	failing := func(ctx context.Context, req *http.Request) error {
+		return assert.AnError
+	}
+	_, err = client.GetJson(context.Background(), failing, mark("never"))
+	assert.Equal(t, assert.AnError, err)
+}
+
+func TestResponseEditors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"firstName":"Alex"}}`))
+	}))
+	defer server.Close()
+
+	var trail []string
+	mark := func(name string) ResponseEditorFn {
+		return func(ctx context.Context, rsp *http.Response) error {
+			trail = append(trail, name)
+			return nil
+		}
+	}
+	unwrap := func(ctx context.Context, rsp *http.Response) error {
+		var envelope struct {
+			Data json.RawMessage `json:"data"`
+		}
+		err := json.NewDecoder(rsp.Body).Decode(&envelope)
+		rsp.Body.Close()
+		if err != nil {
+			return err
+		}
+		rsp.Body = ioutil.NopCloser(bytes.NewReader(envelope.Data))
+		return nil
+	}
+
+	client, err := NewClient(server.URL, WithResponseEditors(mark("log"), unwrap), WithResponseEditorFn(mark("metrics")))
+	require.NoError(t, err)
+	rsp, err := client.GetJson(context.Background(), EditResponse(mark("call")), EditResponse(mark("other")))
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(rsp.Body)
+	rsp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, `{"firstName":"Alex"}`, string(body))
+	assert.Equal(t, []string{"log", "metrics", "call", "other"}, trail)
+
+	// Editors added to clones don't end up in the original.
+	trail = nil
+	clone, err := client.Clone(WithResponseEditorFn(mark("clone")))
+	require.NoError(t, err)
+	rsp, err = client.GetJson(context.Background())
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, []string{"log", "metrics"}, trail)
+	assert.Len(t, clone.ResponseEditors, 4)
+
+	// The first error fails the call.
+	failing := func(ctx context.Context, rsp *http.Response) error {
+		return assert.AnError
+	}
+	trail = nil
+	_, err = client.GetJson(context.Background(), EditResponse(failing, mark("never")))
+	assert.Equal(t, assert.AnError, err)
+	assert.Equal(t, []string{"log", "metrics"}, trail)
+}
+
+func TestWarmup(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	authorize := func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer token")
+		return nil
+	}
+	client, err := NewClient(server.URL+"/api/", WithRequestEditorFn(authorize))
+	require.NoError(t, err)
+
+	require.NoError(t, client.Warmup(context.Background(), 3))
+	assert.Equal(t, []string{"HEAD /api/ Bearer token", "HEAD /api/ Bearer token", "HEAD /api/ Bearer token"}, received)
+
+	received = nil
+	require.NoError(t, client.Warmup(context.Background(), 1, runtime.WithWarmupRequest(http.MethodOptions, "health")))
+	assert.Equal(t, []string{"OPTIONS /api/health Bearer token"}, received)
+
+	server.Close()
+	assert.Error(t, client.Warmup(context.Background(), 2))
+}
+
+func TestRequestBodyHash(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+	body := PostJsonJSONRequestBody{FirstName: "Alex", Role: "admin"}
+	rsp, err := client.PostJson(context.Background(), body)
+	require.NoError(t, err)
+	rsp.Body.Close()
+
+	// The hash is the digest of exactly what was sent.
+	sum := sha256.Sum256(received)
+	hash, err := body.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+
+	other, err := PostJsonJSONRequestBody{FirstName: "Alex", Role: "user"}.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestRequestBodyHashField(t *testing.T) {
+	// The body has a hash property, so its method is named RequestHash.
+	hash := "abc"
+	body := PostHashedJSONRequestBody{Hash: &hash}
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	requestHash, err := body.RequestHash()
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), requestHash)
+}
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stalledResponse returns a response whose body sends a few bytes, then
+// stalls, as a slow upstream would.
+func stalledResponse() *http.Response {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte(`{"firstName":`))
+	}()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       pr,
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	// A custom Doer which ignores the context of requests.
+	stalled := doerFunc(func(req *http.Request) (*http.Response, error) {
+		return stalledResponse(), nil
+	})
+	wait := func(t *testing.T, errs <-chan error) error {
+		select {
+		case err := <-errs:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("the call wasn't aborted")
+			return nil
+		}
+	}
+
+	t.Run("before sending", func(t *testing.T) {
+		called := false
+		client, err := NewClient("http://example.com", WithHTTPClient(doerFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			return nil, errors.New("unexpected request")
+		})))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = client.GetJson(ctx)
+		assert.Equal(t, context.Canceled, err)
+		assert.False(t, called)
+	})
+
+	t.Run("while parsing the response", func(t *testing.T) {
+		client, err := NewClientWithResponses("http://example.com", WithHTTPClient(stalled))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			_, err := client.GetJsonWithResponse(ctx)
+			errs <- err
+		}()
+		cancel()
+		assert.Equal(t, context.Canceled, wait(t, errs))
+	})
+
+	t.Run("while reading the response", func(t *testing.T) {
+		client, err := NewClient("http://example.com", WithHTTPClient(stalled))
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		rsp, err := client.GetJson(ctx)
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+		_, err = ioutil.ReadAll(rsp.Body)
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("while sending the body", func(t *testing.T) {
+		// The Doer reads the body, which the caller produces slowly.
+		client, err := NewClient("http://example.com", WithHTTPClient(doerFunc(func(req *http.Request) (*http.Response, error) {
+			if _, err := ioutil.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})))
+		require.NoError(t, err)
+		body, w := io.Pipe()
+		defer w.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			_, err := client.PostJsonWithBody(ctx, "application/json", body)
+			errs <- err
+		}()
+		_, err = w.Write([]byte(`{"firstName":`))
+		require.NoError(t, err)
+		cancel()
+		assert.Equal(t, context.Canceled, wait(t, errs))
+	})
+}
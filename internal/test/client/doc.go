@@ -1,3 +1,3 @@
 package client
 
-//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=client -o client.gen.go client.yaml
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=client --generate=types,client,server,spec,provenance,manifest -o client.gen.go client.yaml
@@ -123,6 +123,12 @@ type BodyWithAddPropsJSONBody_Inner struct {
 // BodyWithAddPropsRequestBody defines body for BodyWithAddProps for application/json ContentType.
 type BodyWithAddPropsJSONRequestBody BodyWithAddPropsJSONBody
 
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b BodyWithAddPropsJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
 // Getter for additional properties for ParamsWithAddPropsParams_P1. Returns the specified
 // element and whether it was found
 func (a ParamsWithAddPropsParams_P1) Get(fieldName string) (value interface{}, found bool) {
@@ -719,8 +725,16 @@ func (a AdditionalPropertiesObject5) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(req *http.Request, ctx context.Context) error
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
 
 // Doer performs HTTP requests.
 //
@@ -730,6 +744,10 @@ type HttpRequestDoer interface {
 }
 
 // Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
 	// https://api.deepmap.com for example.
@@ -739,9 +757,21 @@ type Client struct {
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
 
-	// A callback for modifying requests which are generated before sending over
-	// the network.
-	RequestEditor RequestEditorFn
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -766,6 +796,29 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	return &client, nil
 }
 
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
 // WithHTTPClient allows overriding the default Doer, which is
 // automatically created using http.Client. This is useful for tests.
 func WithHTTPClient(doer HttpRequestDoer) ClientOption {
@@ -777,67 +830,212 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, editors...)
+		return nil
+	}
+}
+
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
 	return func(c *Client) error {
-		c.RequestEditor = fn
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
 		return nil
 	}
 }
 
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// ParamsWithAddProps request
-	ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams) (*http.Response, error)
+	ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// BodyWithAddProps request  with any body
-	BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+	BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody) (*http.Response, error)
+	BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams) (*http.Response, error) {
+func (c *Client) ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewParamsWithAddPropsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+func (c *Client) BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewBodyWithAddPropsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody) (*http.Response, error) {
+func (c *Client) BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewBodyWithAddPropsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
 // NewParamsWithAddPropsRequest generates requests for ParamsWithAddProps
@@ -995,8 +1193,8 @@ func (r bodyWithAddPropsResponse) StatusCode() int {
 }
 
 // ParamsWithAddPropsWithResponse request returning *ParamsWithAddPropsResponse
-func (c *ClientWithResponses) ParamsWithAddPropsWithResponse(ctx context.Context, params *ParamsWithAddPropsParams) (*paramsWithAddPropsResponse, error) {
-	rsp, err := c.ParamsWithAddProps(ctx, params)
+func (c *ClientWithResponses) ParamsWithAddPropsWithResponse(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*paramsWithAddPropsResponse, error) {
+	rsp, err := c.ParamsWithAddProps(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1004,16 +1202,16 @@ func (c *ClientWithResponses) ParamsWithAddPropsWithResponse(ctx context.Context
 }
 
 // BodyWithAddPropsWithBodyWithResponse request with arbitrary body returning *BodyWithAddPropsResponse
-func (c *ClientWithResponses) BodyWithAddPropsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*bodyWithAddPropsResponse, error) {
-	rsp, err := c.BodyWithAddPropsWithBody(ctx, contentType, body)
+func (c *ClientWithResponses) BodyWithAddPropsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*bodyWithAddPropsResponse, error) {
+	rsp, err := c.BodyWithAddPropsWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseBodyWithAddPropsResponse(rsp)
 }
 
-func (c *ClientWithResponses) BodyWithAddPropsWithResponse(ctx context.Context, body BodyWithAddPropsJSONRequestBody) (*bodyWithAddPropsResponse, error) {
-	rsp, err := c.BodyWithAddProps(ctx, body)
+func (c *ClientWithResponses) BodyWithAddPropsWithResponse(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*bodyWithAddPropsResponse, error) {
+	rsp, err := c.BodyWithAddProps(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1083,24 +1281,24 @@ func (w *ServerInterfaceWrapper) ParamsWithAddProps(ctx echo.Context) error {
 	if paramValue := ctx.QueryParam("p1"); paramValue != "" {
 
 	} else {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument p1 is required, but not found"))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgRequiredQueryParam, "p1"), "p1", "This parameter has additional properties"))
 	}
 
 	err = runtime.BindQueryParameter("simple", true, true, "p1", ctx.QueryParams(), &params.P1)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p1: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "p1", err), "p1", "This parameter has additional properties"))
 	}
 
 	// ------------- Required query parameter "p2" -------------
 	if paramValue := ctx.QueryParam("p2"); paramValue != "" {
 
 	} else {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument p2 is required, but not found"))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgRequiredQueryParam, "p2"), "p2", "This parameter has an anonymous inner property which needs to be turned into a proper type for additionalProperties to work"))
 	}
 
 	err = runtime.BindQueryParameter("form", true, true, "p2", ctx.QueryParams(), &params.P2)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p2: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "p2", err), "p2", "This parameter has an anonymous inner property which needs to be turned into a proper type for additionalProperties to work"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -0,0 +1,91 @@
+package compose
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backend serves the sub-resources which dashboards are composed of.
+type backend struct {
+	ordersCancelled chan struct{}
+}
+
+func (b *backend) GetUser(ctx echo.Context, userId string) error {
+	if userId != "u-1" {
+		return ctx.NoContent(http.StatusNotFound)
+	}
+	return ctx.JSON(http.StatusOK, User{Id: userId, Name: "Alex"})
+}
+
+func (b *backend) ListOrders(ctx echo.Context, params ListOrdersParams) error {
+	if params.CustomerId != "u-1" {
+		// Wait for the failure of getUser to cancel the call.
+		<-ctx.Request().Context().Done()
+		close(b.ordersCancelled)
+		return ctx.Request().Context().Err()
+	}
+	orders := []Order{{Id: "o-1", Status: "shipped"}, {Id: "o-2", Status: "pending"}}
+	if params.Status != nil {
+		var filtered []Order
+		for _, order := range orders {
+			if order.Status == *params.Status {
+				filtered = append(filtered, order)
+			}
+		}
+		orders = filtered
+	}
+	return ctx.JSON(http.StatusOK, orders)
+}
+
+func (b *backend) GetDashboard(ctx echo.Context, userId string, params GetDashboardParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+func newBackend(t *testing.T) (*backend, *ClientWithResponses, func()) {
+	b := &backend{ordersCancelled: make(chan struct{})}
+	e := echo.New()
+	RegisterHandlers(e, b)
+	srv := httptest.NewServer(e)
+	client, err := NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+	return b, client, srv.Close
+}
+
+func TestCompose(t *testing.T) {
+	_, client, closeBackend := newBackend(t)
+	defer closeBackend()
+
+	dashboard, err := client.ComposeGetDashboard(context.Background(), "u-1", &GetDashboardParams{})
+	require.NoError(t, err)
+	assert.Equal(t, User{Id: "u-1", Name: "Alex"}, dashboard.Owner)
+	require.NotNil(t, dashboard.Orders)
+	assert.Len(t, *dashboard.Orders, 2)
+
+	// The parameters of getDashboard are passed on.
+	shipped := "shipped"
+	dashboard, err = client.ComposeGetDashboard(context.Background(), "u-1", &GetDashboardParams{Status: &shipped})
+	require.NoError(t, err)
+	assert.Equal(t, []Order{{Id: "o-1", Status: "shipped"}}, *dashboard.Orders)
+}
+
+func TestComposeError(t *testing.T) {
+	b, client, closeBackend := newBackend(t)
+	defer closeBackend()
+
+	_, err := client.ComposeGetDashboard(context.Background(), "u-2", &GetDashboardParams{})
+	var fanOutErr *runtime.FanOutError
+	require.True(t, errors.As(err, &fanOutErr))
+	assert.Equal(t, "owner", fanOutErr.Name)
+	assert.EqualError(t, err, "owner: unexpected response to getUser: 404 Not Found")
+
+	// The call to listOrders was cancelled.
+	<-b.ordersCancelled
+}
@@ -0,0 +1,3 @@
+package compose
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=compose --generate=types,client,server -o compose.gen.go compose.yaml
@@ -0,0 +1,686 @@
+// Package datetime provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package datetime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Event defines model for Event.
+type Event struct {
+	At      DateTime      `json:"at"`
+	Created *Timestamp    `json:"created,omitempty"`
+	Day     *EventDayTime `json:"day,omitempty"`
+}
+
+// Timestamp defines model for Timestamp.
+type Timestamp = DateTime
+
+// GetEventParams defines parameters for GetEvent.
+type GetEventParams struct {
+	Since *DateTime                `json:"since,omitempty"`
+	Until *GetEventParamsUntilTime `json:"until,omitempty"`
+}
+
+// DateTime is a date-time which is marshaled with the layout "2006-01-02T15:04:05Z07:00",
+// after conversion to UTC.
+type DateTime struct {
+	time.Time
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DateTime) MarshalText() ([]byte, error) {
+	return []byte(t.Time.UTC().Format("2006-01-02T15:04:05Z07:00")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *DateTime) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse("2006-01-02T15:04:05Z07:00", string(data))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *DateTime) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(text))
+}
+
+// EventDayTime is a date-time which is marshaled with the layout "2006-01-02",
+// after conversion to UTC.
+type EventDayTime struct {
+	time.Time
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t EventDayTime) MarshalText() ([]byte, error) {
+	return []byte(t.Time.UTC().Format("2006-01-02")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *EventDayTime) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse("2006-01-02", string(data))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t EventDayTime) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *EventDayTime) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(text))
+}
+
+// GetEventParamsUntilTime is a date-time which is marshaled with the layout "2006-01-02 15:04",
+// after conversion to UTC.
+type GetEventParamsUntilTime struct {
+	time.Time
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t GetEventParamsUntilTime) MarshalText() ([]byte, error) {
+	return []byte(t.Time.UTC().Format("2006-01-02 15:04")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *GetEventParamsUntilTime) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse("2006-01-02 15:04", string(data))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t GetEventParamsUntilTime) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *GetEventParamsUntilTime) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(text))
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, editors...)
+		return nil
+	}
+}
+
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
+		return nil
+	}
+}
+
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetEvent request
+	GetEvent(ctx context.Context, at DateTime, params *GetEventParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetEvent(ctx context.Context, at DateTime, params *GetEventParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEventRequest(c.Server, at, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+// NewGetEventRequest generates requests for GetEvent
+func NewGetEventRequest(server string, at DateTime, params *GetEventParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", false, "at", at)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/events/%s", pathParam0))
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.Since != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "since", *params.Since); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Until != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "until", *params.Until); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+type getEventResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Event
+}
+
+// Status returns HTTPResponse.Status
+func (r getEventResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getEventResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetEventWithResponse request returning *GetEventResponse
+func (c *ClientWithResponses) GetEventWithResponse(ctx context.Context, at DateTime, params *GetEventParams, reqEditors ...RequestEditorFn) (*getEventResponse, error) {
+	rsp, err := c.GetEvent(ctx, at, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEventResponse(rsp)
+}
+
+// ParseGetEventResponse parses an HTTP response from a GetEventWithResponse call
+func ParseGetEventResponse(rsp *http.Response) (*getEventResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getEventResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		response.JSON200 = &Event{}
+		if err := json.Unmarshal(bodyBytes, response.JSON200); err != nil {
+			return nil, err
+		}
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /events/{at})
+	GetEvent(ctx echo.Context, at DateTime, params GetEventParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetEvent converts echo context to params.
+func (w *ServerInterfaceWrapper) GetEvent(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "at" -------------
+	var at DateTime
+
+	err = runtime.BindStyledParameter("simple", false, "at", ctx.Param("at"), &at)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "at", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventParams
+	// ------------- Optional query parameter "since" -------------
+	if paramValue := ctx.QueryParam("since"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "since", err))
+	}
+
+	// ------------- Optional query parameter "until" -------------
+	if paramValue := ctx.QueryParam("until"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "until", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetEvent(ctx, at, params)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}, si ServerInterface) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET("/events/:at", wrapper.GetEvent)
+
+}
+
+// NewInMemoryClient creates a new Client which passes its requests directly to
+// the handlers of si, without going through the network. Parameters and
+// bodies are still marshaled and bound by the generated client and server
+// code, which makes this useful for fast integration tests, and for composing
+// services generated from the same spec in a single process.
+func NewInMemoryClient(si ServerInterface, opts ...ClientOption) (*Client, error) {
+	e := echo.New()
+	RegisterHandlers(e, si)
+	handler := http.Handler(e)
+	opts = append([]ClientOption{WithHTTPClient(runtime.NewHandlerDoer(handler))}, opts...)
+	return NewClient("http://in-memory", opts...)
+}
+
+// NewInMemoryClientWithResponses creates a new ClientWithResponses on top of
+// NewInMemoryClient.
+func NewInMemoryClientWithResponses(si ServerInterface, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewInMemoryClient(si, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
@@ -0,0 +1,75 @@
+package datetime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cet = time.FixedZone("CET", 3600)
+
+type testServer struct {
+	at     DateTime
+	params GetEventParams
+}
+
+func (s *testServer) GetEvent(ctx echo.Context, at DateTime, params GetEventParams) error {
+	s.at = at
+	s.params = params
+	return ctx.JSON(http.StatusOK, Event{At: at})
+}
+
+func TestMarshalEvent(t *testing.T) {
+	event := Event{
+		At:      DateTime{time.Date(2020, 1, 2, 3, 4, 5, 678, cet)},
+		Created: &Timestamp{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Day:     &EventDayTime{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	buf, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"at":"2020-01-02T02:04:05Z","created":"2020-01-01T00:00:00Z","day":"2020-01-02"}`, string(buf))
+
+	var decoded Event
+	err = json.Unmarshal([]byte(`{"at":"2020-01-02T03:04:05+01:00","day":"2020-01-02"}`), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 2, 4, 5, 0, time.UTC), decoded.At.Time)
+	assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), decoded.Day.Time)
+
+	// Sub-second precision is accepted, but not sent back
+	err = json.Unmarshal([]byte(`{"at":"2020-01-02T03:04:05.678+01:00"}`), &decoded)
+	require.NoError(t, err)
+	buf, err = json.Marshal(decoded.At)
+	require.NoError(t, err)
+	assert.Equal(t, `"2020-01-02T02:04:05Z"`, string(buf))
+}
+
+func TestDateTimeParameters(t *testing.T) {
+	var ts testServer
+	client, err := NewInMemoryClientWithResponses(&ts)
+	require.NoError(t, err)
+
+	at := DateTime{time.Date(2020, 1, 2, 3, 4, 5, 678, cet)}
+	params := GetEventParams{
+		Since: &DateTime{time.Date(2019, 12, 31, 23, 0, 0, 0, time.UTC)},
+		Until: &GetEventParamsUntilTime{time.Date(2020, 2, 1, 12, 30, 0, 0, cet)},
+	}
+	rsp, err := client.GetEventWithResponse(context.Background(), at, &params)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+
+	expectedAt := time.Date(2020, 1, 2, 2, 4, 5, 0, time.UTC)
+	assert.Equal(t, expectedAt, ts.at.Time)
+	require.NotNil(t, ts.params.Since)
+	assert.Equal(t, params.Since.Time, ts.params.Since.Time)
+	require.NotNil(t, ts.params.Until)
+	assert.Equal(t, time.Date(2020, 2, 1, 11, 30, 0, 0, time.UTC), ts.params.Until.Time)
+
+	require.NotNil(t, rsp.JSON200)
+	assert.Equal(t, expectedAt, rsp.JSON200.At.Time)
+}
@@ -0,0 +1,3 @@
+package datetime
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=datetime --generate=types,client,server,in-memory-client --date-time-utc --date-time-layout=RFC3339 -o datetime.gen.go datetime.yaml
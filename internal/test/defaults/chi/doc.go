@@ -0,0 +1,3 @@
+package chi
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=chi --generate=types,chi-server -o chi.gen.go ../defaults.yaml
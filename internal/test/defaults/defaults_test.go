@@ -0,0 +1,110 @@
+package defaults
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/internal/test/defaults/chi"
+)
+
+type echoServer struct {
+	params ListPetsParams
+}
+
+func (s *echoServer) ListPets(ctx echo.Context, params ListPetsParams) error {
+	s.params = params
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+type chiServer struct {
+	params chi.ListPetsParams
+}
+
+func (s *chiServer) ListPets(w http.ResponseWriter, r *http.Request) {
+	s.params = *chi.ParamsForListPets(r.Context())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestDefaultsAndEnums(t *testing.T) {
+	es := &echoServer{}
+	e := echo.New()
+	RegisterHandlers(e, es)
+	cs := &chiServer{}
+
+	servers := map[string]struct {
+		handler http.Handler
+		// params returns the parameters of the last call, as the echo types
+		params func() ListPetsParams
+	}{
+		"echo": {e, func() ListPetsParams { return es.params }},
+		"chi": {chi.Handler(cs), func() ListPetsParams {
+			return ListPetsParams{
+				Status: cs.params.Status,
+				Limit:  cs.params.Limit,
+				Ratio:  cs.params.Ratio,
+				Tags:   cs.params.Tags,
+				Sort:   cs.params.Sort,
+			}
+		}},
+	}
+	for name, server := range servers {
+		get := func(t *testing.T, query string) (int, string) {
+			rec := httptest.NewRecorder()
+			server.handler.ServeHTTP(rec, httptest.NewRequest("GET", "/pets"+query, nil))
+			body, err := ioutil.ReadAll(rec.Body)
+			require.NoError(t, err)
+			return rec.Code, string(body)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Run("defaults", func(t *testing.T) {
+				code, _ := get(t, "")
+				require.Equal(t, http.StatusNoContent, code)
+				params := server.params()
+				require.NotNil(t, params.Status)
+				assert.Equal(t, "available", *params.Status)
+				require.NotNil(t, params.Limit)
+				assert.Equal(t, 20, *params.Limit)
+				require.NotNil(t, params.Ratio)
+				assert.Equal(t, float32(0.5), *params.Ratio)
+				require.NotNil(t, params.Tags)
+				assert.Equal(t, []string{"cat"}, *params.Tags)
+				// Parameters without a default stay nil
+				assert.Nil(t, params.Sort)
+			})
+
+			t.Run("allowed values", func(t *testing.T) {
+				code, _ := get(t, "?status=sold&limit=50&tags=cat&tags=dog&sort=age")
+				require.Equal(t, http.StatusNoContent, code)
+				params := server.params()
+				assert.Equal(t, "sold", *params.Status)
+				assert.Equal(t, 50, *params.Limit)
+				assert.Equal(t, []string{"cat", "dog"}, *params.Tags)
+				assert.Equal(t, "age", *params.Sort)
+			})
+
+			rejected := []struct {
+				query   string
+				message string
+			}{
+				{"?status=lost", `Parameter status can't be 'lost', allowed values are: available, pending, sold`},
+				{"?limit=15", `Parameter limit can't be '15', allowed values are: 10, 20, 50`},
+				{"?tags=cat&tags=bird", `Parameter tags can't be 'bird', allowed values are: cat, dog`},
+				{"?sort=size", `Parameter sort can't be 'size', allowed values are: name, age`},
+			}
+			for _, test := range rejected {
+				t.Run("rejects "+test.query, func(t *testing.T) {
+					code, body := get(t, test.query)
+					assert.Equal(t, http.StatusBadRequest, code)
+					assert.Contains(t, body, test.message)
+				})
+			}
+		})
+	}
+}
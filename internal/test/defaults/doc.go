@@ -0,0 +1,3 @@
+package defaults
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=defaults --generate=types,server -o defaults.gen.go defaults.yaml
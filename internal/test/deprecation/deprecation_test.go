@@ -0,0 +1,79 @@
+package deprecation
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) GetOrderV1(ctx echo.Context, orderId string) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) ListOrdersV1(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) GetOrder(ctx echo.Context, orderId string) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func TestDeprecatedOperations(t *testing.T) {
+	assert.Equal(t, map[string]runtime.DeprecatedOperation{
+		"getOrderV1": {OperationID: "getOrderV1", Method: http.MethodGet, Path: "/v1/orders/{orderId}",
+			Sunset: time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)},
+		"listOrdersV1": {OperationID: "listOrdersV1", Method: http.MethodGet, Path: "/v1/orders"},
+	}, DeprecatedOperations)
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	deprecated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var recorded []string
+	tracker := runtime.NewDeprecationTracker(
+		runtime.WithDeprecationHeader(deprecated),
+		runtime.WithDeprecationRecorder(func(ctx context.Context, op runtime.DeprecatedOperation) {
+			recorded = append(recorded, op.OperationID)
+		}))
+	e := echo.New()
+	e.Use(DeprecationMiddleware(tracker))
+	RegisterHandlers(e, server{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/orders/o-1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "@1767225600", rec.Header().Get("Deprecation"))
+	assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", rec.Header().Get("Sunset"))
+
+	// Without a sunset in the spec, nor given to the tracker, there's no
+	// Sunset header.
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/orders", nil))
+	assert.Equal(t, "@1767225600", rec.Header().Get("Deprecation"))
+	assert.Empty(t, rec.Header().Get("Sunset"))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/orders", nil))
+
+	// Operations which aren't deprecated aren't tracked.
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/orders/o-1", nil))
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+
+	assert.Equal(t, map[string]uint64{"getOrderV1": 1, "listOrdersV1": 2}, tracker.Counts())
+	assert.Equal(t, []string{"getOrderV1", "listOrdersV1", "listOrdersV1"}, recorded)
+
+	rec = httptest.NewRecorder()
+	tracker.MetricsHandler(DeprecatedOperations).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := ioutil.ReadAll(rec.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body),
+		`deprecated_operation_requests_total{operation="listOrdersV1",method="GET",path="/v1/orders",sunset=""} 2`)
+}
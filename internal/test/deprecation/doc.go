@@ -0,0 +1,3 @@
+package deprecation
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=deprecation --generate=types,server,deprecation -o deprecation.gen.go deprecation.yaml
@@ -0,0 +1,3 @@
+package encrypted
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=encrypted --generate=types,client -o encrypted.gen.go encrypted.yaml
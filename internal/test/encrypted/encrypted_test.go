@@ -0,0 +1,141 @@
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
+func setCipher(t *testing.T) {
+	cipher, err := runtime.NewAESFieldCipher([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+	EncryptedFieldCipher = cipher
+}
+
+func TestEncryptedFields(t *testing.T) {
+	setCipher(t)
+	defer func() { EncryptedFieldCipher = nil }()
+
+	diagnoses := []string{"flu"}
+	patient := Patient{Name: "Alex", Ssn: "123-45-6789", Diagnoses: &diagnoses}
+	data, err := json.Marshal(patient)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "123-45-6789")
+	assert.NotContains(t, string(data), "flu")
+
+	var object map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &object))
+	assert.Equal(t, "Alex", object["name"])
+	assert.IsType(t, "", object["ssn"])
+	assert.IsType(t, "", object["diagnoses"])
+
+	var decoded Patient
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, patient, decoded)
+
+	// Optional properties which aren't set are left out.
+	data, err = json.Marshal(Patient{Name: "Sam", Ssn: "987-65-4321"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "diagnoses")
+
+	// Types with additional properties encrypt their properties too.
+	secret := "hidden"
+	notes := Notes{Secret: &secret}
+	notes.Set("visit", "2021-03-01")
+	data, err = json.Marshal(notes)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hidden")
+	assert.Contains(t, string(data), `"visit":"2021-03-01"`)
+	var decodedNotes Notes
+	require.NoError(t, json.Unmarshal(data, &decodedNotes))
+	assert.Equal(t, notes, decodedNotes)
+
+	// Values can't be moved from a field to another.
+	data, err = json.Marshal(Patient{Name: "Alex", Ssn: "123-45-6789"})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &object))
+	tampered, err := json.Marshal(map[string]interface{}{"referral": object["ssn"], "patient": object})
+	require.NoError(t, err)
+	var body AddPatientJSONBody
+	assert.Error(t, json.Unmarshal(tampered, &body))
+}
+
+func TestEncryptedRequestBody(t *testing.T) {
+	setCipher(t)
+	defer func() { EncryptedFieldCipher = nil }()
+
+	var sent []byte
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		sent, _ = ioutil.ReadAll(req.Body)
+		// The server answers with the patient, still encrypted.
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(sent, &body); err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(body["patient"])),
+		}, nil
+	})
+	client, err := NewClientWithResponses("http://example.com", WithHTTPClient(doer))
+	require.NoError(t, err)
+
+	referral := "Dr. Who"
+	rsp, err := client.AddPatientWithResponse(context.Background(), AddPatientJSONRequestBody{
+		Patient:  Patient{Name: "Alex", Ssn: "123-45-6789"},
+		Referral: &referral,
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, string(sent), "123-45-6789")
+	assert.NotContains(t, string(sent), "Dr. Who")
+	assert.Equal(t, "123-45-6789", rsp.JSON201.Ssn)
+}
+
+func TestEncryptedRefRequestBody(t *testing.T) {
+	setCipher(t)
+	defer func() { EncryptedFieldCipher = nil }()
+
+	var sent []byte
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		sent, _ = ioutil.ReadAll(req.Body)
+		return &http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+	})
+	client, err := NewClientWithResponses("http://example.com", WithHTTPClient(doer))
+	require.NoError(t, err)
+
+	// The body refers to Patient, whose properties are encrypted all the same.
+	_, err = client.UpdatePatientWithResponse(context.Background(), "1", UpdatePatientJSONRequestBody{
+		Name: "Alex",
+		Ssn:  "123-45-6789",
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, string(sent), "123-45-6789")
+
+	var patient Patient
+	require.NoError(t, json.Unmarshal(sent, &patient))
+	assert.Equal(t, "123-45-6789", patient.Ssn)
+
+	var body UpdatePatientJSONRequestBody
+	require.NoError(t, json.Unmarshal(sent, &body))
+	assert.Equal(t, "123-45-6789", body.Ssn)
+}
+
+func TestMissingCipher(t *testing.T) {
+	_, err := json.Marshal(Patient{Name: "Alex", Ssn: "123-45-6789"})
+	assert.Error(t, err)
+}
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
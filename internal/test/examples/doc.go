@@ -0,0 +1,4 @@
+package examples
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=examples --generate=types -o examples.gen.go examples.yaml
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=examples --generate=example-tests -o examples.gen_test.go examples.yaml
@@ -0,0 +1,24 @@
+// Package examples provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package examples
+
+// Owner defines model for Owner.
+type Owner struct {
+	Name  *string `json:"name,omitempty"`
+	Phone *string `json:"phone,omitempty"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Owner *Owner  `json:"owner,omitempty"`
+	Tag   *string `json:"tag,omitempty"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Code    int     `json:"code"`
+	Message *string `json:"message,omitempty"`
+}
@@ -0,0 +1,88 @@
+// Package examples provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package examples
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestSpecExamples checks that the examples in the spec survive a round trip
+// through the generated types. Each example is unmarshaled into its type and
+// marshaled back, and the result has to be equal to the example, which
+// catches drift between the spec and the generated types, such as a wrong
+// field name or a missing omitempty.
+func TestSpecExamples(t *testing.T) {
+	tests := []struct {
+		name    string
+		example string
+		value   interface{}
+	}{
+		{
+			name:    "Owner",
+			example: `{"name":"Alex","phone":"+1 555 0100"}`,
+			value:   new(Owner),
+		},
+		{
+			name:    "Pet",
+			example: `{"id":1,"name":"Tom"}`,
+			value:   new(Pet),
+		},
+		{
+			name:    "Error",
+			example: `{"code":404,"message":"no such pet"}`,
+			value:   new(Error),
+		},
+		{
+			name:    "ListPetsJSON200/cats",
+			example: `[{"id":1,"name":"Tom","tag":"cat"},{"id":2,"name":"Felix"}]`,
+			value:   new([]Pet),
+		},
+		{
+			name:    "ListPetsJSON200/empty",
+			example: `[]`,
+			value:   new([]Pet),
+		},
+		{
+			name:    "ListPetsJSONDefault",
+			example: `{"code":404,"message":"no such pet"}`,
+			value: new(struct {
+				Code    int     `json:"code"`
+				Message *string `json:"message,omitempty"`
+			}),
+		},
+		{
+			name:    "GetPetJSON200",
+			example: `{"id":3,"name":"Rex","owner":{"name":"Alex"},"tag":"dog"}`,
+			value:   new(Pet),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tt.example), tt.value)
+			if err != nil {
+				t.Fatalf("error unmarshaling example into %T: %s", tt.value, err)
+			}
+			marshaled, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("error marshaling %T: %s", tt.value, err)
+			}
+
+			var expected, actual interface{}
+			if err := json.Unmarshal([]byte(tt.example), &expected); err != nil {
+				t.Fatalf("error unmarshaling example: %s", err)
+			}
+			if err := json.Unmarshal(marshaled, &actual); err != nil {
+				t.Fatalf("error unmarshaling marshaled %T: %s", tt.value, err)
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("example doesn't survive a round trip through %T\nexample:    %s\nmarshaled:  %s",
+					tt.value, tt.example, marshaled)
+			}
+		})
+	}
+}
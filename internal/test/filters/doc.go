@@ -0,0 +1,3 @@
+package filters
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=filters --generate=types,client -o filters.gen.go filters.yaml
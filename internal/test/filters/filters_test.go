@@ -0,0 +1,69 @@
+package filters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
+func TestODataFilter(t *testing.T) {
+	f := FindPetsFilterFields
+	var params FindPetsParams
+	params.SetFilter(runtime.And(
+		f.Name.Eq("O'Malley"),
+		runtime.Or(f.Age.Gt(3), f.Weight.Le(4.5)),
+		f.Vaccinated.Eq(true),
+		f.Born.Ge(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+	))
+	require.NotNil(t, params.Filter)
+	assert.Equal(t, "name eq 'O''Malley' and (age gt 3 or weight le 4.5) and vaccinated eq true and born ge 2020-01-02T03:04:05Z", *params.Filter)
+}
+
+func TestRSQLFilter(t *testing.T) {
+	f := FindOrdersQFields
+	var params FindOrdersParams
+	params.SetQ(runtime.Or(f.Status.In("open", "on hold"), runtime.And(f.Status.Ne("closed"), f.Total.Gt(100))))
+	assert.Equal(t, `status=in=(open,"on hold"),status!=closed;total=gt=100`, params.Q)
+}
+
+func TestFilterQuery(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("filter")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+	var params FindPetsParams
+	params.SetFilter(FindPetsFilterFields.Name.In("Rex", "Tom & Jerry"))
+	rsp, err := client.FindPets(context.Background(), &params)
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, "name in ('Rex','Tom & Jerry')", query)
+}
+
+func TestFilterQuerySeparators(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+	var params FindOrdersParams
+	f := FindOrdersQFields
+	params.SetQ(runtime.And(f.Status.Eq("open"), f.Total.Gt(1e6)))
+	rsp, err := client.FindOrders(context.Background(), &params)
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, "status==open;total=gt=1e+06", query)
+}
@@ -0,0 +1,4 @@
+package fuzz
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=fuzz --generate=types,server,spec -o fuzz.gen.go fuzz.yaml
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=fuzz --generate=fuzz-tests -o fuzz.gen_test.go fuzz.yaml
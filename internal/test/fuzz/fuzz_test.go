@@ -0,0 +1,40 @@
+package fuzz
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type server struct{}
+
+func (server) Ping(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) ListBooks(ctx echo.Context, shelfId int, params ListBooksParams) error {
+	limit := 10
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	books := make([]Book, 0, limit)
+	if params.Tags != nil {
+		for _, tag := range *params.Tags {
+			books = append(books, Book{Title: tag})
+		}
+	}
+	return ctx.JSON(http.StatusOK, books)
+}
+
+func (server) AddBook(ctx echo.Context, shelfId int) error {
+	var book AddBookJSONRequestBody
+	if err := ctx.Bind(&book); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, book)
+}
+
+func newFuzzServer(tb testing.TB) ServerInterface {
+	return server{}
+}
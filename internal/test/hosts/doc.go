@@ -0,0 +1,3 @@
+package hosts
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=hosts --generate=types,server -o hosts.gen.go hosts.yaml
@@ -0,0 +1,77 @@
+// Package hosts provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package hosts
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /items)
+	ListItems(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// ListItems converts echo context to params.
+func (w *ServerInterfaceWrapper) ListItems(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListItems(ctx)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}, si ServerInterface) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET("/items", wrapper.ListItems)
+
+}
+
+// RegisterHandlersForHosts adds each server route to e for the hosts of the
+// servers of the spec, under their base paths, so that one echo instance can
+// serve several APIs based on the Host header. The middlewares m apply to
+// these routes only. The routes are added for:
+//
+//	api.example.com/v1
+//	api.example.com/v2
+//	admin.example.com
+func RegisterHandlersForHosts(e *echo.Echo, si ServerInterface, m ...echo.MiddlewareFunc) {
+	hosts := []struct {
+		host      string
+		basePaths []string
+	}{
+		{"api.example.com", []string{"/v1", "/v2"}},
+		{"admin.example.com", []string{""}},
+	}
+	for _, h := range hosts {
+		// Routers are per host, so each host is only created once.
+		router := e.Host(h.host)
+		for _, basePath := range h.basePaths {
+			group := router.Group(basePath)
+			group.Use(m...)
+			RegisterHandlers(group, si)
+		}
+	}
+}
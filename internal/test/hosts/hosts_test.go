@@ -0,0 +1,75 @@
+package hosts
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type itemServer struct {
+	items []string
+}
+
+func (s *itemServer) ListItems(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, s.items)
+}
+
+func TestRegisterHandlersForHosts(t *testing.T) {
+	e := echo.New()
+	tagged := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.Response().Header().Set("X-Api", "items")
+			return next(ctx)
+		}
+	}
+	RegisterHandlersForHosts(e, &itemServer{items: []string{"a", "b"}}, tagged)
+	// Another API served by the same instance, on another host.
+	e.Host("other.example.com").GET("/items", func(ctx echo.Context) error {
+		return ctx.JSON(http.StatusOK, []string{"other"})
+	})
+
+	tests := []struct {
+		host   string
+		path   string
+		status int
+		body   string
+	}{
+		{"api.example.com", "/v1/items", http.StatusOK, `["a","b"]`},
+		{"api.example.com", "/v2/items", http.StatusOK, `["a","b"]`},
+		{"admin.example.com", "/items", http.StatusOK, `["a","b"]`},
+		{"other.example.com", "/items", http.StatusOK, `["other"]`},
+		// Only the base paths of the servers are routed
+		{"api.example.com", "/items", http.StatusNotFound, ""},
+		{"admin.example.com", "/v1/items", http.StatusNotFound, ""},
+		// Relative servers have no host
+		{"example.com", "/local/items", http.StatusNotFound, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.host+test.path, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.path, nil)
+			req.Host = test.host
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, test.status, rec.Code)
+			if test.status == http.StatusOK {
+				assert.JSONEq(t, test.body, rec.Body.String())
+			}
+		})
+	}
+
+	// The middlewares only apply to the generated routes.
+	req := httptest.NewRequest("GET", "/v1/items", nil)
+	req.Host = "api.example.com"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "items", rec.Header().Get("X-Api"))
+
+	req = httptest.NewRequest("GET", "/items", nil)
+	req.Host = "other.example.com"
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Empty(t, rec.Header().Get("X-Api"))
+}
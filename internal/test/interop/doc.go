@@ -0,0 +1,8 @@
+// Package interop checks that generated clients and servers serialize
+// requests and responses the way other OpenAPI implementations do. The
+// fixtures in testdata record exchanges of the operations of interop.yaml,
+// which are replayed against the generated server, and compared with the
+// requests sent by the generated client.
+package interop
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=interop --generate=types,client,server -o interop.gen.go interop.yaml
@@ -0,0 +1,1083 @@
+// Package interop provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package interop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Pet defines model for Pet.
+type Pet struct {
+	Name   string    `json:"name"`
+	Size   *string   `json:"size,omitempty"`
+	Status PetStatus `json:"status"`
+}
+
+// PetStatus defines model for PetStatus.
+type PetStatus string
+
+// Point defines model for Point.
+type Point struct {
+	B int `json:"B"`
+	G int `json:"G"`
+	R int `json:"R"`
+}
+
+// GetFormParams defines parameters for GetForm.
+type GetFormParams struct {
+	Color     *[]string `json:"color,omitempty"`
+	ColorList *[]string `json:"colorList,omitempty"`
+	Point     *Point    `json:"point,omitempty"`
+	Filter    *Point    `json:"filter,omitempty"`
+	XColor    *[]string `json:"X-Color,omitempty"`
+}
+
+// AddPetJSONBody defines parameters for AddPet.
+type AddPetJSONBody Pet
+
+// AddPetRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody AddPetJSONBody
+
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b AddPetJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, editors...)
+		return nil
+	}
+}
+
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
+		return nil
+	}
+}
+
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetForm request
+	GetForm(ctx context.Context, params *GetFormParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabel request
+	GetLabel(ctx context.Context, color []string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMatrix request
+	GetMatrix(ctx context.Context, color []string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPet request  with any body
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSimple request
+	GetSimple(ctx context.Context, point Point, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetForm(ctx context.Context, params *GetFormParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFormRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) GetLabel(ctx context.Context, color []string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLabelRequest(c.Server, color)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) GetMatrix(ctx context.Context, color []string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMatrixRequest(c.Server, color)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) GetSimple(ctx context.Context, point Point, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSimpleRequest(c.Server, point)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+// NewGetFormRequest generates requests for GetForm
+func NewGetFormRequest(server string, params *GetFormParams) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/form"))
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryUrl.Query()
+
+	if params.Color != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", true, "color", *params.Color); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.ColorList != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", false, "colorList", *params.ColorList); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Point != nil {
+
+		if queryFrag, err := runtime.StyleParam("form", false, "point", *params.Point); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Filter != nil {
+
+		if queryFrag, err := runtime.StyleParam("deepObject", true, "filter", *params.Filter); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryUrl.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.XColor != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParam("simple", false, "X-Color", *params.XColor)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("X-Color", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewGetLabelRequest generates requests for GetLabel
+func NewGetLabelRequest(server string, color []string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("label", false, "color", color)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/label/%s", pathParam0))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMatrixRequest generates requests for GetMatrix
+func NewGetMatrixRequest(server string, color []string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("matrix", true, "color", color)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/matrix/%s", pathParam0))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPetRequest calls the generic AddPet builder with application/json body
+func NewAddPetRequest(server string, body AddPetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPetRequestWithBody generates requests for AddPet with any type of body
+func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/pets"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewGetSimpleRequest generates requests for GetSimple
+func NewGetSimpleRequest(server string, point Point) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParam("simple", true, "point", point)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/simple/%s", pathParam0))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+type getFormResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getFormResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getFormResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getLabelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getLabelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getLabelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getMatrixResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getMatrixResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getMatrixResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type addPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r addPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r addPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getSimpleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getSimpleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getSimpleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetFormWithResponse request returning *GetFormResponse
+func (c *ClientWithResponses) GetFormWithResponse(ctx context.Context, params *GetFormParams, reqEditors ...RequestEditorFn) (*getFormResponse, error) {
+	rsp, err := c.GetForm(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFormResponse(rsp)
+}
+
+// GetLabelWithResponse request returning *GetLabelResponse
+func (c *ClientWithResponses) GetLabelWithResponse(ctx context.Context, color []string, reqEditors ...RequestEditorFn) (*getLabelResponse, error) {
+	rsp, err := c.GetLabel(ctx, color, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLabelResponse(rsp)
+}
+
+// GetMatrixWithResponse request returning *GetMatrixResponse
+func (c *ClientWithResponses) GetMatrixWithResponse(ctx context.Context, color []string, reqEditors ...RequestEditorFn) (*getMatrixResponse, error) {
+	rsp, err := c.GetMatrix(ctx, color, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMatrixResponse(rsp)
+}
+
+// AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*addPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*addPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+// GetSimpleWithResponse request returning *GetSimpleResponse
+func (c *ClientWithResponses) GetSimpleWithResponse(ctx context.Context, point Point, reqEditors ...RequestEditorFn) (*getSimpleResponse, error) {
+	rsp, err := c.GetSimple(ctx, point, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSimpleResponse(rsp)
+}
+
+// ParseGetFormResponse parses an HTTP response from a GetFormWithResponse call
+func ParseGetFormResponse(rsp *http.Response) (*getFormResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getFormResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseGetLabelResponse parses an HTTP response from a GetLabelWithResponse call
+func ParseGetLabelResponse(rsp *http.Response) (*getLabelResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getLabelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseGetMatrixResponse parses an HTTP response from a GetMatrixWithResponse call
+func ParseGetMatrixResponse(rsp *http.Response) (*getMatrixResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getMatrixResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseAddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseAddPetResponse(rsp *http.Response) (*addPetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &addPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		response.JSON201 = &Pet{}
+		if err := json.Unmarshal(bodyBytes, response.JSON201); err != nil {
+			return nil, err
+		}
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSimpleResponse parses an HTTP response from a GetSimpleWithResponse call
+func ParseGetSimpleResponse(rsp *http.Response) (*getSimpleResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getSimpleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /form)
+	GetForm(ctx echo.Context, params GetFormParams) error
+
+	// (GET /label/{color})
+	GetLabel(ctx echo.Context, color []string) error
+
+	// (GET /matrix/{color})
+	GetMatrix(ctx echo.Context, color []string) error
+
+	// (POST /pets)
+	AddPet(ctx echo.Context) error
+
+	// (GET /simple/{point})
+	GetSimple(ctx echo.Context, point Point) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetForm converts echo context to params.
+func (w *ServerInterfaceWrapper) GetForm(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFormParams
+	// ------------- Optional query parameter "color" -------------
+	if paramValue := ctx.QueryParam("color"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "color", ctx.QueryParams(), &params.Color)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "color", err))
+	}
+
+	// ------------- Optional query parameter "colorList" -------------
+	if paramValue := ctx.QueryParam("colorList"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "colorList", ctx.QueryParams(), &params.ColorList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "colorList", err))
+	}
+
+	// ------------- Optional query parameter "point" -------------
+	if paramValue := ctx.QueryParam("point"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "point", ctx.QueryParams(), &params.Point)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "point", err))
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+	if paramValue := ctx.QueryParam("filter"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "filter", ctx.QueryParams(), &params.Filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "filter", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "X-Color" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Color")]; found {
+		var XColor []string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Color", n))
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "X-Color", valueList[0], &XColor)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Color", err))
+		}
+
+		params.XColor = &XColor
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetForm(ctx, params)
+	return err
+}
+
+// GetLabel converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLabel(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "color" -------------
+	var color []string
+
+	err = runtime.BindStyledParameter("label", false, "color", ctx.Param("color"), &color)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "color", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetLabel(ctx, color)
+	return err
+}
+
+// GetMatrix converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMatrix(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "color" -------------
+	var color []string
+
+	err = runtime.BindStyledParameter("matrix", true, "color", ctx.Param("color"), &color)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "color", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetMatrix(ctx, color)
+	return err
+}
+
+// AddPet converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPet(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPet(ctx)
+	return err
+}
+
+// GetSimple converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSimple(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "point" -------------
+	var point Point
+
+	err = runtime.BindStyledParameter("simple", true, "point", ctx.Param("point"), &point)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "point", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetSimple(ctx, point)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}, si ServerInterface) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET("/form", wrapper.GetForm)
+	router.GET("/label/:color", wrapper.GetLabel)
+	router.GET("/matrix/:color", wrapper.GetMatrix)
+	router.POST("/pets", wrapper.AddPet)
+	router.GET("/simple/:point", wrapper.GetSimple)
+
+}
@@ -1,3 +1,3 @@
 package parameters
 
-//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=parameters -o parameters.gen.go parameters.yaml
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=parameters --generate=types,client,server,spec,in-memory-client -o parameters.gen.go parameters.yaml
@@ -16,6 +16,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -106,8 +107,16 @@ type GetQueryFormParams struct {
 	Co *ComplexObject `json:"co,omitempty"`
 }
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(req *http.Request, ctx context.Context) error
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
 
 // Doer performs HTTP requests.
 //
@@ -117,6 +126,10 @@ type HttpRequestDoer interface {
 }
 
 // Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
 	// https://api.deepmap.com for example.
@@ -126,9 +139,21 @@ type Client struct {
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
 
-	// A callback for modifying requests which are generated before sending over
-	// the network.
-	RequestEditor RequestEditorFn
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -153,6 +178,29 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	return &client, nil
 }
 
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
 // WithHTTPClient allows overriding the default Doer, which is
 // automatically created using http.Client. This is useful for tests.
 func WithHTTPClient(doer HttpRequestDoer) ClientOption {
@@ -164,338 +212,378 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, editors...)
+		return nil
+	}
+}
+
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
 	return func(c *Client) error {
-		c.RequestEditor = fn
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
 		return nil
 	}
 }
 
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetContentObject request
-	GetContentObject(ctx context.Context, param ComplexObject) (*http.Response, error)
+	GetContentObject(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetCookie request
-	GetCookie(ctx context.Context, params *GetCookieParams) (*http.Response, error)
+	GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetHeader request
-	GetHeader(ctx context.Context, params *GetHeaderParams) (*http.Response, error)
+	GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetLabelExplodeArray request
-	GetLabelExplodeArray(ctx context.Context, param []int32) (*http.Response, error)
+	GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetLabelExplodeObject request
-	GetLabelExplodeObject(ctx context.Context, param Object) (*http.Response, error)
+	GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetLabelNoExplodeArray request
-	GetLabelNoExplodeArray(ctx context.Context, param []int32) (*http.Response, error)
+	GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetLabelNoExplodeObject request
-	GetLabelNoExplodeObject(ctx context.Context, param Object) (*http.Response, error)
+	GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetMatrixExplodeArray request
-	GetMatrixExplodeArray(ctx context.Context, id []int32) (*http.Response, error)
+	GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetMatrixExplodeObject request
-	GetMatrixExplodeObject(ctx context.Context, id Object) (*http.Response, error)
+	GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetMatrixNoExplodeArray request
-	GetMatrixNoExplodeArray(ctx context.Context, id []int32) (*http.Response, error)
+	GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetMatrixNoExplodeObject request
-	GetMatrixNoExplodeObject(ctx context.Context, id Object) (*http.Response, error)
+	GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetPassThrough request
-	GetPassThrough(ctx context.Context, param string) (*http.Response, error)
+	GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetQueryForm request
-	GetQueryForm(ctx context.Context, params *GetQueryFormParams) (*http.Response, error)
+	GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetSimpleExplodeArray request
-	GetSimpleExplodeArray(ctx context.Context, param []int32) (*http.Response, error)
+	GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetSimpleExplodeObject request
-	GetSimpleExplodeObject(ctx context.Context, param Object) (*http.Response, error)
+	GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetSimpleNoExplodeArray request
-	GetSimpleNoExplodeArray(ctx context.Context, param []int32) (*http.Response, error)
+	GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetSimpleNoExplodeObject request
-	GetSimpleNoExplodeObject(ctx context.Context, param Object) (*http.Response, error)
+	GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetSimplePrimitive request
-	GetSimplePrimitive(ctx context.Context, param int32) (*http.Response, error)
+	GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetContentObject(ctx context.Context, param ComplexObject) (*http.Response, error) {
+func (c *Client) GetContentObject(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetContentObjectRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams) (*http.Response, error) {
+func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetCookieRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams) (*http.Response, error) {
+func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetHeaderRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32) (*http.Response, error) {
+func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetLabelExplodeArrayRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object) (*http.Response, error) {
+func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetLabelExplodeObjectRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32) (*http.Response, error) {
+func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetLabelNoExplodeArrayRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object) (*http.Response, error) {
+func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetLabelNoExplodeObjectRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32) (*http.Response, error) {
+func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetMatrixExplodeArrayRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object) (*http.Response, error) {
+func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetMatrixExplodeObjectRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32) (*http.Response, error) {
+func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetMatrixNoExplodeArrayRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object) (*http.Response, error) {
+func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetMatrixNoExplodeObjectRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetPassThrough(ctx context.Context, param string) (*http.Response, error) {
+func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetPassThroughRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams) (*http.Response, error) {
+func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetQueryFormRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32) (*http.Response, error) {
+func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSimpleExplodeArrayRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object) (*http.Response, error) {
+func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSimpleExplodeObjectRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32) (*http.Response, error) {
+func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSimpleNoExplodeArrayRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object) (*http.Response, error) {
+func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSimpleNoExplodeObjectRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
-func (c *Client) GetSimplePrimitive(ctx context.Context, param int32) (*http.Response, error) {
+func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSimplePrimitiveRequest(c.Server, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if c.RequestEditor != nil {
-		err = c.RequestEditor(req, ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, req, nil, reqEditors)
 }
 
 // NewGetContentObjectRequest generates requests for GetContentObject
@@ -1685,8 +1773,8 @@ func (r getSimplePrimitiveResponse) StatusCode() int {
 }
 
 // GetContentObjectWithResponse request returning *GetContentObjectResponse
-func (c *ClientWithResponses) GetContentObjectWithResponse(ctx context.Context, param ComplexObject) (*getContentObjectResponse, error) {
-	rsp, err := c.GetContentObject(ctx, param)
+func (c *ClientWithResponses) GetContentObjectWithResponse(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*getContentObjectResponse, error) {
+	rsp, err := c.GetContentObject(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1694,8 +1782,8 @@ func (c *ClientWithResponses) GetContentObjectWithResponse(ctx context.Context,
 }
 
 // GetCookieWithResponse request returning *GetCookieResponse
-func (c *ClientWithResponses) GetCookieWithResponse(ctx context.Context, params *GetCookieParams) (*getCookieResponse, error) {
-	rsp, err := c.GetCookie(ctx, params)
+func (c *ClientWithResponses) GetCookieWithResponse(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*getCookieResponse, error) {
+	rsp, err := c.GetCookie(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1703,8 +1791,8 @@ func (c *ClientWithResponses) GetCookieWithResponse(ctx context.Context, params
 }
 
 // GetHeaderWithResponse request returning *GetHeaderResponse
-func (c *ClientWithResponses) GetHeaderWithResponse(ctx context.Context, params *GetHeaderParams) (*getHeaderResponse, error) {
-	rsp, err := c.GetHeader(ctx, params)
+func (c *ClientWithResponses) GetHeaderWithResponse(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*getHeaderResponse, error) {
+	rsp, err := c.GetHeader(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1712,8 +1800,8 @@ func (c *ClientWithResponses) GetHeaderWithResponse(ctx context.Context, params
 }
 
 // GetLabelExplodeArrayWithResponse request returning *GetLabelExplodeArrayResponse
-func (c *ClientWithResponses) GetLabelExplodeArrayWithResponse(ctx context.Context, param []int32) (*getLabelExplodeArrayResponse, error) {
-	rsp, err := c.GetLabelExplodeArray(ctx, param)
+func (c *ClientWithResponses) GetLabelExplodeArrayWithResponse(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*getLabelExplodeArrayResponse, error) {
+	rsp, err := c.GetLabelExplodeArray(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1721,8 +1809,8 @@ func (c *ClientWithResponses) GetLabelExplodeArrayWithResponse(ctx context.Conte
 }
 
 // GetLabelExplodeObjectWithResponse request returning *GetLabelExplodeObjectResponse
-func (c *ClientWithResponses) GetLabelExplodeObjectWithResponse(ctx context.Context, param Object) (*getLabelExplodeObjectResponse, error) {
-	rsp, err := c.GetLabelExplodeObject(ctx, param)
+func (c *ClientWithResponses) GetLabelExplodeObjectWithResponse(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*getLabelExplodeObjectResponse, error) {
+	rsp, err := c.GetLabelExplodeObject(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1730,8 +1818,8 @@ func (c *ClientWithResponses) GetLabelExplodeObjectWithResponse(ctx context.Cont
 }
 
 // GetLabelNoExplodeArrayWithResponse request returning *GetLabelNoExplodeArrayResponse
-func (c *ClientWithResponses) GetLabelNoExplodeArrayWithResponse(ctx context.Context, param []int32) (*getLabelNoExplodeArrayResponse, error) {
-	rsp, err := c.GetLabelNoExplodeArray(ctx, param)
+func (c *ClientWithResponses) GetLabelNoExplodeArrayWithResponse(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*getLabelNoExplodeArrayResponse, error) {
+	rsp, err := c.GetLabelNoExplodeArray(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1739,8 +1827,8 @@ func (c *ClientWithResponses) GetLabelNoExplodeArrayWithResponse(ctx context.Con
 }
 
 // GetLabelNoExplodeObjectWithResponse request returning *GetLabelNoExplodeObjectResponse
-func (c *ClientWithResponses) GetLabelNoExplodeObjectWithResponse(ctx context.Context, param Object) (*getLabelNoExplodeObjectResponse, error) {
-	rsp, err := c.GetLabelNoExplodeObject(ctx, param)
+func (c *ClientWithResponses) GetLabelNoExplodeObjectWithResponse(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*getLabelNoExplodeObjectResponse, error) {
+	rsp, err := c.GetLabelNoExplodeObject(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1748,8 +1836,8 @@ func (c *ClientWithResponses) GetLabelNoExplodeObjectWithResponse(ctx context.Co
 }
 
 // GetMatrixExplodeArrayWithResponse request returning *GetMatrixExplodeArrayResponse
-func (c *ClientWithResponses) GetMatrixExplodeArrayWithResponse(ctx context.Context, id []int32) (*getMatrixExplodeArrayResponse, error) {
-	rsp, err := c.GetMatrixExplodeArray(ctx, id)
+func (c *ClientWithResponses) GetMatrixExplodeArrayWithResponse(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*getMatrixExplodeArrayResponse, error) {
+	rsp, err := c.GetMatrixExplodeArray(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1757,8 +1845,8 @@ func (c *ClientWithResponses) GetMatrixExplodeArrayWithResponse(ctx context.Cont
 }
 
 // GetMatrixExplodeObjectWithResponse request returning *GetMatrixExplodeObjectResponse
-func (c *ClientWithResponses) GetMatrixExplodeObjectWithResponse(ctx context.Context, id Object) (*getMatrixExplodeObjectResponse, error) {
-	rsp, err := c.GetMatrixExplodeObject(ctx, id)
+func (c *ClientWithResponses) GetMatrixExplodeObjectWithResponse(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*getMatrixExplodeObjectResponse, error) {
+	rsp, err := c.GetMatrixExplodeObject(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1766,8 +1854,8 @@ func (c *ClientWithResponses) GetMatrixExplodeObjectWithResponse(ctx context.Con
 }
 
 // GetMatrixNoExplodeArrayWithResponse request returning *GetMatrixNoExplodeArrayResponse
-func (c *ClientWithResponses) GetMatrixNoExplodeArrayWithResponse(ctx context.Context, id []int32) (*getMatrixNoExplodeArrayResponse, error) {
-	rsp, err := c.GetMatrixNoExplodeArray(ctx, id)
+func (c *ClientWithResponses) GetMatrixNoExplodeArrayWithResponse(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*getMatrixNoExplodeArrayResponse, error) {
+	rsp, err := c.GetMatrixNoExplodeArray(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1775,8 +1863,8 @@ func (c *ClientWithResponses) GetMatrixNoExplodeArrayWithResponse(ctx context.Co
 }
 
 // GetMatrixNoExplodeObjectWithResponse request returning *GetMatrixNoExplodeObjectResponse
-func (c *ClientWithResponses) GetMatrixNoExplodeObjectWithResponse(ctx context.Context, id Object) (*getMatrixNoExplodeObjectResponse, error) {
-	rsp, err := c.GetMatrixNoExplodeObject(ctx, id)
+func (c *ClientWithResponses) GetMatrixNoExplodeObjectWithResponse(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*getMatrixNoExplodeObjectResponse, error) {
+	rsp, err := c.GetMatrixNoExplodeObject(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1784,8 +1872,8 @@ func (c *ClientWithResponses) GetMatrixNoExplodeObjectWithResponse(ctx context.C
 }
 
 // GetPassThroughWithResponse request returning *GetPassThroughResponse
-func (c *ClientWithResponses) GetPassThroughWithResponse(ctx context.Context, param string) (*getPassThroughResponse, error) {
-	rsp, err := c.GetPassThrough(ctx, param)
+func (c *ClientWithResponses) GetPassThroughWithResponse(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*getPassThroughResponse, error) {
+	rsp, err := c.GetPassThrough(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1793,8 +1881,8 @@ func (c *ClientWithResponses) GetPassThroughWithResponse(ctx context.Context, pa
 }
 
 // GetQueryFormWithResponse request returning *GetQueryFormResponse
-func (c *ClientWithResponses) GetQueryFormWithResponse(ctx context.Context, params *GetQueryFormParams) (*getQueryFormResponse, error) {
-	rsp, err := c.GetQueryForm(ctx, params)
+func (c *ClientWithResponses) GetQueryFormWithResponse(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*getQueryFormResponse, error) {
+	rsp, err := c.GetQueryForm(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1802,8 +1890,8 @@ func (c *ClientWithResponses) GetQueryFormWithResponse(ctx context.Context, para
 }
 
 // GetSimpleExplodeArrayWithResponse request returning *GetSimpleExplodeArrayResponse
-func (c *ClientWithResponses) GetSimpleExplodeArrayWithResponse(ctx context.Context, param []int32) (*getSimpleExplodeArrayResponse, error) {
-	rsp, err := c.GetSimpleExplodeArray(ctx, param)
+func (c *ClientWithResponses) GetSimpleExplodeArrayWithResponse(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*getSimpleExplodeArrayResponse, error) {
+	rsp, err := c.GetSimpleExplodeArray(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1811,8 +1899,8 @@ func (c *ClientWithResponses) GetSimpleExplodeArrayWithResponse(ctx context.Cont
 }
 
 // GetSimpleExplodeObjectWithResponse request returning *GetSimpleExplodeObjectResponse
-func (c *ClientWithResponses) GetSimpleExplodeObjectWithResponse(ctx context.Context, param Object) (*getSimpleExplodeObjectResponse, error) {
-	rsp, err := c.GetSimpleExplodeObject(ctx, param)
+func (c *ClientWithResponses) GetSimpleExplodeObjectWithResponse(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*getSimpleExplodeObjectResponse, error) {
+	rsp, err := c.GetSimpleExplodeObject(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1820,8 +1908,8 @@ func (c *ClientWithResponses) GetSimpleExplodeObjectWithResponse(ctx context.Con
 }
 
 // GetSimpleNoExplodeArrayWithResponse request returning *GetSimpleNoExplodeArrayResponse
-func (c *ClientWithResponses) GetSimpleNoExplodeArrayWithResponse(ctx context.Context, param []int32) (*getSimpleNoExplodeArrayResponse, error) {
-	rsp, err := c.GetSimpleNoExplodeArray(ctx, param)
+func (c *ClientWithResponses) GetSimpleNoExplodeArrayWithResponse(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*getSimpleNoExplodeArrayResponse, error) {
+	rsp, err := c.GetSimpleNoExplodeArray(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1829,8 +1917,8 @@ func (c *ClientWithResponses) GetSimpleNoExplodeArrayWithResponse(ctx context.Co
 }
 
 // GetSimpleNoExplodeObjectWithResponse request returning *GetSimpleNoExplodeObjectResponse
-func (c *ClientWithResponses) GetSimpleNoExplodeObjectWithResponse(ctx context.Context, param Object) (*getSimpleNoExplodeObjectResponse, error) {
-	rsp, err := c.GetSimpleNoExplodeObject(ctx, param)
+func (c *ClientWithResponses) GetSimpleNoExplodeObjectWithResponse(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*getSimpleNoExplodeObjectResponse, error) {
+	rsp, err := c.GetSimpleNoExplodeObject(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1838,8 +1926,8 @@ func (c *ClientWithResponses) GetSimpleNoExplodeObjectWithResponse(ctx context.C
 }
 
 // GetSimplePrimitiveWithResponse request returning *GetSimplePrimitiveResponse
-func (c *ClientWithResponses) GetSimplePrimitiveWithResponse(ctx context.Context, param int32) (*getSimplePrimitiveResponse, error) {
-	rsp, err := c.GetSimplePrimitive(ctx, param)
+func (c *ClientWithResponses) GetSimplePrimitiveWithResponse(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*getSimplePrimitiveResponse, error) {
+	rsp, err := c.GetSimplePrimitive(ctx, param, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -2259,7 +2347,7 @@ func (w *ServerInterfaceWrapper) GetContentObject(ctx echo.Context) error {
 
 	err = json.Unmarshal([]byte(ctx.Param("param")), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'param' as JSON")
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgUnmarshalParamJSON, "param"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2279,7 +2367,7 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value int32
 		err = runtime.BindStyledParameter("simple", false, "p", cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "p", err), "p", "primitive"))
 		}
 		params.P = &value
 
@@ -2290,7 +2378,7 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value int32
 		err = runtime.BindStyledParameter("simple", true, "ep", cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ep: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "ep", err), "ep", "primitive"))
 		}
 		params.Ep = &value
 
@@ -2301,7 +2389,7 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value []int32
 		err = runtime.BindStyledParameter("simple", true, "ea", cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ea: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "ea", err), "ea", "exploded array"))
 		}
 		params.Ea = &value
 
@@ -2312,7 +2400,7 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value []int32
 		err = runtime.BindStyledParameter("simple", false, "a", cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "a", err), "a", "array"))
 		}
 		params.A = &value
 
@@ -2323,7 +2411,7 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value Object
 		err = runtime.BindStyledParameter("simple", true, "eo", cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter eo: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "eo", err), "eo", "exploded object"))
 		}
 		params.Eo = &value
 
@@ -2334,7 +2422,7 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value Object
 		err = runtime.BindStyledParameter("simple", false, "o", cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter o: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "o", err), "o", "object"))
 		}
 		params.O = &value
 
@@ -2346,11 +2434,11 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var decoded string
 		decoded, err := url.QueryUnescape(cookie.Value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter 'co'")
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgUnescapeCookieParam, "co"), "co", "complex object"))
 		}
 		err = json.Unmarshal([]byte(decoded), &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'co' as JSON")
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgUnmarshalParamJSON, "co"), "co", "complex object"))
 		}
 		params.Co = &value
 
@@ -2374,12 +2462,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XPrimitive int32
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Primitive, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Primitive", n), "X-Primitive", "primitive"))
 		}
 
 		err = runtime.BindStyledParameter("simple", false, "X-Primitive", valueList[0], &XPrimitive)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Primitive: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Primitive", err), "X-Primitive", "primitive"))
 		}
 
 		params.XPrimitive = &XPrimitive
@@ -2389,12 +2477,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XPrimitiveExploded int32
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Primitive-Exploded, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Primitive-Exploded", n), "X-Primitive-Exploded", "primitive"))
 		}
 
 		err = runtime.BindStyledParameter("simple", true, "X-Primitive-Exploded", valueList[0], &XPrimitiveExploded)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Primitive-Exploded: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Primitive-Exploded", err), "X-Primitive-Exploded", "primitive"))
 		}
 
 		params.XPrimitiveExploded = &XPrimitiveExploded
@@ -2404,12 +2492,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XArrayExploded []int32
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Array-Exploded, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Array-Exploded", n), "X-Array-Exploded", "exploded array"))
 		}
 
 		err = runtime.BindStyledParameter("simple", true, "X-Array-Exploded", valueList[0], &XArrayExploded)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array-Exploded: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Array-Exploded", err), "X-Array-Exploded", "exploded array"))
 		}
 
 		params.XArrayExploded = &XArrayExploded
@@ -2419,12 +2507,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XArray []int32
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Array, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Array", n), "X-Array", "array"))
 		}
 
 		err = runtime.BindStyledParameter("simple", false, "X-Array", valueList[0], &XArray)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Array", err), "X-Array", "array"))
 		}
 
 		params.XArray = &XArray
@@ -2434,12 +2522,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XObjectExploded Object
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Object-Exploded, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Object-Exploded", n), "X-Object-Exploded", "exploded object"))
 		}
 
 		err = runtime.BindStyledParameter("simple", true, "X-Object-Exploded", valueList[0], &XObjectExploded)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object-Exploded: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Object-Exploded", err), "X-Object-Exploded", "exploded object"))
 		}
 
 		params.XObjectExploded = &XObjectExploded
@@ -2449,12 +2537,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XObject Object
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Object, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Object", n), "X-Object", "object"))
 		}
 
 		err = runtime.BindStyledParameter("simple", false, "X-Object", valueList[0], &XObject)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object: %s", err))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "X-Object", err), "X-Object", "object"))
 		}
 
 		params.XObject = &XObject
@@ -2464,12 +2552,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XComplexObject ComplexObject
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Complex-Object, got %d", n))
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgParamValueCount, "X-Complex-Object", n), "X-Complex-Object", "complex object"))
 		}
 
 		err = json.Unmarshal([]byte(valueList[0]), &XComplexObject)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'X-Complex-Object' as JSON")
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgUnmarshalParamJSON, "X-Complex-Object"), "X-Complex-Object", "complex object"))
 		}
 
 		params.XComplexObject = &XComplexObject
@@ -2488,7 +2576,7 @@ func (w *ServerInterfaceWrapper) GetLabelExplodeArray(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameter("label", true, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2504,7 +2592,7 @@ func (w *ServerInterfaceWrapper) GetLabelExplodeObject(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameter("label", true, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2520,7 +2608,7 @@ func (w *ServerInterfaceWrapper) GetLabelNoExplodeArray(ctx echo.Context) error
 
 	err = runtime.BindStyledParameter("label", false, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2536,7 +2624,7 @@ func (w *ServerInterfaceWrapper) GetLabelNoExplodeObject(ctx echo.Context) error
 
 	err = runtime.BindStyledParameter("label", false, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2552,7 +2640,7 @@ func (w *ServerInterfaceWrapper) GetMatrixExplodeArray(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameter("matrix", true, "id", ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "id", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2568,7 +2656,7 @@ func (w *ServerInterfaceWrapper) GetMatrixExplodeObject(ctx echo.Context) error
 
 	err = runtime.BindStyledParameter("matrix", true, "id", ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "id", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2584,7 +2672,7 @@ func (w *ServerInterfaceWrapper) GetMatrixNoExplodeArray(ctx echo.Context) error
 
 	err = runtime.BindStyledParameter("matrix", false, "id", ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "id", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2600,7 +2688,7 @@ func (w *ServerInterfaceWrapper) GetMatrixNoExplodeObject(ctx echo.Context) erro
 
 	err = runtime.BindStyledParameter("matrix", false, "id", ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "id", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2634,7 +2722,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "ea", ctx.QueryParams(), &params.Ea)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ea: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "ea", err), "ea", "exploded array"))
 	}
 
 	// ------------- Optional query parameter "a" -------------
@@ -2644,7 +2732,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", false, false, "a", ctx.QueryParams(), &params.A)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "a", err), "a", "array"))
 	}
 
 	// ------------- Optional query parameter "eo" -------------
@@ -2654,7 +2742,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "eo", ctx.QueryParams(), &params.Eo)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter eo: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "eo", err), "eo", "exploded object"))
 	}
 
 	// ------------- Optional query parameter "o" -------------
@@ -2664,7 +2752,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", false, false, "o", ctx.QueryParams(), &params.O)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter o: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "o", err), "o", "object"))
 	}
 
 	// ------------- Optional query parameter "ep" -------------
@@ -2674,7 +2762,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "ep", ctx.QueryParams(), &params.Ep)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ep: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "ep", err), "ep", "exploded primitive"))
 	}
 
 	// ------------- Optional query parameter "p" -------------
@@ -2684,7 +2772,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", false, false, "p", ctx.QueryParams(), &params.P)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "p", err), "p", "primitive"))
 	}
 
 	// ------------- Optional query parameter "co" -------------
@@ -2693,7 +2781,7 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 		var value ComplexObject
 		err = json.Unmarshal([]byte(paramValue), &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'co' as JSON")
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Describe(ctx.Request(), runtime.Message(ctx.Request(), runtime.MsgUnmarshalParamJSON, "co"), "co", "complex object"))
 		}
 		params.Co = &value
 
@@ -2712,7 +2800,7 @@ func (w *ServerInterfaceWrapper) GetSimpleExplodeArray(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameter("simple", true, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2728,7 +2816,7 @@ func (w *ServerInterfaceWrapper) GetSimpleExplodeObject(ctx echo.Context) error
 
 	err = runtime.BindStyledParameter("simple", true, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2744,7 +2832,7 @@ func (w *ServerInterfaceWrapper) GetSimpleNoExplodeArray(ctx echo.Context) error
 
 	err = runtime.BindStyledParameter("simple", false, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2760,7 +2848,7 @@ func (w *ServerInterfaceWrapper) GetSimpleNoExplodeObject(ctx echo.Context) erro
 
 	err = runtime.BindStyledParameter("simple", false, "param", ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2774,9 +2862,15 @@ func (w *ServerInterfaceWrapper) GetSimplePrimitive(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param int32
 
-	err = runtime.BindStyledParameter("simple", false, "param", ctx.Param("param"), &param)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+	if paramValue := ctx.Param("param"); paramValue != "" {
+		var value int64
+		value, err = strconv.ParseInt(paramValue, 10, 32)
+		param = int32(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidParamFormat, "param", err))
+		}
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgEmptyParam, "param"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2822,6 +2916,53 @@ func RegisterHandlers(router interface {
 
 }
 
+// RegisterHandlersForHosts adds each server route to e for the hosts of the
+// servers of the spec, under their base paths, so that one echo instance can
+// serve several APIs based on the Host header. The middlewares m apply to
+// these routes only. The routes are added for:
+//
+//	openapitest.deepmap.ai
+func RegisterHandlersForHosts(e *echo.Echo, si ServerInterface, m ...echo.MiddlewareFunc) {
+	hosts := []struct {
+		host      string
+		basePaths []string
+	}{
+		{"openapitest.deepmap.ai", []string{""}},
+	}
+	for _, h := range hosts {
+		// Routers are per host, so each host is only created once.
+		router := e.Host(h.host)
+		for _, basePath := range h.basePaths {
+			group := router.Group(basePath)
+			group.Use(m...)
+			RegisterHandlers(group, si)
+		}
+	}
+}
+
+// NewInMemoryClient creates a new Client which passes its requests directly to
+// the handlers of si, without going through the network. Parameters and
+// bodies are still marshaled and bound by the generated client and server
+// code, which makes this useful for fast integration tests, and for composing
+// services generated from the same spec in a single process.
+func NewInMemoryClient(si ServerInterface, opts ...ClientOption) (*Client, error) {
+	e := echo.New()
+	RegisterHandlers(e, si)
+	handler := http.Handler(e)
+	opts = append([]ClientOption{WithHTTPClient(runtime.NewHandlerDoer(handler))}, opts...)
+	return NewClient("http://in-memory", opts...)
+}
+
+// NewInMemoryClientWithResponses creates a new ClientWithResponses on top of
+// NewInMemoryClient.
+func NewInMemoryClientWithResponses(si ServerInterface, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewInMemoryClient(si, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
@@ -1,6 +1,7 @@
 package parameters
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -326,6 +327,15 @@ func TestParameterBinding(t *testing.T) {
 	assert.EqualValues(t, &expectedPrimitive, ts.primitive)
 	ts.reset()
 
+	// Values which don't fit the parameter type are rejected
+	result = testutil.NewRequest().Get("/simplePrimitive/five").Go(t, e)
+	assert.Equal(t, http.StatusBadRequest, result.Code())
+	assert.Nil(t, ts.primitive)
+	result = testutil.NewRequest().Get("/simplePrimitive/4294967296").Go(t, e)
+	assert.Equal(t, http.StatusBadRequest, result.Code())
+	assert.Nil(t, ts.primitive)
+	ts.reset()
+
 	// ---------------------- Test Form Query Parameters ----------------------
 	//  (GET /queryForm)
 
@@ -644,3 +654,109 @@ func TestClientQueryParams(t *testing.T) {
 	assert.EqualValues(t, hParams, *ts.headerParams)
 	ts.reset()
 }
+
+func TestInMemoryClient(t *testing.T) {
+	var ts testServer
+	client, err := NewInMemoryClientWithResponses(&ts)
+	require.NoError(t, err)
+
+	expectedObject := Object{
+		FirstName: "Alex",
+		Role:      "admin",
+	}
+	var expectedPrimitive int32 = 5
+
+	rsp, err := client.GetSimplePrimitiveWithResponse(context.Background(), expectedPrimitive)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode())
+	assert.EqualValues(t, &expectedPrimitive, ts.primitive)
+	ts.reset()
+
+	rsp2, err := client.GetMatrixExplodeObjectWithResponse(context.Background(), expectedObject)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp2.StatusCode())
+	assert.EqualValues(t, &expectedObject, ts.object)
+	ts.reset()
+
+	qParams := GetQueryFormParams{
+		Ep: &expectedPrimitive,
+	}
+	rsp3, err := client.GetQueryFormWithResponse(context.Background(), &qParams)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp3.StatusCode())
+	require.NotNil(t, ts.queryParams)
+	assert.EqualValues(t, &expectedPrimitive, ts.queryParams.Ep)
+	ts.reset()
+}
+
+// The benchmarks below measure the per-request overhead of the generated echo
+// wrappers, which is mostly parameter binding. The handlers themselves don't
+// do any work.
+func benchmarkServer(b *testing.B, req *http.Request) {
+	var ts testServer
+	e := echo.New()
+	RegisterHandlers(e, &ts)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			b.Fatalf("unexpected status code %d", rec.Code)
+		}
+	}
+}
+
+func BenchmarkServerSimplePrimitive(b *testing.B) {
+	req, err := NewGetSimplePrimitiveRequest("http://example.com", 5)
+	require.NoError(b, err)
+	benchmarkServer(b, req)
+}
+
+func BenchmarkServerSimpleExplodeObject(b *testing.B) {
+	req, err := NewGetSimpleExplodeObjectRequest("http://example.com", Object{
+		FirstName: "Alex",
+		Role:      "admin",
+	})
+	require.NoError(b, err)
+	benchmarkServer(b, req)
+}
+
+func BenchmarkServerContentObject(b *testing.B) {
+	req, err := NewGetContentObjectRequest("http://example.com", ComplexObject{
+		Object: Object{
+			FirstName: "Alex",
+			Role:      "admin",
+		},
+		Id: "12345",
+	})
+	require.NoError(b, err)
+	benchmarkServer(b, req)
+}
+
+func BenchmarkServerQueryForm(b *testing.B) {
+	var primitive int32 = 5
+	array := []int32{3, 4, 5}
+	req, err := NewGetQueryFormRequest("http://example.com", &GetQueryFormParams{
+		Ea: &array,
+		Eo: &Object{
+			FirstName: "Alex",
+			Role:      "admin",
+		},
+		P: &primitive,
+	})
+	require.NoError(b, err)
+	benchmarkServer(b, req)
+}
+
+func BenchmarkServerHeader(b *testing.B) {
+	var primitive int32 = 5
+	array := []int32{3, 4, 5}
+	req, err := NewGetHeaderRequest("http://example.com", &GetHeaderParams{
+		XArray:     &array,
+		XPrimitive: &primitive,
+	})
+	require.NoError(b, err)
+	benchmarkServer(b, req)
+}
@@ -0,0 +1,3 @@
+package presence
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=presence --generate=types,client --field-presence -o presence.gen.go presence.yaml
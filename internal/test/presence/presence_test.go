@@ -0,0 +1,85 @@
+package presence
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
+func TestFieldPresence(t *testing.T) {
+	var update PetUpdate
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Rex", "tag": null}`), &update))
+	assert.Equal(t, "Rex", *update.Name)
+	assert.Nil(t, update.Tag)
+	assert.True(t, update.IsSet("name"))
+	// An explicit null is told apart from a missing property.
+	assert.True(t, update.IsSet("tag"))
+	assert.False(t, update.IsSet("age"))
+	assert.False(t, update.IsSet("unknown"))
+	assert.Equal(t, []string{"name", "tag"}, update.SetFields())
+
+	// Values built in code mark their properties themselves.
+	var built PetUpdate
+	age := 3
+	built.Age = &age
+	require.NoError(t, built.MarkSet("age"))
+	assert.Equal(t, []string{"age"}, built.SetFields())
+	assert.EqualError(t, built.MarkSet("owner"), `unknown property "owner"`)
+
+	// Marshaling isn't affected.
+	data, err := json.Marshal(update)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "Rex"}`, string(data))
+
+	var body UpdatePetJSONRequestBody
+	require.NoError(t, json.Unmarshal([]byte(`{"age": 4}`), &body))
+	assert.Equal(t, []string{"age"}, PetUpdate(body).SetFields())
+}
+
+func TestFieldPresenceAllOf(t *testing.T) {
+	var pet Pet
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 7, "name": "Rex"}`), &pet))
+	assert.Equal(t, int64(7), pet.Id)
+	assert.Equal(t, "Rex", *pet.Name)
+	assert.Equal(t, []string{"name", "id"}, pet.SetFields())
+	assert.True(t, pet.IsSet("id"))
+	assert.True(t, pet.IsSet("name"))
+	assert.False(t, pet.IsSet("tag"))
+
+	// The properties of the embedded types are marked in them.
+	require.NoError(t, pet.MarkSet("tag", "id"))
+	assert.True(t, pet.PetUpdate.IsSet("tag"))
+	assert.True(t, pet.IsSet("tag"))
+	assert.EqualError(t, pet.MarkSet("owner"), `unknown property "owner"`)
+}
+
+func TestFieldPresenceAdditionalProperties(t *testing.T) {
+	var labels Labels
+	require.NoError(t, json.Unmarshal([]byte(`{"owner": "Alex", "color": "brown"}`), &labels))
+	assert.True(t, labels.IsSet("owner"))
+	assert.False(t, labels.IsSet("color"))
+	color, found := labels.Get("color")
+	assert.True(t, found)
+	assert.Equal(t, "brown", color)
+}
+
+func TestFieldPresenceEncrypted(t *testing.T) {
+	cipher, err := runtime.NewAESFieldCipher([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+	EncryptedFieldCipher = cipher
+	defer func() { EncryptedFieldCipher = nil }()
+
+	value := "s3cret"
+	data, err := json.Marshal(Secret{Value: &value})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), value)
+
+	var secret Secret
+	require.NoError(t, json.Unmarshal(data, &secret))
+	assert.Equal(t, value, *secret.Value)
+	assert.Equal(t, []string{"value"}, secret.SetFields())
+}
@@ -0,0 +1,3 @@
+package proxy
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=proxy --generate=types,client,server -o proxy.gen.go proxy.yaml
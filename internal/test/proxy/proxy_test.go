@@ -0,0 +1,86 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct{}
+
+// ForwardObjects echoes the raw request, as a gateway passing it on to an
+// upstream service would.
+func (s *testServer) ForwardObjects(ctx echo.Context) error {
+	req := ctx.Request()
+	rsp := ctx.Response()
+	rsp.Header().Set("Content-Type", req.Header.Get("Content-Type"))
+	rsp.Header().Set("X-Service", ctx.Param("service"))
+	rsp.Header().Set("X-Trace", req.URL.Query().Get("trace"))
+	rsp.WriteHeader(http.StatusOK)
+	_, err := io.Copy(rsp, req.Body)
+	return err
+}
+
+func (s *testServer) GetObject(ctx echo.Context, id int) error {
+	name := "object " + strconv.Itoa(id)
+	return ctx.JSON(http.StatusOK, Object{Name: &name})
+}
+
+func TestProxy(t *testing.T) {
+	e := echo.New()
+	RegisterHandlers(e, &testServer{})
+	server := httptest.NewServer(e)
+	defer server.Close()
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	t.Run("server", func(t *testing.T) {
+		// Neither the parameters nor the body are parsed, so that values which
+		// only the upstream service understands are passed through.
+		body := `{"name": "raw", "extra": [1, 2]}`
+		rsp, err := http.Post(server.URL+"/upstream/objects/objects?trace=not-a-number", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+		assert.Equal(t, "objects", rsp.Header.Get("X-Service"))
+		assert.Equal(t, "not-a-number", rsp.Header.Get("X-Trace"))
+		echoed, err := ioutil.ReadAll(rsp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(echoed))
+	})
+
+	t.Run("client", func(t *testing.T) {
+		trace := 7
+		body := `{"name": "streamed"}`
+		rsp, err := client.ForwardObjectsWithBodyWithResponse(context.Background(), "objects", &ForwardObjectsParams{Trace: &trace}, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+		assert.Equal(t, "7", rsp.Header.Get("X-Trace"))
+		// The response is returned unread.
+		echoed, err := ioutil.ReadAll(rsp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(echoed))
+	})
+
+	t.Run("other operations", func(t *testing.T) {
+		rsp, err := client.GetObjectWithResponse(context.Background(), 3)
+		require.NoError(t, err)
+		require.NotNil(t, rsp.JSON200)
+		assert.Equal(t, "object 3", *rsp.JSON200.Name)
+
+		// Parameters of other operations are still bound.
+		invalid, err := http.Get(server.URL + "/objects/not-a-number")
+		require.NoError(t, err)
+		invalid.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, invalid.StatusCode)
+	})
+}
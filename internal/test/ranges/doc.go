@@ -0,0 +1,3 @@
+package ranges
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=ranges --generate=types,client,tag-clients,server,in-memory-client,fake-client -o ranges.gen.go ranges.yaml
@@ -0,0 +1,120 @@
+package ranges
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+)
+
+var content = []byte("abcdefghij")
+
+type testServer struct{}
+
+func (testServer) GetFile(ctx echo.Context, name string) error {
+	runtime.ServeContent(ctx.Response(), ctx.Request(), "application/octet-stream", time.Time{}, bytes.NewReader(content))
+	return nil
+}
+
+func TestRangeClient(t *testing.T) {
+	client, err := NewInMemoryClientWithResponses(testServer{})
+	require.NoError(t, err)
+
+	rsp, err := client.GetFileRangeWithResponse(context.Background(), "letters", runtime.ByteRange{Start: 2, End: 4})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, rsp.StatusCode())
+	require.NotNil(t, rsp.PartialContent)
+	assert.Equal(t, runtime.ContentRange{Start: 2, End: 4, Size: 10}, rsp.PartialContent.ContentRange)
+	assert.Equal(t, []byte("cde"), rsp.PartialContent.Body)
+
+	rsp, err = client.GetFileRangeWithResponse(context.Background(), "letters", runtime.ByteRange{Start: 20, End: -1})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, rsp.StatusCode())
+	assert.Nil(t, rsp.PartialContent)
+
+	rsp, err = client.GetFileWithResponse(context.Background(), "letters")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode())
+	assert.Nil(t, rsp.PartialContent)
+	assert.Equal(t, content, rsp.Body)
+}
+
+func TestFakeRangeClient(t *testing.T) {
+	fake := NewFakeClient().GetFileReturnsResponse(nil, nil)
+	_, err := fake.GetFileRange(context.Background(), "letters", runtime.ByteRange{Start: -3})
+	require.NoError(t, err)
+
+	calls := fake.GetFileCalls()
+	require.Len(t, calls, 1)
+	require.NotNil(t, calls[0].Range)
+	assert.Equal(t, runtime.ByteRange{Start: -3}, *calls[0].Range)
+}
+
+// writerAt collects the bytes written to it at any offset.
+type writerAt struct {
+	buf []byte
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	if end := int(off) + len(p); end > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, end-len(w.buf))...)
+	}
+	return copy(w.buf[off:], p), nil
+}
+
+func TestDownloadResumable(t *testing.T) {
+	client, err := NewInMemoryClientWithResponses(testServer{})
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(content)
+	w := writerAt{buf: append([]byte(nil), content[:4]...)}
+	n, err := client.DownloadGetFileResumable(context.Background(), "letters", &w,
+		runtime.WithDownloadOffset(4), runtime.WithDownloadSize(int64(len(content))))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, content, w.buf)
+
+	_, err = client.DownloadGetFileResumable(context.Background(), "letters", &writerAt{},
+		runtime.WithDownloadDigest(sha256.New(), digest[:]))
+	assert.NoError(t, err)
+}
+
+func TestTagClient(t *testing.T) {
+	client, err := NewInMemoryClient(testServer{})
+	require.NoError(t, err)
+
+	var edited []string
+	files := client.Files(WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		edited = append(edited, req.URL.Path)
+		return nil
+	}))
+	rsp, err := files.GetFileRange(context.Background(), "letters", runtime.ByteRange{Start: 0, End: 1})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, rsp.StatusCode)
+	assert.Equal(t, []string{"/files/letters"}, edited)
+
+	// The options only apply to the sub-client
+	_, err = client.GetFile(context.Background(), "letters")
+	require.NoError(t, err)
+	assert.Len(t, edited, 1)
+
+	failing := client.Files(func(*Client) error {
+		return errors.New("bad option")
+	})
+	_, err = failing.GetFile(context.Background(), "letters")
+	assert.EqualError(t, err, "bad option")
+
+	fake := NewFakeClient().GetFileReturnsResponse(rsp, nil)
+	_, err = NewFilesClient(fake).GetFile(context.Background(), "letters")
+	require.NoError(t, err)
+	assert.Len(t, fake.GetFileCalls(), 1)
+}
@@ -0,0 +1,3 @@
+package responses
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=responses --generate=types,client,fake-client -o responses.gen.go responses.yaml
@@ -0,0 +1,845 @@
+// Package responses provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package responses
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Error defines model for Error.
+type Error struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// Thing defines model for Thing.
+type Thing struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	client := *c
+	// Editors added to the clone mustn't share the array of c.
+	client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+	client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+	client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+	for scheme, provider := range c.SecurityProviders {
+		client.SecurityProviders[scheme] = provider
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, editors...)
+		return nil
+	}
+}
+
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
+		return nil
+	}
+}
+
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//	client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+	if len(c.SecurityProviders) != 0 {
+		requirement, err := security.Select(func(scheme string) bool {
+			_, found := c.SecurityProviders[scheme]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		for _, scheme := range requirement.Schemes() {
+			if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+	}
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Body != nil {
+		rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+	}
+	additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+	if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+		if rsp.Body != nil {
+			rsp.Body.Close()
+		}
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+	for _, r := range c.ResponseEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		warmupUrl, err := url.Parse(c.Server)
+		if err != nil {
+			return nil, err
+		}
+		warmupUrl, err = warmupUrl.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, warmupUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, req, nil, nil)
+	}
+	return runtime.Warmup(ctx, n, send, opts...)
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetRanged request
+	GetRanged(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetThing request
+	GetThing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListThings request
+	ListThings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetRanged(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRangedRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) GetThing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetThingRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+func (c *Client) ListThings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListThingsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, nil, reqEditors)
+}
+
+// NewGetRangedRequest generates requests for GetRanged
+func NewGetRangedRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/ranged"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetThingRequest generates requests for GetThing
+func NewGetThingRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/thing"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListThingsRequest generates requests for ListThings
+func NewListThingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryUrl, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	queryUrl, err = queryUrl.Parse(fmt.Sprintf("/things"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+type getRangedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Thing
+	JSON2XX      *Thing
+	JSON4XX      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r getRangedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getRangedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getThingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Thing
+	XML404       *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r getThingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getThingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type listThingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON2XX      *[]Thing
+}
+
+// Status returns HTTPResponse.Status
+func (r listThingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r listThingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetRangedWithResponse request returning *GetRangedResponse
+func (c *ClientWithResponses) GetRangedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*getRangedResponse, error) {
+	rsp, err := c.GetRanged(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRangedResponse(rsp)
+}
+
+// GetThingWithResponse request returning *GetThingResponse
+func (c *ClientWithResponses) GetThingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*getThingResponse, error) {
+	rsp, err := c.GetThing(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetThingResponse(rsp)
+}
+
+// ListThingsWithResponse request returning *ListThingsResponse
+func (c *ClientWithResponses) ListThingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*listThingsResponse, error) {
+	rsp, err := c.ListThings(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListThingsResponse(rsp)
+}
+
+// ParseGetRangedResponse parses an HTTP response from a GetRangedWithResponse call
+func ParseGetRangedResponse(rsp *http.Response) (*getRangedResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getRangedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		response.JSON200 = &Thing{}
+		if err := json.Unmarshal(bodyBytes, response.JSON200); err != nil {
+			return nil, err
+		}
+
+	case rsp.StatusCode == 200:
+		break // Declared status codes aren't parsed as a less specific response
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode/100 == 2:
+		response.JSON2XX = &Thing{}
+		if err := json.Unmarshal(bodyBytes, response.JSON2XX); err != nil {
+			return nil, err
+		}
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode/100 == 4:
+		response.JSON4XX = &Error{}
+		if err := json.Unmarshal(bodyBytes, response.JSON4XX); err != nil {
+			return nil, err
+		}
+
+	case rsp.StatusCode/100 == 2 || rsp.StatusCode/100 == 4:
+		break // Declared status codes aren't parsed as a less specific response
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
+		response.JSONDefault = &Error{}
+		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
+			return nil, err
+		}
+
+	}
+
+	return response, nil
+}
+
+// ParseGetThingResponse parses an HTTP response from a GetThingWithResponse call
+func ParseGetThingResponse(rsp *http.Response) (*getThingResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getThingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		response.JSON200 = &Thing{}
+		if err := json.Unmarshal(bodyBytes, response.JSON200); err != nil {
+			return nil, err
+		}
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 404:
+		response.XML404 = &Error{}
+		if err := xml.Unmarshal(bodyBytes, response.XML404); err != nil {
+			return nil, err
+		}
+
+	case rsp.StatusCode == 200:
+	// Content-type (text/plain) unsupported
+
+	case rsp.StatusCode == 204 || rsp.StatusCode == 404:
+		break // Declared status codes aren't parsed as a less specific response
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
+		response.JSONDefault = &Error{}
+		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
+			return nil, err
+		}
+
+	}
+
+	return response, nil
+}
+
+// ParseListThingsResponse parses an HTTP response from a ListThingsWithResponse call
+func ParseListThingsResponse(rsp *http.Response) (*listThingsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &listThingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode/100 == 2:
+		response.JSON2XX = &[]Thing{}
+		if err := json.Unmarshal(bodyBytes, response.JSON2XX); err != nil {
+			return nil, err
+		}
+
+	}
+
+	return response, nil
+}
+
+// FakeClient implements ClientInterface without performing any HTTP requests.
+// Responses are programmed per operation, and every call is recorded, so that
+// code built on top of the client can be tested in isolation. As no request
+// is built, the request editors passed to calls are ignored.
+type FakeClient struct {
+	mu sync.Mutex
+
+	getRangedStub   func(call FakeGetRangedCall) (*http.Response, error)
+	getRangedCalls  []FakeGetRangedCall
+	getThingStub    func(call FakeGetThingCall) (*http.Response, error)
+	getThingCalls   []FakeGetThingCall
+	listThingsStub  func(call FakeListThingsCall) (*http.Response, error)
+	listThingsCalls []FakeListThingsCall
+}
+
+var _ ClientInterface = (*FakeClient)(nil)
+
+// NewFakeClient creates a FakeClient with no programmed responses.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{}
+}
+
+// FakeGetRangedCall records the arguments of a single GetRanged call.
+type FakeGetRangedCall struct {
+	Ctx context.Context
+}
+
+// GetRangedStub sets the function which produces the response of every
+// following GetRanged call.
+func (f *FakeClient) GetRangedStub(stub func(call FakeGetRangedCall) (*http.Response, error)) *FakeClient {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getRangedStub = stub
+	return f
+}
+
+// GetRangedReturnsResponse makes GetRanged return the given response and
+// error. The same response is returned on every call, so its body can only be
+// read once.
+func (f *FakeClient) GetRangedReturnsResponse(rsp *http.Response, err error) *FakeClient {
+	return f.GetRangedStub(func(FakeGetRangedCall) (*http.Response, error) {
+		return rsp, err
+	})
+}
+
+// GetRangedReturns makes GetRanged respond with a 200 status and the
+// JSON encoding of body, or fail with err when it isn't nil.
+func (f *FakeClient) GetRangedReturns(body Thing, err error) *FakeClient {
+	return f.GetRangedStub(func(FakeGetRangedCall) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		return runtime.NewJSONResponse(200, body)
+	})
+}
+
+// GetRangedCalls returns all the GetRanged calls made so far.
+func (f *FakeClient) GetRangedCalls() []FakeGetRangedCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeGetRangedCall(nil), f.getRangedCalls...)
+}
+
+func (f *FakeClient) recordGetRanged(call FakeGetRangedCall) (*http.Response, error) {
+	f.mu.Lock()
+	f.getRangedCalls = append(f.getRangedCalls, call)
+	stub := f.getRangedStub
+	f.mu.Unlock()
+	if stub == nil {
+		return nil, fmt.Errorf("FakeClient: no response programmed for GetRanged")
+	}
+	return stub(call)
+}
+
+func (f *FakeClient) GetRanged(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return f.recordGetRanged(FakeGetRangedCall{
+		Ctx: ctx,
+	})
+}
+
+// FakeGetThingCall records the arguments of a single GetThing call.
+type FakeGetThingCall struct {
+	Ctx context.Context
+}
+
+// GetThingStub sets the function which produces the response of every
+// following GetThing call.
+func (f *FakeClient) GetThingStub(stub func(call FakeGetThingCall) (*http.Response, error)) *FakeClient {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getThingStub = stub
+	return f
+}
+
+// GetThingReturnsResponse makes GetThing return the given response and
+// error. The same response is returned on every call, so its body can only be
+// read once.
+func (f *FakeClient) GetThingReturnsResponse(rsp *http.Response, err error) *FakeClient {
+	return f.GetThingStub(func(FakeGetThingCall) (*http.Response, error) {
+		return rsp, err
+	})
+}
+
+// GetThingReturns makes GetThing respond with a 200 status and the
+// JSON encoding of body, or fail with err when it isn't nil.
+func (f *FakeClient) GetThingReturns(body Thing, err error) *FakeClient {
+	return f.GetThingStub(func(FakeGetThingCall) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		return runtime.NewJSONResponse(200, body)
+	})
+}
+
+// GetThingCalls returns all the GetThing calls made so far.
+func (f *FakeClient) GetThingCalls() []FakeGetThingCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeGetThingCall(nil), f.getThingCalls...)
+}
+
+func (f *FakeClient) recordGetThing(call FakeGetThingCall) (*http.Response, error) {
+	f.mu.Lock()
+	f.getThingCalls = append(f.getThingCalls, call)
+	stub := f.getThingStub
+	f.mu.Unlock()
+	if stub == nil {
+		return nil, fmt.Errorf("FakeClient: no response programmed for GetThing")
+	}
+	return stub(call)
+}
+
+func (f *FakeClient) GetThing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return f.recordGetThing(FakeGetThingCall{
+		Ctx: ctx,
+	})
+}
+
+// FakeListThingsCall records the arguments of a single ListThings call.
+type FakeListThingsCall struct {
+	Ctx context.Context
+}
+
+// ListThingsStub sets the function which produces the response of every
+// following ListThings call.
+func (f *FakeClient) ListThingsStub(stub func(call FakeListThingsCall) (*http.Response, error)) *FakeClient {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listThingsStub = stub
+	return f
+}
+
+// ListThingsReturnsResponse makes ListThings return the given response and
+// error. The same response is returned on every call, so its body can only be
+// read once.
+func (f *FakeClient) ListThingsReturnsResponse(rsp *http.Response, err error) *FakeClient {
+	return f.ListThingsStub(func(FakeListThingsCall) (*http.Response, error) {
+		return rsp, err
+	})
+}
+
+// ListThingsReturns makes ListThings respond with a 200 status and the
+// JSON encoding of body, or fail with err when it isn't nil.
+func (f *FakeClient) ListThingsReturns(body []Thing, err error) *FakeClient {
+	return f.ListThingsStub(func(FakeListThingsCall) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		return runtime.NewJSONResponse(200, body)
+	})
+}
+
+// ListThingsCalls returns all the ListThings calls made so far.
+func (f *FakeClient) ListThingsCalls() []FakeListThingsCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeListThingsCall(nil), f.listThingsCalls...)
+}
+
+func (f *FakeClient) recordListThings(call FakeListThingsCall) (*http.Response, error) {
+	f.mu.Lock()
+	f.listThingsCalls = append(f.listThingsCalls, call)
+	stub := f.listThingsStub
+	f.mu.Unlock()
+	if stub == nil {
+		return nil, fmt.Errorf("FakeClient: no response programmed for ListThings")
+	}
+	return stub(call)
+}
+
+func (f *FakeClient) ListThings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return f.recordListThings(FakeListThingsCall{
+		Ctx: ctx,
+	})
+}
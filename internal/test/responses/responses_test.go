@@ -0,0 +1,102 @@
+package responses
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cannedResponse(status int, contentType, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestResponsePrecedence(t *testing.T) {
+	// A declared status code takes precedence over default, even though
+	// both have a JSON body.
+	rsp, err := ParseGetThingResponse(cannedResponse(200, "application/json", `{"name": "thing"}`))
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON200)
+	assert.Equal(t, "thing", *rsp.JSON200.Name)
+	assert.Nil(t, rsp.JSONDefault)
+
+	// A declared status code with a content type which isn't unmarshaled
+	// doesn't fall through to default.
+	rsp, err = ParseGetThingResponse(cannedResponse(200, "text/plain", `thing`))
+	require.NoError(t, err)
+	assert.Nil(t, rsp.JSON200)
+	assert.Nil(t, rsp.JSONDefault)
+	assert.Equal(t, "thing", string(rsp.Body))
+
+	rsp, err = ParseGetThingResponse(cannedResponse(404, "application/json", `{"message": "missing"}`))
+	require.NoError(t, err)
+	assert.Nil(t, rsp.XML404)
+	assert.Nil(t, rsp.JSONDefault)
+
+	rsp, err = ParseGetThingResponse(cannedResponse(404, "application/xml", `<Error><message>missing</message></Error>`))
+	require.NoError(t, err)
+	require.NotNil(t, rsp.XML404)
+	assert.Nil(t, rsp.JSONDefault)
+
+	rsp, err = ParseGetThingResponse(cannedResponse(204, "application/json", `{"message": "empty"}`))
+	require.NoError(t, err)
+	assert.Nil(t, rsp.JSONDefault)
+
+	// Status codes which aren't declared are parsed as default, including
+	// successful ones.
+	for _, status := range []int{201, 500} {
+		rsp, err = ParseGetThingResponse(cannedResponse(status, "application/json", `{"message": "other"}`))
+		require.NoError(t, err)
+		assert.Nil(t, rsp.JSON200)
+		require.NotNil(t, rsp.JSONDefault, "status %d", status)
+		assert.Equal(t, "other", *rsp.JSONDefault.Message)
+	}
+}
+
+func TestStatusCodeRanges(t *testing.T) {
+	// A declared status code takes precedence over its range.
+	rsp, err := ParseGetRangedResponse(cannedResponse(200, "application/json", `{"name": "exact"}`))
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON200)
+	assert.Nil(t, rsp.JSON2XX)
+
+	rsp, err = ParseGetRangedResponse(cannedResponse(200, "application/xml", `<Thing/>`))
+	require.NoError(t, err)
+	assert.Nil(t, rsp.JSON200)
+	assert.Nil(t, rsp.JSON2XX)
+
+	rsp, err = ParseGetRangedResponse(cannedResponse(201, "application/json", `{"name": "ranged"}`))
+	require.NoError(t, err)
+	assert.Nil(t, rsp.JSON200)
+	require.NotNil(t, rsp.JSON2XX)
+	assert.Equal(t, "ranged", *rsp.JSON2XX.Name)
+
+	// A range takes precedence over default.
+	rsp, err = ParseGetRangedResponse(cannedResponse(404, "application/json", `{"message": "missing"}`))
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON4XX)
+	assert.Nil(t, rsp.JSONDefault)
+
+	rsp, err = ParseGetRangedResponse(cannedResponse(503, "application/json", `{"message": "unavailable"}`))
+	require.NoError(t, err)
+	assert.Nil(t, rsp.JSON4XX)
+	require.NotNil(t, rsp.JSONDefault)
+
+	// The fake client responds with the first status code of the range.
+	fake := NewFakeClient().ListThingsReturns([]Thing{{}}, nil)
+	res, err := fake.ListThings(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+	listed, err := ParseListThingsResponse(res)
+	require.NoError(t, err)
+	require.NotNil(t, listed.JSON2XX)
+	assert.Len(t, *listed.JSON2XX, 1)
+}
@@ -0,0 +1,3 @@
+package schemainfo
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=schemainfo --generate=types,schema-export -o schemainfo.gen.go schemainfo.yaml
@@ -0,0 +1,70 @@
+package schemainfo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/schemainfo"
+)
+
+func TestSchemaInfo(t *testing.T) {
+	assert.Equal(t, []string{"Named", "Owner", "Resource"}, SchemaInfo.Names())
+
+	resource, found := SchemaInfo.Lookup("Resource")
+	require.True(t, found)
+	name, found := resource.Field("name")
+	require.True(t, found)
+	assert.True(t, name.Required)
+	assert.Equal(t, "^[a-z][a-z0-9-]*$", name.Schema.Validation.Pattern)
+	assert.Equal(t, uint64(3), name.Schema.Validation.MinLength)
+	assert.Equal(t, uint64(63), *name.Schema.Validation.MaxLength)
+
+	kind, _ := resource.Field("kind")
+	assert.Equal(t, []string{`"bucket"`, `"queue"`}, kind.Schema.Validation.Enum)
+	size, _ := resource.Field("size")
+	assert.Equal(t, "10", size.Schema.Validation.Default)
+	assert.Equal(t, 1024.0, *size.Schema.Validation.Maximum)
+
+	// allOf schemas are flattened into the fields of the object.
+	owner, _ := SchemaInfo.Lookup("Owner")
+	email, found := owner.Field("email")
+	require.True(t, found)
+	assert.True(t, email.Schema.ReadOnly)
+	_, found = owner.Field("name")
+	assert.True(t, found)
+
+	var paths []string
+	err := SchemaInfo.Walk("Resource", func(path []string, s *schemainfo.Schema) error {
+		paths = append(paths, strings.Join(path, "."))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, paths, "owner.email")
+	assert.Contains(t, paths, "children.[]")
+	assert.Contains(t, paths, "labels.{}")
+}
+
+// TestSchemaInfoMatchesTypes checks the Go names of the schemas and fields
+// against the generated types.
+func TestSchemaInfoMatchesTypes(t *testing.T) {
+	types := map[string]reflect.Type{
+		"Named":    reflect.TypeOf(Named{}),
+		"Owner":    reflect.TypeOf(Owner{}),
+		"Resource": reflect.TypeOf(Resource{}),
+	}
+	for _, name := range SchemaInfo.Names() {
+		s, _ := SchemaInfo.Lookup(name)
+		goType, found := types[s.GoType]
+		require.True(t, found, s.GoType)
+		for _, f := range s.Fields {
+			field, found := goType.FieldByName(f.GoName)
+			if assert.True(t, found, "%s.%s", s.GoType, f.GoName) {
+				assert.Equal(t, f.Name, strings.Split(field.Tag.Get("json"), ",")[0])
+			}
+		}
+	}
+}
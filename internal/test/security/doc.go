@@ -0,0 +1,3 @@
+package security
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=security --generate=types,client,server -o security.gen.go security.yaml
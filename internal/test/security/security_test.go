@@ -0,0 +1,187 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) Health(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) ListReports(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) DeleteReport(ctx echo.Context, reportId string) error {
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (server) GetStatus(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+// authenticate accepts the API key "key", and the tokens "reader", granted
+// reports.read, and "admin", granted every scope.
+func authenticate(ctx context.Context, r *http.Request, scheme string, scopes []string) error {
+	switch scheme {
+	case "ApiKey":
+		if r.Header.Get("X-Api-Key") != "key" {
+			return errors.New("invalid API key")
+		}
+		return nil
+	case "Bearer":
+		switch r.Header.Get("Authorization") {
+		case "Bearer admin":
+			return nil
+		case "Bearer reader":
+			for _, scope := range scopes {
+				if scope != "reports.read" {
+					return errors.New("missing scope " + scope)
+				}
+			}
+			return nil
+		}
+		return errors.New("invalid token")
+	}
+	return errors.New("unknown scheme " + scheme)
+}
+
+func newServer() *httptest.Server {
+	e := echo.New()
+	e.Use(runtime.SecurityMiddleware(authenticate))
+	RegisterHandlers(e, server{})
+	return httptest.NewServer(e)
+}
+
+func TestSecurityMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(runtime.SecurityMiddleware(authenticate))
+	RegisterHandlers(e, server{})
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		code    int
+	}{
+		{"API key alternative", http.MethodGet, "/reports", map[string]string{"X-Api-Key": "key"}, http.StatusOK},
+		{"token alternative", http.MethodGet, "/reports", map[string]string{"Authorization": "Bearer reader"}, http.StatusOK},
+		{"no alternative", http.MethodGet, "/reports", nil, http.StatusUnauthorized},
+		{"both schemes", http.MethodDelete, "/reports/r-1", map[string]string{"X-Api-Key": "key", "Authorization": "Bearer admin"}, http.StatusNoContent},
+		{"API key only", http.MethodDelete, "/reports/r-1", map[string]string{"X-Api-Key": "key"}, http.StatusUnauthorized},
+		{"missing scope", http.MethodDelete, "/reports/r-1", map[string]string{"X-Api-Key": "key", "Authorization": "Bearer reader"}, http.StatusUnauthorized},
+		{"optional credentials", http.MethodGet, "/status", nil, http.StatusOK},
+		{"not secured", http.MethodGet, "/health", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+
+	// The reasons aren't sent to the caller, only to the error handler.
+	var internal error
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		internal = err.(*echo.HTTPError).Internal
+		e.DefaultHTTPErrorHandler(err, c)
+	}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/reports/r-1", nil)
+	req.Header.Set("Authorization", "Bearer reader")
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "The security requirements of the operation aren't satisfied")
+	assert.NotContains(t, rec.Body.String(), "invalid API key")
+	var securityErr *runtime.SecurityError
+	require.True(t, errors.As(internal, &securityErr))
+	assert.EqualError(t, internal, "none of the security requirements is satisfied: "+
+		"ApiKey and Bearer: ApiKey: invalid API key")
+}
+
+func TestSecurityProviders(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	apiKey := func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Api-Key", "key")
+		return nil
+	}
+	bearer := func(token string) RequestEditorFn {
+		return func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}
+	}
+
+	// With an API key only, the first alternative of listReports is chosen,
+	// and deleteReport, which requires a token too, can't be called.
+	client, err := NewClient(srv.URL, WithSecurityProvider("ApiKey", apiKey))
+	require.NoError(t, err)
+	rsp, err := client.ListReports(context.Background())
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	_, err = client.DeleteReport(context.Background(), "r-1")
+	var unsatisfied *runtime.SecurityUnsatisfiedError
+	require.True(t, errors.As(err, &unsatisfied))
+	assert.EqualError(t, err, "no security provider satisfies any of the security requirements: ApiKey and Bearer")
+
+	// With both, deleteReport gets the credentials of both schemes, and
+	// listReports only those of the first alternative.
+	var sent http.Header
+	client, err = NewClient(srv.URL,
+		WithSecurityProvider("ApiKey", apiKey),
+		WithSecurityProvider("Bearer", bearer("admin")),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			sent = req.Header.Clone()
+			return nil
+		}))
+	require.NoError(t, err)
+	rsp, err = client.DeleteReport(context.Background(), "r-1")
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	rsp, err = client.ListReports(context.Background())
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, "key", sent.Get("X-Api-Key"))
+	assert.Empty(t, sent.Get("Authorization"))
+
+	// Credentials are optional for getStatus, and never sent for health.
+	client, err = NewClient(srv.URL, WithSecurityProvider("Bearer", bearer("reader")),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			sent = req.Header.Clone()
+			return nil
+		}))
+	require.NoError(t, err)
+	rsp, err = client.GetStatus(context.Background())
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, "Bearer reader", sent.Get("Authorization"))
+	rsp, err = client.Health(context.Background())
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(rsp.Body)
+	rsp.Body.Close()
+	require.NoError(t, err)
+	assert.Empty(t, body)
+	assert.Empty(t, sent.Get("Authorization"))
+}
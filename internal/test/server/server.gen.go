@@ -5,11 +5,11 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"github.com/go-chi/chi"
 	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
 	openapi_types "github.com/shawnhankim/oapi-codegen/pkg/types"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -119,12 +119,30 @@ type UpdateResource3JSONBody struct {
 // CreateResourceRequestBody defines body for CreateResource for application/json ContentType.
 type CreateResourceJSONRequestBody CreateResourceJSONBody
 
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b CreateResourceJSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
 // CreateResource2RequestBody defines body for CreateResource2 for application/json ContentType.
 type CreateResource2JSONRequestBody CreateResource2JSONBody
 
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b CreateResource2JSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
 // UpdateResource3RequestBody defines body for UpdateResource3 for application/json ContentType.
 type UpdateResource3JSONRequestBody UpdateResource3JSONBody
 
+// Hash returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b UpdateResource3JSONRequestBody) Hash() (string, error) {
+	return runtime.JSONHash(b)
+}
+
 type ServerInterface interface {
 	// Get resource via simple path (GET /get-simple)
 	GetSimple(w http.ResponseWriter, r *http.Request)
@@ -173,7 +191,7 @@ func GetWithArgsCtx(next http.Handler) http.Handler {
 
 		err = runtime.BindQueryParameter("form", true, false, "optional_argument", r.URL.Query(), &params.OptionalArgument)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter optional_argument: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "optional_argument", err), "optional_argument", "An optional query argument"), http.StatusBadRequest)
 			return
 		}
 
@@ -181,13 +199,13 @@ func GetWithArgsCtx(next http.Handler) http.Handler {
 		if paramValue := r.URL.Query().Get("required_argument"); paramValue != "" {
 
 		} else {
-			http.Error(w, "Query argument required_argument is required, but not found", http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgRequiredQueryParam, "required_argument"), "required_argument", "An optional query argument"), http.StatusBadRequest)
 			return
 		}
 
 		err = runtime.BindQueryParameter("form", true, true, "required_argument", r.URL.Query(), &params.RequiredArgument)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter required_argument: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "required_argument", err), "required_argument", "An optional query argument"), http.StatusBadRequest)
 			return
 		}
 
@@ -198,13 +216,13 @@ func GetWithArgsCtx(next http.Handler) http.Handler {
 			var HeaderArgument int32
 			n := len(valueList)
 			if n != 1 {
-				http.Error(w, fmt.Sprintf("Expected one value for header_argument, got %d", n), http.StatusBadRequest)
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgParamValueCount, "header_argument", n), "header_argument", "An optional query argument"), http.StatusBadRequest)
 				return
 			}
 
 			err = runtime.BindStyledParameter("simple", false, "header_argument", valueList[0], &HeaderArgument)
 			if err != nil {
-				http.Error(w, fmt.Sprintf("Invalid format for parameter header_argument: %s", err), http.StatusBadRequest)
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "header_argument", err), "header_argument", "An optional query argument"), http.StatusBadRequest)
 				return
 			}
 
@@ -228,9 +246,14 @@ func GetWithReferencesCtx(next http.Handler) http.Handler {
 		// ------------- Path parameter "global_argument" -------------
 		var globalArgument int64
 
-		err = runtime.BindStyledParameter("simple", false, "global_argument", chi.URLParam(r, "global_argument"), &globalArgument)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter global_argument: %s", err), http.StatusBadRequest)
+		if paramValue := chi.URLParam(r, "global_argument"); paramValue != "" {
+			globalArgument, err = strconv.ParseInt(paramValue, 10, 64)
+			if err != nil {
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "global_argument", err), "global_argument", "A parameter in global path scope"), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "global_argument"), "global_argument", "A parameter in global path scope"), http.StatusBadRequest)
 			return
 		}
 
@@ -240,7 +263,7 @@ func GetWithReferencesCtx(next http.Handler) http.Handler {
 
 		err = runtime.BindStyledParameter("simple", false, "argument", chi.URLParam(r, "argument"), &argument)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter argument: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "argument", err), "argument", "Some argument"), http.StatusBadRequest)
 			return
 		}
 
@@ -260,9 +283,14 @@ func GetWithContentTypeCtx(next http.Handler) http.Handler {
 		// ------------- Path parameter "content_type" -------------
 		var contentType string
 
-		err = runtime.BindStyledParameter("simple", false, "content_type", chi.URLParam(r, "content_type"), &contentType)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter content_type: %s", err), http.StatusBadRequest)
+		if paramValue := chi.URLParam(r, "content_type"); paramValue != "" {
+			contentType = paramValue
+			if err != nil {
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "content_type", err), "content_type", "Get with a parameter and multiple output types"), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "content_type"), "content_type", "Get with a parameter and multiple output types"), http.StatusBadRequest)
 			return
 		}
 
@@ -284,7 +312,7 @@ func CreateResourceCtx(next http.Handler) http.Handler {
 
 		err = runtime.BindStyledParameter("simple", false, "argument", chi.URLParam(r, "argument"), &argument)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter argument: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "argument", err), "argument", "Some argument"), http.StatusBadRequest)
 			return
 		}
 
@@ -309,9 +337,14 @@ func CreateResource2Ctx(next http.Handler) http.Handler {
 		// ------------- Path parameter "inline_argument" -------------
 		var inlineArgument int
 
-		err = runtime.BindStyledParameter("simple", false, "inline_argument", chi.URLParam(r, "inline_argument"), &inlineArgument)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter inline_argument: %s", err), http.StatusBadRequest)
+		if paramValue := chi.URLParam(r, "inline_argument"); paramValue != "" {
+			inlineArgument, err = strconv.Atoi(paramValue)
+			if err != nil {
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "inline_argument", err), "inline_argument", "Some argument"), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "inline_argument"), "inline_argument", "Some argument"), http.StatusBadRequest)
 			return
 		}
 
@@ -327,7 +360,7 @@ func CreateResource2Ctx(next http.Handler) http.Handler {
 
 		err = runtime.BindQueryParameter("form", true, false, "inline_query_argument", r.URL.Query(), &params.InlineQueryArgument)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter inline_query_argument: %s", err), http.StatusBadRequest)
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "inline_query_argument", err), "inline_query_argument", "Some query argument"), http.StatusBadRequest)
 			return
 		}
 
@@ -347,9 +380,14 @@ func UpdateResource3Ctx(next http.Handler) http.Handler {
 		// ------------- Path parameter "fallthrough" -------------
 		var pFallthrough int
 
-		err = runtime.BindStyledParameter("simple", false, "fallthrough", chi.URLParam(r, "fallthrough"), &pFallthrough)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid format for parameter fallthrough: %s", err), http.StatusBadRequest)
+		if paramValue := chi.URLParam(r, "fallthrough"); paramValue != "" {
+			pFallthrough, err = strconv.Atoi(paramValue)
+			if err != nil {
+				http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgInvalidParamFormat, "fallthrough", err), "fallthrough", "Some argument"), http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "fallthrough"), "fallthrough", "Some argument"), http.StatusBadRequest)
 			return
 		}
 
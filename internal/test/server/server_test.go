@@ -27,3 +27,15 @@ func TestParameters(t *testing.T) {
 
 	assert.Equal(t, 1, len(m.CreateResource2Calls()))
 }
+
+func TestParameterDescriptions(t *testing.T) {
+	h := Handler(&ServerInterfaceMock{})
+
+	// The description of the parameter in the spec tells callers what's
+	// expected of it.
+	req := httptest.NewRequest("GET", "http://openapitest.deepmap.ai/get-with-args", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Query argument required_argument is required, but not found (required_argument: An optional query argument)\n", rr.Body.String())
+}
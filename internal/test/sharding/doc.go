@@ -0,0 +1,3 @@
+package sharding
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=sharding --generate=types,spec --shard-spec-by-tag -o sharding.gen.go sharding.yaml
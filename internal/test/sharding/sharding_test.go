@@ -0,0 +1,90 @@
+package sharding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// operationIDs lists the IDs of the operations of swagger, in lower case,
+// since the generator capitalizes those of the embedded spec.
+func operationIDs(swagger *openapi3.Swagger) []string {
+	var ids []string
+	for _, item := range swagger.Paths {
+		for _, op := range item.Operations() {
+			ids = append(ids, strings.ToLower(op.OperationID))
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestGetSwagger(t *testing.T) {
+	swagger, err := GetSwagger()
+	require.NoError(t, err)
+	require.NoError(t, swagger.Validate(context.Background()))
+
+	original, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("sharding.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, operationIDs(original), operationIDs(swagger))
+
+	// Path level parameters stay with the path
+	require.NotNil(t, swagger.Paths["/pets/{id}"])
+	assert.Len(t, swagger.Paths["/pets/{id}"].Parameters, 1)
+}
+
+func TestGetSwaggerForTags(t *testing.T) {
+	swagger, err := GetSwaggerForTags("pets")
+	require.NoError(t, err)
+	require.NoError(t, swagger.Validate(context.Background()))
+	assert.Equal(t, []string{"getpet", "health"}, operationIDs(swagger))
+
+	// Operations belong to the shard of their first tag
+	swagger, err = GetSwaggerForTags("admin", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"createorder", "deletepet", "health"}, operationIDs(swagger))
+
+	swagger, err = GetSwaggerForTags()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"health"}, operationIDs(swagger))
+}
+
+func TestValidateWithShards(t *testing.T) {
+	swagger, err := GetSwaggerForTags("orders")
+	require.NoError(t, err)
+	router, err := legacy.NewRouter(swagger)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"name": "Rex"}`))
+	req.Header.Set("Content-Type", "application/json")
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+	err = openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+	})
+	assert.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	err = openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+	})
+	assert.Error(t, err)
+
+	// Operations of other tags aren't loaded
+	_, _, err = router.FindRoute(httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+	assert.Error(t, err)
+}
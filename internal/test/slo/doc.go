@@ -0,0 +1,3 @@
+package slo
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=slo --generate=types,server,slo -o slo.gen.go slo.yaml
@@ -0,0 +1,155 @@
+package slo
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) CreateOrder(ctx echo.Context) error {
+	return echo.NewHTTPError(http.StatusServiceUnavailable, "out of stock")
+}
+
+func (server) GetOrder(ctx echo.Context, orderId string) error {
+	slo, found := runtime.SLOFromContext(ctx.Request().Context())
+	if !found || slo.OperationID != "getOrder" {
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (server) Health(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func TestOperationSLOs(t *testing.T) {
+	assert.Equal(t, runtime.SLO{
+		OperationID:   "createOrder",
+		Method:        http.MethodPost,
+		Path:          "/orders",
+		Availability:  0.999,
+		Latency:       300 * time.Millisecond,
+		LatencyTarget: 0.99,
+	}, OperationSLOs["createOrder"])
+	assert.Equal(t, runtime.SLO{
+		OperationID:   "getOrder",
+		Method:        http.MethodGet,
+		Path:          "/orders/{orderId}",
+		Latency:       50 * time.Millisecond,
+		LatencyTarget: 0.95,
+	}, OperationSLOs["getOrder"])
+	assert.NotContains(t, OperationSLOs, "health")
+}
+
+func TestSLOMiddleware(t *testing.T) {
+	var observations []runtime.SLOObservation
+	e := echo.New()
+	e.Use(SLOMiddleware(func(ctx context.Context, o runtime.SLOObservation) {
+		observations = append(observations, o)
+	}))
+	RegisterHandlers(e, server{})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/o-1", nil))
+	require.Len(t, observations, 1)
+	assert.Equal(t, "getOrder", observations[0].OperationID)
+	assert.Equal(t, http.StatusOK, observations[0].Status)
+	assert.True(t, observations[0].Available())
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil))
+	require.Len(t, observations, 2)
+	assert.Equal(t, "createOrder", observations[1].OperationID)
+	assert.Equal(t, http.StatusServiceUnavailable, observations[1].Status)
+	assert.False(t, observations[1].Available())
+	assert.Equal(t, "503", observations[1].Labels()["code"])
+
+	// Operations without an SLO aren't observed.
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Len(t, observations, 2)
+}
+
+func TestSLOLatencyHistogram(t *testing.T) {
+	// The trace ID would come from the span of the request.
+	type traceIDKey struct{}
+	histogram := NewSLOLatencyHistogram("http_request_duration_seconds", func(ctx context.Context) string {
+		traceID, _ := ctx.Value(traceIDKey{}).(string)
+		return traceID
+	})
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := context.WithValue(c.Request().Context(), traceIDKey{}, "0af7651916cd43dd8448eb211c80319c")
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	e.Use(SLOMiddleware(histogram.RecordSLO))
+	RegisterHandlers(e, server{})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/o-1", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	rec := httptest.NewRecorder()
+	histogram.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	// The latency thresholds of the SLOs are buckets, and requests are
+	// recorded with their trace ID as an exemplar.
+	assert.Regexp(t, `http_request_duration_seconds_bucket\{operation="getOrder",code="200",le="[0-9.]+"\} 1 # \{trace_id="0af7651916cd43dd8448eb211c80319c"\} `, body)
+	assert.Contains(t, body, `http_request_duration_seconds_bucket{operation="getOrder",code="200",le="0.3"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{operation="getOrder",code="200"} 1`)
+	assert.NotContains(t, body, "health")
+}
+
+func TestLatencyMiddleware(t *testing.T) {
+	histogram := NewSLOLatencyHistogram("http_request_duration_seconds", nil)
+	var observations []runtime.SLOObservation
+	e := echo.New()
+	e.Use(runtime.LatencyMiddleware(histogram))
+	e.Use(SLOMiddleware(func(ctx context.Context, o runtime.SLOObservation) {
+		observations = append(observations, o)
+	}))
+	RegisterHandlers(e, server{})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/o-1", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// The health operation has no SLO, but its latency is observed all the
+	// same.
+	rec := httptest.NewRecorder()
+	histogram.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, `http_request_duration_seconds_count{operation="getOrder",code="200"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{operation="health",code="200"} 1`)
+	require.Len(t, observations, 1)
+	assert.Equal(t, "getOrder", observations[0].OperationID)
+}
+
+func TestSLOExport(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SLOMetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics/slo", nil))
+	assert.Equal(t, runtime.OpenMetricsContentType, rec.Header().Get("Content-Type"))
+	body, err := ioutil.ReadAll(rec.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body),
+		`slo_availability_objective{operation="createOrder",method="POST",path="/orders"} 0.999`)
+	assert.Contains(t, string(body),
+		`slo_latency_threshold_seconds{operation="getOrder",method="GET",path="/orders/{orderId}"} 0.05`)
+
+	rules := SLORecordingRules("orders-slo", runtime.SLOMetricNames{
+		Requests: "http_requests_total",
+		Duration: "http_request_duration_seconds",
+	})
+	assert.True(t, strings.HasPrefix(rules, "groups:\n- name: \"orders-slo\"\n"))
+	assert.Contains(t, rules, "record: slo:availability_burn_rate:rate1h")
+	assert.Contains(t, rules, `http_request_duration_seconds_bucket{operation=\"getOrder\",le=\"0.05\"}[5m]`)
+}
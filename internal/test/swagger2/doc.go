@@ -0,0 +1,3 @@
+package swagger2
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=swagger2 --generate=types --swagger2-extensions -o swagger2.gen.go swagger2.yaml
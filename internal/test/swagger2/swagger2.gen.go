@@ -0,0 +1,107 @@
+// Package swagger2 provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+package swagger2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// Account defines model for Account.
+type Account struct {
+	Balance  *int     `json:"balance"`
+	Email    string   `json:"email,omitempty"`
+	Id       string   `json:"id"`
+	Nickname *string  `json:"nickname"`
+	Note     *string  `json:"note"`
+	Phone    string   `json:"phone,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Settings defines model for Settings.
+type Settings struct {
+	Locale               string            `json:"locale,omitempty"`
+	Theme                *string           `json:"theme"`
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// Getter for additional properties for Settings. Returns the specified
+// element and whether it was found
+func (a Settings) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Settings
+func (a *Settings) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Settings to handle AdditionalProperties
+func (a *Settings) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["locale"]; found {
+		err = json.Unmarshal(raw, &a.Locale)
+		if err != nil {
+			return errors.Wrap(err, "error reading 'locale'")
+		}
+		delete(object, "locale")
+	}
+
+	if raw, found := object["theme"]; found {
+		err = json.Unmarshal(raw, &a.Theme)
+		if err != nil {
+			return errors.Wrap(err, "error reading 'theme'")
+		}
+		delete(object, "theme")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Settings to handle AdditionalProperties
+func (a Settings) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	object["locale"], err = json.Marshal(a.Locale)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error marshaling 'locale'"))
+	}
+
+	object["theme"], err = json.Marshal(a.Theme)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error marshaling 'theme'"))
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
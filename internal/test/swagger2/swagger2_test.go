@@ -0,0 +1,41 @@
+package swagger2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger2Optionality(t *testing.T) {
+	// Only the fields which are required, and not made nullable, are always
+	// present; email and phone are optional, but not pointers.
+	buf, err := json.Marshal(Account{Id: "a1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "a1", "nickname": null, "balance": null, "note": null}`, string(buf))
+
+	nickname := "al"
+	buf, err = json.Marshal(Account{Id: "a1", Nickname: &nickname, Email: "al@example.com", Tags: []string{"vip"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "a1",
+		"nickname": "al",
+		"email": "al@example.com",
+		"balance": null,
+		"tags": ["vip"],
+		"note": null
+	}`, string(buf))
+
+	var account Account
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "a1", "nickname": null, "balance": 5}`), &account))
+	assert.Nil(t, account.Nickname)
+	require.NotNil(t, account.Balance)
+	assert.Equal(t, 5, *account.Balance)
+}
+
+func TestSwagger2OptionalityWithAdditionalProperties(t *testing.T) {
+	buf, err := json.Marshal(Settings{AdditionalProperties: map[string]string{"x": "y"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"theme": null, "locale": "", "x": "y"}`, string(buf))
+}
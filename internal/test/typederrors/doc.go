@@ -0,0 +1,3 @@
+package typederrors
+
+//go:generate go run github.com/shawnhankim/oapi-codegen/cmd/oapi-codegen --package=typederrors --generate=types,client --typed-errors -o typederrors.gen.go typederrors.yaml
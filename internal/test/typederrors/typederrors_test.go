@@ -0,0 +1,80 @@
+package typederrors
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// respond returns a client receiving a JSON response with the given status
+// and body.
+func respond(t *testing.T, status int, body string) *ClientWithResponses {
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	client, err := NewClientWithResponses("http://example.com", WithHTTPClient(doer))
+	require.NoError(t, err)
+	return client
+}
+
+func TestTypedErrors(t *testing.T) {
+	ctx := context.Background()
+
+	rsp, err := respond(t, http.StatusOK, `{"name":"Rex"}`).GetPetWithResponse(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Rex", rsp.JSON200.Name)
+
+	rsp, err = respond(t, http.StatusNotFound, `{"code":12,"message":"no pet 1"}`).GetPetWithResponse(ctx, 1)
+	require.Error(t, err)
+	assert.NotNil(t, rsp.JSON404)
+	assert.Equal(t, "404 Not Found: no pet 1", err.Error())
+	var notFound NotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, http.StatusNotFound, notFound.StatusCode)
+	assert.Equal(t, 12, notFound.Body.Code)
+	assert.True(t, errors.Is(err, NotFoundError{}))
+	assert.True(t, errors.Is(err, NotFoundError{Body: Error{Code: 12}}))
+	assert.False(t, errors.Is(err, NotFoundError{Body: Error{Code: 13}}))
+	assert.False(t, errors.Is(err, DefaultError{}))
+
+	// The default response is only an error for 4xx and 5xx statuses.
+	_, err = respond(t, http.StatusTeapot, `{"code":1,"message":"teapot"}`).GetPetWithResponse(ctx, 1)
+	var unexpected DefaultError
+	require.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, http.StatusTeapot, unexpected.StatusCode)
+	rsp, err = respond(t, http.StatusAccepted, `{"code":1,"message":"later"}`).GetPetWithResponse(ctx, 1)
+	require.NoError(t, err)
+	assert.NotNil(t, rsp.JSONDefault)
+
+	_, err = respond(t, http.StatusBadRequest, `{"code":"name","message":"missing name"}`).AddPetWithResponse(ctx, AddPetJSONRequestBody{})
+	assert.True(t, errors.Is(err, BadRequestError{}))
+	code := "name"
+	assert.True(t, errors.Is(err, BadRequestError{Body: Problem{Code: &code}}))
+	assert.Equal(t, "400 Bad Request: missing name", err.Error())
+
+	// Both operations return the same type for 404 Error responses.
+	_, err = respond(t, http.StatusNotFound, `{"code":3,"message":"no owner"}`).AddPetWithResponse(ctx, AddPetJSONRequestBody{})
+	assert.True(t, errors.Is(err, NotFoundError{Body: Error{Code: 3}}))
+
+	_, err = respond(t, http.StatusBadGateway, `{"detail":"down"}`).AddPetWithResponse(ctx, AddPetJSONRequestBody{})
+	var serverError ServerError
+	require.True(t, errors.As(err, &serverError))
+	assert.Equal(t, "down", *serverError.Body.Detail)
+	assert.Equal(t, "502 Bad Gateway", err.Error())
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ServiceMethod is a method of an existing business service, which an
+// operation is delegated to, per x-go-impl.
+type ServiceMethod struct {
+	Service       string                 // The Go interface of the service, eg, OrderService
+	Method        string                 // The method of the service, eg, Create
+	Body          *RequestBodyDefinition // The JSON body passed to the method, if any
+	ResultType    string                 // The Go type returned by the method, for JSON success responses
+	Status        int                    // The status of the success response
+	ErrorStatuses []int                  // The error statuses declared by the operation
+	HasDefault    bool                   // Whether the operation declares a default response
+}
+
+// ErrorStatusesLiteral returns ErrorStatuses as a Go []int literal.
+func (m ServiceMethod) ErrorStatusesLiteral() string {
+	if len(m.ErrorStatuses) == 0 {
+		return "nil"
+	}
+	statuses := make([]string, len(m.ErrorStatuses))
+	for i, status := range m.ErrorStatuses {
+		statuses[i] = strconv.Itoa(status)
+	}
+	return "[]int{" + strings.Join(statuses, ", ") + "}"
+}
+
+// ServiceDefinition is a business service, with the operations delegated to
+// its methods.
+type ServiceDefinition struct {
+	Name       string
+	Operations []OperationDefinition
+}
+
+// describeServiceMethod reads the x-go-impl extension of an operation, which
+// names the method of a service, eg, OrderService.Create, and describes the
+// method from the parameters, body and responses of the operation. It returns
+// nil when the operation doesn't have the extension.
+func describeServiceMethod(op *OperationDefinition) (*ServiceMethod, error) {
+	impl, found, err := extString(op.Spec.Extensions, extOpGoImpl)
+	if err != nil || !found {
+		return nil, err
+	}
+	parts := strings.Split(impl, ".")
+	if len(parts) != 2 || !goIdentifierRe.MatchString(parts[0]) || !goIdentifierRe.MatchString(parts[1]) {
+		return nil, fmt.Errorf("%s must be of the form Service.Method, got %q", extOpGoImpl, impl)
+	}
+	if op.IsProxy {
+		return nil, fmt.Errorf("%s can't be combined with %s", extOpGoImpl, extOpProxy)
+	}
+	method := &ServiceMethod{
+		Service: UppercaseFirstCharacter(parts[0]),
+		Method:  UppercaseFirstCharacter(parts[1]),
+	}
+
+	for i, body := range op.Bodies {
+		if body.NameTag == "JSON" {
+			method.Body = &op.Bodies[i]
+		}
+	}
+	if len(op.Bodies) != 0 && method.Body == nil {
+		return nil, fmt.Errorf("%s requires a JSON request body", extOpGoImpl)
+	}
+
+	success := ""
+	for _, name := range SortedResponsesKeys(op.Spec.Responses) {
+		switch {
+		case name == "default":
+			method.HasDefault = true
+		case strings.HasPrefix(name, "2"):
+			if success == "" {
+				success = name
+			}
+		default:
+			if status, err := strconv.Atoi(name); err == nil && status >= 400 {
+				method.ErrorStatuses = append(method.ErrorStatuses, status)
+			}
+		}
+	}
+	if success == "" {
+		return nil, fmt.Errorf("%s requires a success response", extOpGoImpl)
+	}
+	// Ranges, such as 2XX, are answered with 200.
+	method.Status = 200
+	if status, err := strconv.Atoi(success); err == nil {
+		method.Status = status
+	}
+	responseTypes, err := op.GetResponseTypeDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	for _, td := range responseTypes {
+		if td.ResponseName == success && strings.HasPrefix(td.TypeName, "JSON") {
+			method.ResultType = td.Schema.TypeDecl()
+			break
+		}
+	}
+	return method, nil
+}
+
+// serviceAdapterMembers are the names which ServiceAdapter declares besides
+// the fields of the services, and its operation methods.
+var serviceAdapterMembers = []string{"ServiceAdapter", "ServerInterface", "ErrorHandler"}
+
+// ServiceDefinitions groups the operations with x-go-impl by service, in
+// the order of their names. A service can't be named like an operation, or
+// another member of ServiceAdapter, as it's the name of its field.
+func ServiceDefinitions(ops []OperationDefinition) ([]ServiceDefinition, error) {
+	services := make(map[string]*ServiceDefinition)
+	methods := make(map[string]string)
+	for _, op := range ops {
+		if op.Impl == nil {
+			continue
+		}
+		name := op.Impl.Service + "." + op.Impl.Method
+		if other, found := methods[name]; found {
+			return nil, fmt.Errorf("operations %s and %s are both implemented by %s", other, op.OperationId, name)
+		}
+		methods[name] = op.OperationId
+		service, found := services[op.Impl.Service]
+		if !found {
+			service = &ServiceDefinition{Name: op.Impl.Service}
+			services[op.Impl.Service] = service
+		}
+		service.Operations = append(service.Operations, op)
+	}
+	result := make([]ServiceDefinition, 0, len(services))
+	for _, service := range services {
+		result = append(result, *service)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	for _, service := range result {
+		if StringInArray(service.Name, serviceAdapterMembers) {
+			return nil, fmt.Errorf("service %s has the name of a member of ServiceAdapter", service.Name)
+		}
+		for _, op := range ops {
+			if op.OperationId == service.Name {
+				return nil, fmt.Errorf("service %s has the name of the method of operation %s", service.Name, op.OperationId)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GenerateServiceAdapters generates the interfaces of the services which
+// operations are delegated to with x-go-impl, and ServiceAdapter, which
+// implements the server interface on top of them. Nothing is generated when
+// no operation has the extension.
+func GenerateServiceAdapters(t *template.Template, ops []OperationDefinition) (string, error) {
+	services, err := ServiceDefinitions(ops)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = t.ExecuteTemplate(w, "service-adapter.tmpl", services)
+	if err != nil {
+		return "", fmt.Errorf("error generating service adapters: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for service adapters: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// AsyncJobDefinition describes the status operation of an asynchronous job,
+// per x-async-job, for which a watcher polling it is generated.
+type AsyncJobDefinition struct {
+	Name            string    // The prefix of the generated types, eg, Job for JobWatcher
+	StatusType      string    // The Go type of the statuses
+	DefineStatus    bool      // Whether StatusType is generated, rather than a component schema
+	DeclareStatuses bool      // Whether the constants and methods of StatusType are generated with this job
+	Statuses        []string  // All the statuses, from the enum of the status property
+	Succeeded       []string  // The terminal statuses of jobs which succeeded
+	Failed          []string  // The terminal statuses of jobs which failed
+	BodyType        string    // The Go type of the job, returned by the operation
+	DefineBody      bool      // Whether Name is declared as an alias of BodyType, for inline schemas
+	FieldName       string    // The field of the response type holding the job, eg, JSON200
+	Status          Property  // The property of the job holding its status
+	Result          *Property // The property of the job holding its result, if any
+}
+
+// JobType returns the Go type of the job used by the watcher.
+func (j AsyncJobDefinition) JobType() string {
+	if j.DefineBody {
+		return j.Name
+	}
+	return j.BodyType
+}
+
+// StatusConstant returns the name of the constant of a status.
+func (j AsyncJobDefinition) StatusConstant(status string) string {
+	return j.StatusType + ToCamelCase(status)
+}
+
+// describeAsyncJob reads the x-async-job extension of an operation, which is
+// either true or an object with the optional name, status, result, succeeded
+// and failed fields, and describes the job returned by the operation. It
+// returns nil when the operation doesn't have the extension.
+func describeAsyncJob(op *OperationDefinition) (*AsyncJobDefinition, error) {
+	raw, found := op.Spec.Extensions[extOpAsyncJob]
+	if !found {
+		return nil, nil
+	}
+	rawJSON, ok := raw.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object, got %T", extOpAsyncJob, raw)
+	}
+	var ext struct {
+		Name      string   `json:"name"`
+		Status    string   `json:"status"`
+		Result    string   `json:"result"`
+		Succeeded []string `json:"succeeded"`
+		Failed    []string `json:"failed"`
+	}
+	var enabled bool
+	if err := json.Unmarshal(rawJSON, &enabled); err == nil {
+		if !enabled {
+			return nil, nil
+		}
+	} else if err := json.Unmarshal(rawJSON, &ext); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", extOpAsyncJob, err)
+	}
+	if ext.Status == "" {
+		ext.Status = "status"
+	}
+	if len(ext.Succeeded) == 0 {
+		ext.Succeeded = []string{"succeeded"}
+	}
+	if len(ext.Failed) == 0 {
+		ext.Failed = []string{"failed"}
+	}
+	if op.IsProxy || op.HasBody() {
+		return nil, fmt.Errorf("%s can't be used on operations with a request body, or with %s", extOpAsyncJob, extOpProxy)
+	}
+
+	td, err := op.SuccessJSONResponse()
+	if err != nil {
+		return nil, err
+	}
+	if td == nil {
+		return nil, fmt.Errorf("%s requires a 2xx JSON response holding the job", extOpAsyncJob)
+	}
+	job := &AsyncJobDefinition{
+		Name:      ext.Name,
+		BodyType:  td.Schema.TypeDecl(),
+		FieldName: td.TypeName,
+		Succeeded: ext.Succeeded,
+		Failed:    ext.Failed,
+	}
+	if job.Name == "" {
+		job.Name = op.OperationId
+		if goIdentifierRe.MatchString(job.BodyType) {
+			job.Name = job.BodyType
+		}
+	}
+	job.DefineBody = !goIdentifierRe.MatchString(job.BodyType)
+
+	schema, err := responseGoSchema(op, td.ResponseName)
+	if err != nil {
+		return nil, err
+	}
+	var statusFound bool
+	for i, p := range schema.Properties {
+		switch p.JsonFieldName {
+		case ext.Status:
+			job.Status = p
+			statusFound = true
+		case ext.Result:
+			job.Result = &schema.Properties[i]
+		}
+	}
+	if !statusFound {
+		return nil, fmt.Errorf("the job has no %s property holding its status", ext.Status)
+	}
+	if ext.Result != "" && job.Result == nil {
+		return nil, fmt.Errorf("the job has no %s property holding its result", ext.Result)
+	}
+
+	schemaRef := jsonResponseSchema(op.Spec.Responses[td.ResponseName].Value)
+	statusSchema := findPropertySchema(schemaRef.Value, ext.Status)
+	if statusSchema == nil || statusSchema.Value == nil || statusSchema.Value.Type != "string" {
+		return nil, fmt.Errorf("the %s property of the job must be a string", ext.Status)
+	}
+	if statusSchema.Ref != "" {
+		job.StatusType = job.Status.Schema.TypeDecl()
+	} else {
+		job.StatusType = job.Name + "Status"
+		job.DefineStatus = true
+	}
+	for _, value := range statusSchema.Value.Enum {
+		if s, ok := value.(string); ok {
+			job.Statuses = append(job.Statuses, s)
+		}
+	}
+	for _, terminal := range append(append([]string{}, ext.Succeeded...), ext.Failed...) {
+		if len(statusSchema.Value.Enum) != 0 && !StringInArray(terminal, job.Statuses) {
+			return nil, fmt.Errorf("the terminal status %s isn't one of the statuses of the job: %s",
+				terminal, strings.Join(job.Statuses, ", "))
+		}
+		if len(statusSchema.Value.Enum) == 0 {
+			job.Statuses = append(job.Statuses, terminal)
+		}
+	}
+	return job, nil
+}
+
+// findPropertySchema returns the schema of a property of an object, which may
+// be declared in one of the schemas it's composed of with allOf.
+func findPropertySchema(schema *openapi3.Schema, name string) *openapi3.SchemaRef {
+	if schema == nil {
+		return nil
+	}
+	if property, found := schema.Properties[name]; found {
+		return property
+	}
+	for _, ref := range schema.AllOf {
+		if property := findPropertySchema(ref.Value, name); property != nil {
+			return property
+		}
+	}
+	return nil
+}
+
+// checkAsyncJobs checks that the types generated for the jobs of the
+// operations are unique, and don't clash with those of the components. Jobs
+// sharing a status type from the components must declare the same terminal
+// statuses, which are declared with the first of them.
+func checkAsyncJobs(ops []OperationDefinition, reserved map[string]bool) error {
+	names := make(map[string]string)
+	statusTypes := make(map[string]*AsyncJobDefinition)
+	for _, op := range ops {
+		job := op.AsyncJob
+		if job == nil {
+			continue
+		}
+		generated := []string{job.Name + "Watcher"}
+		if job.DefineBody {
+			generated = append(generated, job.Name)
+		}
+		if job.DefineStatus {
+			generated = append(generated, job.StatusType)
+		}
+		for _, name := range generated {
+			if other, found := names[name]; found || reserved[name] {
+				if !found {
+					other = "the components"
+				}
+				return fmt.Errorf("%s of %s clashes with a type of %s, name the job with %s",
+					name, op.OperationId, other, extOpAsyncJob)
+			}
+			names[name] = op.OperationId
+		}
+		first, found := statusTypes[job.StatusType]
+		if !found {
+			job.DeclareStatuses = true
+			statusTypes[job.StatusType] = job
+			continue
+		}
+		if strings.Join(first.Succeeded, ",") != strings.Join(job.Succeeded, ",") ||
+			strings.Join(first.Failed, ",") != strings.Join(job.Failed, ",") {
+			return fmt.Errorf("the jobs of %s declare different terminal statuses of %s", op.OperationId, job.StatusType)
+		}
+	}
+	return nil
+}
+
+// GenerateAsyncJobWatchers generates the status types and the watchers of the
+// jobs described with x-async-job.
+func GenerateAsyncJobWatchers(t *template.Template, ops []OperationDefinition) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "async-job.tmpl", ops)
+	if err != nil {
+		return "", fmt.Errorf("error generating job watchers: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for job watchers: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// AuditDefinition describes an operation for audit logging. It's generated
+// into a runtime.AuditDescriptor.
+type AuditDefinition struct {
+	OperationId string // The operation ID as written in the spec
+	Method      string
+	Path        string
+	Resource    string
+	Action      string
+	Sensitivity string
+	Sensitive   []string // The names of the sensitive parameters
+}
+
+// auditActions are the default actions of operations without an x-audit
+// action, by method.
+var auditActions = map[string]string{
+	"GET":    "read",
+	"HEAD":   "read",
+	"POST":   "create",
+	"PUT":    "update",
+	"PATCH":  "update",
+	"DELETE": "delete",
+}
+
+// DescribeAudit describes an operation for audit logging. The resource, action
+// and sensitivity are read from the x-audit extension of the operation. When
+// they aren't there, the resource is the first tag of the operation, or the
+// first segment of its path, and the action follows from its method.
+func DescribeAudit(op OperationDefinition) (AuditDefinition, error) {
+	audit := AuditDefinition{
+		OperationId: op.SpecOperationId,
+		Method:      op.Method,
+		Path:        op.Path,
+	}
+	if raw, found := op.Spec.Extensions[extOpAudit]; found {
+		rawJSON, ok := raw.(json.RawMessage)
+		if !ok {
+			return audit, fmt.Errorf("%s must be an object, got %T", extOpAudit, raw)
+		}
+		var ext struct {
+			Resource    string `json:"resource"`
+			Action      string `json:"action"`
+			Sensitivity string `json:"sensitivity"`
+		}
+		if err := json.Unmarshal(rawJSON, &ext); err != nil {
+			return audit, fmt.Errorf("failed to parse %s: %s", extOpAudit, err)
+		}
+		audit.Resource = ext.Resource
+		audit.Action = ext.Action
+		audit.Sensitivity = ext.Sensitivity
+	}
+	if audit.Resource == "" {
+		if len(op.Spec.Tags) != 0 {
+			audit.Resource = op.Spec.Tags[0]
+		} else {
+			audit.Resource = strings.SplitN(strings.TrimPrefix(op.Path, "/"), "/", 2)[0]
+		}
+	}
+	if audit.Action == "" {
+		audit.Action = auditActions[op.Method]
+		if audit.Action == "" {
+			audit.Action = strings.ToLower(op.Method)
+		}
+	}
+
+	for _, param := range op.AllParams() {
+		sensitive, _, err := extBool(param.Spec.Extensions, extParamSensitive)
+		if err != nil {
+			return audit, fmt.Errorf("parameter %s: %s", param.ParamName, err)
+		}
+		if !sensitive && param.Spec.Schema != nil && param.Spec.Schema.Value != nil {
+			sensitive, _, err = extBool(param.Spec.Schema.Value.Extensions, extParamSensitive)
+			if err != nil {
+				return audit, fmt.Errorf("parameter %s: %s", param.ParamName, err)
+			}
+		}
+		if sensitive {
+			audit.Sensitive = append(audit.Sensitive, param.ParamName)
+		}
+	}
+	return audit, nil
+}
+
+// GenerateAudit generates AuditDescriptors, which describe the operations for
+// audit logging, and the middleware of the generated servers which emits
+// audit events.
+func GenerateAudit(t *template.Template, ops []OperationDefinition) (string, error) {
+	context := struct {
+		Operations []AuditDefinition
+		Echo       bool
+		Chi        bool
+	}{
+		Echo: globalState.options.GenerateEchoServer,
+		Chi:  globalState.options.GenerateChiServer,
+	}
+	for _, op := range ops {
+		audit, err := DescribeAudit(op)
+		if err != nil {
+			return "", fmt.Errorf("error describing the audit of %s: %s", op.OperationId, err)
+		}
+		context.Operations = append(context.Operations, audit)
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "audit.tmpl", context)
+	if err != nil {
+		return "", fmt.Errorf("error generating audit descriptors: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for audit descriptors: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -19,8 +19,11 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"path"
+	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -35,11 +38,124 @@ type Options struct {
 	GenerateChiServer  bool     // GenerateChiServer specifies whether to generate chi server boilerplate
 	GenerateEchoServer bool     // GenerateEchoServer specifies whether to generate echo server boilerplate
 	GenerateClient     bool     // GenerateClient specifies whether to generate client boilerplate
+	GenerateFakeClient bool     // GenerateFakeClient specifies whether to generate a fake ClientInterface for tests
+	GenerateTagClients bool     // GenerateTagClients specifies whether to generate a sub-client per tag
+	GenerateInMemory   bool     // GenerateInMemory specifies whether to generate a client which calls the server handlers directly
+	GenerateExamples   bool     // GenerateExamples specifies whether to generate tests which check the spec examples against the types
+	GenerateFuzzTests  bool     // GenerateFuzzTests specifies whether to generate fuzz targets sending mutated requests to the echo server
 	GenerateTypes      bool     // GenerateTypes specifies whether to generate type definitions
 	EmbedSpec          bool     // Whether to embed the swagger spec in the generated code
+	OmitSpec           bool     // Whether to leave the embedded spec out, even with EmbedSpec, such as when it comes from the default targets
+	GenerateProvenance bool     // GenerateProvenance specifies whether to generate constants recording the generator and spec versions
+	GenerateManifest   bool     // GenerateManifest specifies whether to generate a manifest of the operations and a handler serving it
+	GenerateGateway    bool     // GenerateGateway specifies whether to generate the route configuration of a gateway, instead of Go code
+	GenerateSchemaInfo bool     // GenerateSchemaInfo specifies whether to generate a walkable description of the component schemas
+	GenerateAudit      bool     // GenerateAudit specifies whether to generate audit descriptors, and make the servers record audit events
+	GenerateSLO        bool     // GenerateSLO specifies whether to generate the SLOs of the operations, and make the servers observe them
+	ShardSpecByTag     bool     // Whether to split the embedded spec in shards per tag, which are decompressed on demand
 	SkipFmt            bool     // Whether to skip go fmt on the generated code
 	IncludeTags        []string // Only include operations that have one of these tags. Ignored when empty.
 	ExcludeTags        []string // Exclude operations that have one of these tags. Ignored when empty.
+
+	// GenerateDeprecation specifies whether to generate the operations marked
+	// deprecated in the spec, and make the servers track the requests to them.
+	GenerateDeprecation bool
+
+	// ResponseContentTypeMatching specifies how the Parse functions of the
+	// client match the Content-Type of responses against the spec. One of
+	// ContentTypeMatchingLenient (the default when empty),
+	// ContentTypeMatchingStrict or ContentTypeMatchingCustom.
+	ResponseContentTypeMatching string
+
+	// UnexpectedContentTypeErrors makes the Parse functions of the client
+	// return a *runtime.UnexpectedContentTypeError for responses whose
+	// Content-Type doesn't match anything declared in the spec.
+	UnexpectedContentTypeErrors bool
+
+	// TypedErrors generates an error type for each JSON error response, and
+	// makes the Parse functions of the client return it along with the
+	// response, for 4xx and 5xx statuses.
+	TypedErrors bool
+
+	// DateTimeUTC makes date-time values be converted to UTC when they are
+	// marshaled or parsed.
+	DateTimeUTC bool
+
+	// DateTimeLayout is the Go time layout, or one of "RFC3339" and
+	// "RFC3339Nano", used for date-time values. When either this or
+	// DateTimeUTC is set, date-time schemas use a generated DateTime type
+	// instead of time.Time. Schemas with a x-go-time-format extension always
+	// get their own type.
+	DateTimeLayout string
+
+	// GatewayFormat is the format of the gateway configuration, one of
+	// GatewayFormatKong (the default when empty) or GatewayFormatNginx.
+	GatewayFormat string
+
+	// GatewayUpstream is the URL of the service which the gateway routes
+	// requests to. It defaults to the first server of the spec.
+	GatewayUpstream string
+
+	// Swagger2Extensions interprets the x-nullable, x-isnullable and
+	// x-omitempty extensions of properties, which specs converted from
+	// Swagger 2 often carry, as Swagger 2 toolchains did. x-nullable makes a
+	// field a pointer, or not, whether it's required or not, and x-omitempty
+	// sets whether its JSON tag has omitempty. nullable counts as
+	// x-nullable: true.
+	Swagger2Extensions bool
+
+	// OutputSuffix is the suffix of the files of GenerateFiles, after the
+	// name of their target. It defaults to DefaultOutputSuffix.
+	OutputSuffix string
+
+	// OutputFiles names the files of GenerateFiles, by target, such as
+	// "client" or "types", overriding their default names.
+	OutputFiles map[string]string
+
+	// MaxTypesPerFile shards the types of GenerateFiles, so that editors
+	// aren't slowed down by a single huge file: the component types are
+	// sorted by name, and written in files of at most this many types, named
+	// after the types file with a number, eg, "types_1.gen.go". The types
+	// file keeps the types of the operations. Zero keeps all the types in one
+	// file.
+	MaxTypesPerFile int
+
+	// FieldPresence makes the types of the component schemas record which of
+	// their properties were present in the JSON they were unmarshaled from,
+	// which they report with an IsSet method.
+	FieldPresence bool
+
+	// RuntimePackage is the import path of pkg/runtime in the generated code,
+	// eg, that of an internal mirror or of a vendored copy, for builds which
+	// can't fetch it from github.com. It defaults to DefaultRuntimePackage.
+	// The other packages the generated code imports, pkg/types,
+	// pkg/middleware and pkg/schemainfo, are imported from its directory.
+	RuntimePackage string
+
+	// CommandLine holds the arguments oapi-codegen was run with. With
+	// GenerateProvenance, they're recorded in the header of the generated
+	// code.
+	CommandLine []string
+}
+
+// These are the valid values of Options.ResponseContentTypeMatching.
+const (
+	// Unmarshal a response when its Content-Type contains "json", "yaml" or
+	// "xml", whatever the exact media type.
+	ContentTypeMatchingLenient = "lenient"
+	// Unmarshal a response only when its media type is one of those declared
+	// for it in the spec.
+	ContentTypeMatchingStrict = "strict"
+	// Generate a ResponseContentTypeMatcher variable, which users of the
+	// client can replace with their own function.
+	ContentTypeMatchingCustom = "custom"
+)
+
+// globalState stores all global state. Please don't put global state anywhere
+// else so that we can easily track it.
+var globalState struct {
+	options   Options
+	timeTypes map[string]TimeTypeDefinition
 }
 
 type goImport struct {
@@ -70,12 +186,20 @@ var (
 		{lookFor: "http\\.", packageName: "net/http"},
 		{lookFor: "io\\.", packageName: "io"},
 		{lookFor: "ioutil\\.", packageName: "io/ioutil"},
+		{lookFor: "httptest\\.", packageName: "net/http/httptest"},
 		{lookFor: "json\\.", packageName: "encoding/json"},
+		{lookFor: "middleware\\.OapiRequestValidator", packageName: "github.com/shawnhankim/oapi-codegen/pkg/middleware"},
 		{lookFor: "openapi3\\.", packageName: "github.com/getkin/kin-openapi/openapi3"},
 		{lookFor: "openapi_types\\.", alias: "openapi_types", packageName: "github.com/shawnhankim/oapi-codegen/pkg/types"},
 		{lookFor: "path\\.", packageName: "path"},
-		{lookFor: "runtime\\.", packageName: "github.com/shawnhankim/oapi-codegen/pkg/runtime"},
+		{lookFor: "reflect\\.", packageName: "reflect"},
+		{lookFor: "runtime\\.", packageName: DefaultRuntimePackage},
+		{lookFor: "schemainfo\\.", packageName: "github.com/shawnhankim/oapi-codegen/pkg/schemainfo"},
+		{lookFor: "strconv\\.", packageName: "strconv"},
 		{lookFor: "strings\\.", packageName: "strings"},
+		{lookFor: "sync\\.", packageName: "sync"},
+		{lookFor: "testing\\.", packageName: "testing"},
+		{lookFor: "time\\.Date", packageName: "time"},
 		{lookFor: "time\\.Duration", packageName: "time"},
 		{lookFor: "time\\.Time", packageName: "time"},
 		{lookFor: "url\\.", packageName: "net/url"},
@@ -88,6 +212,150 @@ var (
 // the descriptions we've built up above from the schema objects.
 // opts defines
 func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (string, error) {
+	t, parts, err := generateParts(swagger, packageName, opts)
+	if err != nil {
+		return "", err
+	}
+	// The gateway configuration is the only part, and isn't Go code.
+	if opts.GenerateGateway {
+		return parts[0].code, nil
+	}
+	return assembleCode(t, packageName, opts, parts)
+}
+
+// GenerateFiles generates the same code as Generate, but split in one file
+// per target, keyed by file name. Files are named after their target, eg,
+// "client" for the client and the clients with responses, followed by
+// Options.OutputSuffix, unless Options.OutputFiles names them. The example
+// tests get a "_test.go" suffix, as Go requires. Every file is complete on its
+// own, with its own imports, so that the files can be written side by side
+// into the package.
+func GenerateFiles(swagger *openapi3.Swagger, packageName string, opts Options) (map[string]string, error) {
+	if opts.GenerateGateway {
+		return nil, errors.New("the gateway config can't be split in files")
+	}
+	for target := range opts.OutputFiles {
+		if !outputTargets[target] {
+			return nil, fmt.Errorf("unknown target %s for output file %s", target, opts.OutputFiles[target])
+		}
+	}
+	t, parts, err := generateParts(swagger, packageName, opts)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string, len(parts))
+	for _, part := range parts {
+		name := OutputFileName(part.target, opts)
+		if part.shard != 0 {
+			name = shardFileName(name, part.shard)
+		}
+		if _, found := files[name]; found {
+			return nil, fmt.Errorf("targets can't share the output file %s", name)
+		}
+		code, err := assembleCode(t, packageName, opts, []generatedPart{part})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error generating %s", name)
+		}
+		files[name] = code
+	}
+	return files, nil
+}
+
+// DefaultRuntimePackage is the import path of pkg/runtime in the generated
+// code, unless Options.RuntimePackage is set.
+const DefaultRuntimePackage = "github.com/shawnhankim/oapi-codegen/pkg/runtime"
+
+// DefaultOutputSuffix is the suffix of the files of GenerateFiles, unless
+// Options.OutputSuffix is set.
+const DefaultOutputSuffix = ".gen.go"
+
+// OutputFileName returns the name of the file which GenerateFiles writes the
+// code of a target to.
+func OutputFileName(target string, opts Options) string {
+	if name, found := opts.OutputFiles[target]; found {
+		return name
+	}
+	suffix := opts.OutputSuffix
+	if suffix == "" {
+		suffix = DefaultOutputSuffix
+	}
+	if target == "example-tests" {
+		return "examples" + strings.TrimSuffix(suffix, ".go") + "_test.go"
+	}
+	if target == "fuzz-tests" {
+		return "fuzz" + strings.TrimSuffix(suffix, ".go") + "_test.go"
+	}
+	return target + suffix
+}
+
+// shardFileName returns the name of a shard of the file with the given name,
+// numbered before its extensions, eg, "types_2.gen.go".
+func shardFileName(name string, shard int) string {
+	dir, base := filepath.Split(name)
+	stem, ext := base, ""
+	if i := strings.Index(base, "."); i > 0 {
+		stem, ext = base[:i], base[i:]
+	}
+	return dir + stem + "_" + strconv.Itoa(shard) + ext
+}
+
+// These are the targets of GenerateFiles, which get a file of their own.
+var outputTargets = map[string]bool{
+	"provenance":       true,
+	"types":            true,
+	"client":           true,
+	"tag-clients":      true,
+	"fake-client":      true,
+	"server":           true,
+	"chi-server":       true,
+	"in-memory-client": true,
+	"example-tests":    true,
+	"fuzz-tests":       true,
+	"manifest":         true,
+	"audit":            true,
+	"slo":              true,
+	"deprecation":      true,
+	"schema-export":    true,
+	"spec":             true,
+}
+
+// generatedPart is the code of a target, without imports.
+type generatedPart struct {
+	target string
+	code   string
+	shard  int // The number of the shard of the target, if it's sharded
+}
+
+// generateParts generates the code of every target of opts, in the order in
+// which Generate writes it.
+func generateParts(swagger *openapi3.Swagger, packageName string, opts Options) (*template.Template, []generatedPart, error) {
+	switch opts.ResponseContentTypeMatching {
+	case "":
+		opts.ResponseContentTypeMatching = ContentTypeMatchingLenient
+	case ContentTypeMatchingLenient, ContentTypeMatchingStrict, ContentTypeMatchingCustom:
+	default:
+		return nil, nil, fmt.Errorf("unknown response content type matching: %s", opts.ResponseContentTypeMatching)
+	}
+	if strings.ContainsAny(opts.RuntimePackage, " \t\n\"`\\") {
+		return nil, nil, fmt.Errorf("invalid runtime package path: %q", opts.RuntimePackage)
+	}
+	if opts.OmitSpec {
+		opts.EmbedSpec = false
+	}
+	globalState.options = opts
+	globalState.timeTypes = nil
+
+	// The spec is hashed before the operations are filtered, as it's the
+	// whole contract which the code is generated from.
+	var specHash string
+	if opts.GenerateProvenance || opts.GenerateManifest {
+		var err error
+		specHash, err = SpecHash(swagger)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error hashing spec")
+		}
+	}
+
 	filterOperationsByTag(swagger, opts)
 
 	// This creates the golang templates text package
@@ -96,19 +364,39 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	// above
 	t, err := templates.Parse(t)
 	if err != nil {
-		return "", errors.Wrap(err, "error parsing oapi-codegen templates")
+		return nil, nil, errors.Wrap(err, "error parsing oapi-codegen templates")
 	}
 
 	ops, err := OperationDefinitions(swagger)
 	if err != nil {
-		return "", errors.Wrap(err, "error creating operation definitions")
+		return nil, nil, errors.Wrap(err, "error creating operation definitions")
 	}
 
+	// The gateway configuration isn't Go code, so it can't be combined with
+	// anything else.
+	if opts.GenerateGateway {
+		if opts.GenerateTypes || opts.GenerateClient || opts.GenerateTagClients || opts.GenerateFakeClient ||
+			opts.GenerateInMemory || opts.GenerateExamples || opts.GenerateEchoServer || opts.GenerateChiServer ||
+			opts.EmbedSpec || opts.GenerateProvenance || opts.GenerateManifest || opts.GenerateSchemaInfo ||
+			opts.GenerateAudit || opts.GenerateSLO || opts.GenerateDeprecation || opts.GenerateFuzzTests {
+			return nil, nil, errors.New("the gateway config has to be generated on its own")
+		}
+		gatewayOut, err := GenerateGatewayConfig(t, swagger, ops, packageName)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating gateway config")
+		}
+		return t, []generatedPart{{target: "gateway-config", code: gatewayOut}}, nil
+	}
+
+	if opts.MaxTypesPerFile < 0 {
+		return nil, nil, fmt.Errorf("invalid maximum number of types per file: %d", opts.MaxTypesPerFile)
+	}
 	var typeDefinitions string
+	var typeShards []string
 	if opts.GenerateTypes {
-		typeDefinitions, err = GenerateTypeDefinitions(t, swagger, ops)
+		typeDefinitions, typeShards, err = GenerateTypeDefinitionShards(t, swagger, ops, opts.MaxTypesPerFile)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating type definitions")
+			return nil, nil, errors.Wrap(err, "error generating type definitions")
 		}
 	}
 
@@ -116,23 +404,38 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	if opts.GenerateEchoServer {
 		echoServerOut, err = GenerateEchoServer(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating Go handlers for Paths")
+			return nil, nil, errors.Wrap(err, "error generating Go handlers for Paths")
 		}
+		hostRegistration, err := GenerateHostRegistration(t, swagger)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating host registration")
+		}
+		echoServerOut += hostRegistration
+		adapters, err := GenerateServiceAdapters(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating service adapters")
+		}
+		echoServerOut += adapters
 	}
 
 	var chiServerOut string
 	if opts.GenerateChiServer {
 		chiServerOut, err = GenerateChiServer(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating Go handlers for Paths")
+			return nil, nil, errors.Wrap(err, "error generating Go handlers for Paths")
+		}
+		adapters, err := GenerateServiceAdapters(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating service adapters")
 		}
+		chiServerOut += adapters
 	}
 
 	var clientOut string
 	if opts.GenerateClient {
 		clientOut, err = GenerateClient(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating client")
+			return nil, nil, errors.Wrap(err, "error generating client")
 		}
 	}
 
@@ -140,7 +443,124 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	if opts.GenerateClient {
 		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating client with responses")
+			return nil, nil, errors.Wrap(err, "error generating client with responses")
+		}
+		if opts.TypedErrors {
+			errorTypes, err := GenerateErrorTypes(t, ops)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error generating error types")
+			}
+			clientWithResponsesOut += errorTypes
+		}
+		watchers, err := GenerateAsyncJobWatchers(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating job watchers")
+		}
+		clientWithResponsesOut += watchers
+		compositions, err := GenerateCompositions(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating compositions")
+		}
+		clientWithResponsesOut += compositions
+	}
+
+	var fakeClientOut string
+	if opts.GenerateFakeClient {
+		fakeClientOut, err = GenerateFakeClient(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating fake client")
+		}
+	}
+
+	var tagClientsOut string
+	if opts.GenerateTagClients {
+		tagClientsOut, err = GenerateTagClients(t, swagger, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating tag clients")
+		}
+	}
+
+	var inMemoryClientOut string
+	if opts.GenerateInMemory {
+		if !opts.GenerateEchoServer && !opts.GenerateChiServer {
+			return nil, nil, errors.New("the in-memory client requires a server to be generated with it")
+		}
+		inMemoryClientOut, err = GenerateInMemoryClient(t, opts)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating in-memory client")
+		}
+	}
+
+	var exampleTestsOut string
+	if opts.GenerateExamples {
+		exampleTestsOut, err = GenerateExampleTests(t, swagger, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating example tests")
+		}
+	}
+
+	var fuzzTestsOut string
+	if opts.GenerateFuzzTests {
+		fuzzTestsOut, err = GenerateFuzzTests(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating fuzz tests")
+		}
+	}
+
+	var provenanceOut string
+	if opts.GenerateProvenance {
+		provenanceOut, err = GenerateProvenance(t, swagger, specHash)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating provenance")
+		}
+	}
+
+	var manifestOut string
+	if opts.GenerateManifest {
+		manifestOut, err = GenerateManifest(t, swagger, ops, specHash)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating manifest")
+		}
+	}
+
+	var auditOut string
+	if opts.GenerateAudit {
+		if !opts.GenerateEchoServer && !opts.GenerateChiServer {
+			return nil, nil, errors.New("the audit descriptors require a server to be generated with them")
+		}
+		auditOut, err = GenerateAudit(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating audit descriptors")
+		}
+	}
+
+	var sloOut string
+	if opts.GenerateSLO {
+		if !opts.GenerateEchoServer && !opts.GenerateChiServer {
+			return nil, nil, errors.New("the SLOs require a server to be generated with them")
+		}
+		sloOut, err = GenerateSLO(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating SLOs")
+		}
+	}
+
+	var deprecationOut string
+	if opts.GenerateDeprecation {
+		if !opts.GenerateEchoServer && !opts.GenerateChiServer {
+			return nil, nil, errors.New("the deprecated operations require a server to be generated with them")
+		}
+		deprecationOut, err = GenerateDeprecation(t, ops)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating deprecated operations")
+		}
+	}
+
+	var schemaInfoOut string
+	if opts.GenerateSchemaInfo {
+		schemaInfoOut, err = GenerateSchemaInfo(t, swagger)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating schema info")
 		}
 	}
 
@@ -148,10 +568,62 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	if opts.EmbedSpec {
 		inlinedSpec, err = GenerateInlinedSpec(t, swagger)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating Go handlers for Paths")
+			return nil, nil, errors.Wrap(err, "error generating Go handlers for Paths")
 		}
 	}
 
+	var parts []generatedPart
+	add := func(enabled bool, target string, code ...string) {
+		if enabled {
+			parts = append(parts, generatedPart{target: target, code: strings.Join(code, "")})
+		}
+	}
+	add(opts.GenerateProvenance, "provenance", provenanceOut)
+	add(opts.GenerateTypes, "types", typeDefinitions)
+	for i, shard := range typeShards {
+		parts = append(parts, generatedPart{target: "types", code: shard, shard: i + 1})
+	}
+	add(opts.GenerateClient, "client", clientOut, clientWithResponsesOut)
+	add(opts.GenerateTagClients, "tag-clients", tagClientsOut)
+	add(opts.GenerateFakeClient, "fake-client", fakeClientOut)
+	add(opts.GenerateEchoServer, "server", echoServerOut)
+	add(opts.GenerateChiServer, "chi-server", chiServerOut)
+	add(opts.GenerateInMemory, "in-memory-client", inMemoryClientOut)
+	add(opts.GenerateExamples, "example-tests", exampleTestsOut)
+	add(opts.GenerateFuzzTests, "fuzz-tests", fuzzTestsOut)
+	add(opts.GenerateManifest, "manifest", manifestOut)
+	add(opts.GenerateAudit, "audit", auditOut)
+	add(opts.GenerateSLO, "slo", sloOut)
+	add(opts.GenerateDeprecation, "deprecation", deprecationOut)
+	add(opts.GenerateSchemaInfo, "schema-export", schemaInfoOut)
+	add(opts.EmbedSpec, "spec", inlinedSpec)
+	return t, parts, nil
+}
+
+// ownPackageImport returns the import of imp, a package of oapi-codegen such as
+// pkg/runtime or pkg/types, from Options.RuntimePackage when it's set. The
+// other packages are taken from the directory of the runtime package. The
+// generated code refers to the runtime package as runtime, so other paths get
+// that alias, unless they end with it.
+func ownPackageImport(imp goImport, opts Options) goImport {
+	if opts.RuntimePackage == "" || opts.RuntimePackage == DefaultRuntimePackage ||
+		path.Dir(imp.packageName) != path.Dir(DefaultRuntimePackage) {
+		return imp
+	}
+	if imp.packageName != DefaultRuntimePackage {
+		imp.packageName = path.Join(path.Dir(opts.RuntimePackage), path.Base(imp.packageName))
+		return imp
+	}
+	imp = goImport{packageName: opts.RuntimePackage}
+	if path.Base(opts.RuntimePackage) != "runtime" {
+		imp.alias = "runtime"
+	}
+	return imp
+}
+
+// assembleCode puts parts together in a Go file, with the imports they need,
+// and formats it.
+func assembleCode(t *template.Template, packageName string, opts Options, parts []generatedPart) (string, error) {
 	// Imports needed for the generated code to compile
 	var imports []string
 
@@ -159,18 +631,36 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	w := bufio.NewWriter(&buf)
 
 	// Based on module prefixes, figure out which optional imports are required.
-	for _, str := range []string{typeDefinitions, chiServerOut, echoServerOut, clientOut, clientWithResponsesOut, inlinedSpec} {
+	for _, part := range parts {
+		if part.target == "provenance" {
+			continue
+		}
 		for _, goImport := range allGoImports {
-			match, err := regexp.MatchString(fmt.Sprintf("[^a-zA-Z0-9_]%s", goImport.lookFor), str)
+			match, err := regexp.MatchString(fmt.Sprintf("[^a-zA-Z0-9_]%s", goImport.lookFor), part.code)
 			if err != nil {
 				return "", errors.Wrap(err, "error figuring out imports")
 			}
 			if match {
+				goImport = ownPackageImport(goImport, opts)
 				imports = append(imports, goImport.String())
 			}
 		}
 	}
 
+	// The fuzz tests need Go 1.18, which the rest of the generated code
+	// doesn't, so they get a file of their own, with a build constraint.
+	for _, part := range parts {
+		if part.target == "fuzz-tests" {
+			if len(parts) > 1 {
+				return "", errors.New("the fuzz tests have to be generated in a file of their own")
+			}
+			_, err := w.WriteString("//go:build go1.18\n// +build go1.18\n\n")
+			if err != nil {
+				return "", errors.Wrap(err, "error writing build constraint")
+			}
+		}
+	}
+
 	importsOut, err := GenerateImports(t, imports, packageName)
 	if err != nil {
 		return "", errors.Wrap(err, "error generating imports")
@@ -181,41 +671,10 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 		return "", errors.Wrap(err, "error writing imports")
 	}
 
-	_, err = w.WriteString(typeDefinitions)
-	if err != nil {
-		return "", errors.Wrap(err, "error writing type definitions")
-
-	}
-
-	if opts.GenerateClient {
-		_, err = w.WriteString(clientOut)
-		if err != nil {
-			return "", errors.Wrap(err, "error writing client")
-		}
-		_, err = w.WriteString(clientWithResponsesOut)
-		if err != nil {
-			return "", errors.Wrap(err, "error writing client")
-		}
-	}
-
-	if opts.GenerateEchoServer {
-		_, err = w.WriteString(echoServerOut)
-		if err != nil {
-			return "", errors.Wrap(err, "error writing server path handlers")
-		}
-	}
-
-	if opts.GenerateChiServer {
-		_, err = w.WriteString(chiServerOut)
-		if err != nil {
-			return "", errors.Wrap(err, "error writing server path handlers")
-		}
-	}
-
-	if opts.EmbedSpec {
-		_, err = w.WriteString(inlinedSpec)
+	for _, part := range parts {
+		_, err = w.WriteString(part.code)
 		if err != nil {
-			return "", errors.Wrap(err, "error writing inlined spec")
+			return "", errors.Wrapf(err, "error writing %s", part.target)
 		}
 	}
 
@@ -241,46 +700,109 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 }
 
 func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition) (string, error) {
+	typeDefinitions, _, err := GenerateTypeDefinitionShards(t, swagger, ops, 0)
+	return typeDefinitions, err
+}
+
+// GenerateTypeDefinitionShards generates the same code as
+// GenerateTypeDefinitions, but with the component types, and their
+// additional properties boilerplate, in shards of at most maxTypes types,
+// sorted by name. The rest is returned on its own: the types of the
+// operations, the date-time types and the methods of the types with
+// encrypted properties. With maxTypes 0, there are no shards.
+func GenerateTypeDefinitionShards(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition, maxTypes int) (string, []string, error) {
 	schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component schemas")
+		return "", nil, errors.Wrap(err, "error generating Go types for component schemas")
 	}
 
 	paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component parameters")
+		return "", nil, errors.Wrap(err, "error generating Go types for component parameters")
 	}
 	allTypes := append(schemaTypes, paramTypes...)
 
 	responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component responses")
+		return "", nil, errors.Wrap(err, "error generating Go types for component responses")
 	}
 	allTypes = append(allTypes, responseTypes...)
 
 	bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component request bodies")
+		return "", nil, errors.Wrap(err, "error generating Go types for component request bodies")
 	}
 	allTypes = append(allTypes, bodyTypes...)
 
 	paramTypesOut, err := GenerateTypesForOperations(t, ops)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for operation parameters")
+		return "", nil, errors.Wrap(err, "error generating Go types for operation parameters")
+	}
+
+	filtersOut, err := GenerateFilters(t, ops)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error generating filters")
 	}
 
-	typesOut, err := GenerateTypes(t, allTypes)
+	var typesOut, allOfBoilerplate string
+	var shards []string
+	if maxTypes == 0 {
+		typesOut, err = GenerateTypes(t, allTypes)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating code for type definitions")
+		}
+
+		allOfBoilerplate, err = GenerateAdditionalPropertyBoilerplate(t, allTypes)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating allOf boilerplate")
+		}
+	} else {
+		sortedTypes := append([]TypeDefinition(nil), allTypes...)
+		sort.SliceStable(sortedTypes, func(i, j int) bool {
+			return sortedTypes[i].TypeName < sortedTypes[j].TypeName
+		})
+		for start := 0; start < len(sortedTypes); start += maxTypes {
+			end := start + maxTypes
+			if end > len(sortedTypes) {
+				end = len(sortedTypes)
+			}
+			shardTypes := sortedTypes[start:end]
+			shardOut, err := GenerateTypes(t, shardTypes)
+			if err != nil {
+				return "", nil, errors.Wrap(err, "error generating code for type definitions")
+			}
+			shardBoilerplate, err := GenerateAdditionalPropertyBoilerplate(t, shardTypes)
+			if err != nil {
+				return "", nil, errors.Wrap(err, "error generating allOf boilerplate")
+			}
+			shards = append(shards, shardOut+shardBoilerplate)
+		}
+	}
+
+	encryptedTypes := allTypes
+	for _, op := range ops {
+		encryptedTypes = append(encryptedTypes, op.TypeDefinitions...)
+	}
+
+	if err := checkTimeTypeNames(encryptedTypes); err != nil {
+		return "", nil, err
+	}
+	timeTypesOut, err := GenerateTimeTypes(t)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating code for type definitions")
+		return "", nil, errors.Wrap(err, "error generating date-time types")
+	}
+	encryptedOut, err := GenerateEncryptedFields(t, encryptedTypes)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error generating encrypted fields")
 	}
 
-	allOfBoilerplate, err := GenerateAdditionalPropertyBoilerplate(t, allTypes)
+	presenceOut, err := GenerateFieldPresence(t, allTypes)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating allOf boilerplate")
+		return "", nil, errors.Wrap(err, "error generating field presence")
 	}
 
-	typeDefinitions := strings.Join([]string{typesOut, paramTypesOut, allOfBoilerplate}, "")
-	return typeDefinitions, nil
+	typeDefinitions := strings.Join([]string{typesOut, paramTypesOut, filtersOut, allOfBoilerplate, timeTypesOut, encryptedOut, presenceOut}, "")
+	return typeDefinitions, shards, nil
 }
 
 // Generates type definitions for any custom types defined in the
@@ -295,6 +817,11 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("error converting Schema %s to Go type", schemaName))
 		}
+		if globalState.options.FieldPresence {
+			if err := trackPresence(&goSchema, schemaName); err != nil {
+				return nil, err
+			}
+		}
 
 		types = append(types, TypeDefinition{
 			JsonName: schemaName,
@@ -448,10 +975,14 @@ func GenerateImports(t *template.Template, imports []string, packageName string)
 	context := struct {
 		Imports     []string
 		PackageName string
+		CommandLine string
 	}{
 		Imports:     imports,
 		PackageName: packageName,
 	}
+	if globalState.options.GenerateProvenance && len(globalState.options.CommandLine) != 0 {
+		context.CommandLine = commandLine(globalState.options.CommandLine)
+	}
 	err := t.ExecuteTemplate(w, "imports.tmpl", context)
 	if err != nil {
 		return "", errors.Wrap(err, "error generating imports")
@@ -529,7 +1060,7 @@ func includeOperationsWithTags(paths openapi3.Paths, tags []string, exclude bool
 	}
 }
 
-//operationHasTag returns true if the operation is tagged with any of tags
+// operationHasTag returns true if the operation is tagged with any of tags
 func operationHasTag(op *openapi3.Operation, tags []string) bool {
 	if op == nil {
 		return false
@@ -2,9 +2,16 @@ package codegen
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -42,7 +49,12 @@ func TestExamplePetStoreCodeGeneration(t *testing.T) {
 	assert.Contains(t, code, "package api")
 
 	// Check that the client method signatures return response structs:
-	assert.Contains(t, code, "func (c *Client) FindPetById(ctx context.Context, id int64) (*http.Response, error) {")
+	assert.Contains(t, code, "func (c *Client) FindPetById(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+
+	// Check that request editors get the context of the call
+	assert.Contains(t, code, "type RequestEditorFn func(ctx context.Context, req *http.Request) error")
+	assert.Contains(t, code, "return c.do(ctx, req, nil, reqEditors)")
+	assert.Contains(t, code, "if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {")
 
 	// Check that the property comments were generated
 	assert.Contains(t, code, "// Unique id of the pet")
@@ -52,6 +64,9 @@ func TestExamplePetStoreCodeGeneration(t *testing.T) {
 	// (DELETE /pets/{id})
 `)
 
+	// Check that simple scalar path parameters are converted without reflection
+	assert.Contains(t, code, `id, err = strconv.ParseInt(paramValue, 10, 64)`)
+
 	// Make sure the generated code is valid:
 	linter := new(lint.Linter)
 	problems, err := linter.Lint("test.gen.go", []byte(code))
@@ -59,6 +74,263 @@ func TestExamplePetStoreCodeGeneration(t *testing.T) {
 	assert.Len(t, problems, 0)
 }
 
+func TestEmbedSpecOmitted(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	opts := Options{
+		GenerateClient:     true,
+		GenerateEchoServer: true,
+		GenerateTypes:      true,
+	}
+	code, err := Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, code, "swaggerSpec")
+	assert.NotContains(t, code, "GetSwagger")
+	assert.NotContains(t, code, `"compress/gzip"`)
+
+	opts.EmbedSpec = true
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, "var swaggerSpec = []string{")
+	assert.Contains(t, code, "func GetSwagger() (*openapi3.Swagger, error) {")
+
+	// OmitSpec leaves it out even when the spec target is given.
+	opts.OmitSpec = true
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, code, "swaggerSpec")
+	assert.NotContains(t, code, "GetSwagger")
+}
+
+func TestProvenance(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+	specHash, err := SpecHash(swagger)
+	assert.NoError(t, err)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", specHash)
+
+	opts := Options{
+		GenerateTypes:      true,
+		GenerateProvenance: true,
+		IncludeTags:        []string{"pets"},
+		CommandLine:        []string{"-generate", "types,provenance", "-o", "my api.gen.go", "petstore.yaml"},
+	}
+	code, err := Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, "//\toapi-codegen -generate types,provenance -o \"my api.gen.go\" petstore.yaml\n")
+	assert.Contains(t, code, `GeneratorOptions = "-generate=types,provenance -include-tags=pets"`)
+	assert.Contains(t, code, `SpecVersion = "1.0.0"`)
+	assert.Contains(t, code, `SpecHash = "`+specHash+`"`)
+
+	opts.GenerateProvenance = false
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, code, "oapi-codegen -generate")
+	assert.NotContains(t, code, "SpecHash")
+}
+
+func TestManifest(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../examples/petstore-expanded/petstore-expanded.yaml")
+	assert.NoError(t, err)
+	specHash, err := SpecHash(swagger)
+	assert.NoError(t, err)
+
+	code, err := Generate(swagger, "api", Options{GenerateManifest: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `SpecHash:    "`+specHash+`",`)
+	assert.Contains(t, code, `{OperationID: "findPets", Method: "GET", Path: "/pets"},`)
+	assert.Contains(t, code, `{OperationID: "find pet by id", Method: "GET", Path: "/pets/{id}"},`)
+	assert.Contains(t, code, "func OperationsManifestHandler() http.Handler {")
+}
+
+func TestGenerateFiles(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../examples/petstore-expanded/petstore-expanded.yaml")
+	assert.NoError(t, err)
+
+	opts := Options{
+		GenerateTypes:      true,
+		GenerateClient:     true,
+		GenerateEchoServer: true,
+		GenerateExamples:   true,
+		OutputSuffix:       "_oapi.gen.go",
+		OutputFiles:        map[string]string{"client": "zz_generated_client.go"},
+	}
+	files, err := GenerateFiles(swagger, "api", opts)
+	assert.NoError(t, err)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"types_oapi.gen.go", "zz_generated_client.go", "server_oapi.gen.go", "examples_oapi.gen_test.go"}, names)
+
+	// Every file has its own imports
+	assert.Contains(t, files["types_oapi.gen.go"], "type NewPet struct {")
+	assert.NotContains(t, files["types_oapi.gen.go"], `"github.com/labstack/echo/v4"`)
+	assert.Contains(t, files["server_oapi.gen.go"], `"github.com/labstack/echo/v4"`)
+	assert.Contains(t, files["zz_generated_client.go"], "func NewClient(")
+	assert.NotContains(t, files["zz_generated_client.go"], "type NewPet struct {")
+	for name, code := range files {
+		assert.Contains(t, code, "\npackage api\n", name)
+	}
+
+	opts.OutputFiles = map[string]string{"clients": "clients.go"}
+	_, err = GenerateFiles(swagger, "api", opts)
+	assert.EqualError(t, err, "unknown target clients for output file clients.go")
+
+	opts.OutputFiles = map[string]string{"client": "api.go", "types": "api.go"}
+	_, err = GenerateFiles(swagger, "api", opts)
+	assert.EqualError(t, err, "targets can't share the output file api.go")
+}
+
+func TestGenerateFilesMaxTypesPerFile(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../examples/petstore-expanded/petstore-expanded.yaml")
+	assert.NoError(t, err)
+
+	opts := Options{
+		GenerateTypes:   true,
+		GenerateClient:  true,
+		MaxTypesPerFile: 2,
+	}
+	files, err := GenerateFiles(swagger, "api", opts)
+	assert.NoError(t, err)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"types.gen.go", "types_1.gen.go", "types_2.gen.go", "client.gen.go"}, names)
+
+	// The component types are sorted by name, while the types of the
+	// operations stay in the types file.
+	assert.Contains(t, files["types_1.gen.go"], "type Error struct {")
+	assert.Contains(t, files["types_1.gen.go"], "type NewPet struct {")
+	assert.Contains(t, files["types_2.gen.go"], "type Pet struct {")
+	assert.NotContains(t, files["types.gen.go"], "type Pet struct {")
+	assert.Contains(t, files["types.gen.go"], "type FindPetsParams struct {")
+	for name, code := range files {
+		assert.Contains(t, code, "\npackage api\n", name)
+	}
+
+	// Everything is still generated in one go without files.
+	code, err := Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, "type Pet struct {")
+	assert.Contains(t, code, "type FindPetsParams struct {")
+
+	opts.OutputFiles = map[string]string{"types": "models.go"}
+	files, err = GenerateFiles(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, files, "models_2.go")
+
+	opts.MaxTypesPerFile = -1
+	_, err = GenerateFiles(swagger, "api", opts)
+	assert.EqualError(t, err, "invalid maximum number of types per file: -1")
+}
+
+func TestServiceAdapterErrors(t *testing.T) {
+	spec := func(impl, extra string) string {
+		return `
+openapi: "3.0.1"
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      x-go-impl: ` + impl + `
+      responses:
+        '200':
+          description: The orders
+    post:
+      operationId: createOrder
+      x-go-impl: ` + extra + `
+      responses:
+        '201':
+          description: Created
+`
+	}
+	tests := []struct {
+		impl, extra string
+		err         string
+	}{
+		{"OrderService", "OrderService.Create", "x-go-impl must be of the form Service.Method"},
+		{"OrderService.List.All", "OrderService.Create", "x-go-impl must be of the form Service.Method"},
+		{"OrderService.Get", "OrderService.Get", "are both implemented by OrderService.Get"},
+		{"ListOrders.Get", "OrderService.Create", "service ListOrders has the name of the method of operation ListOrders"},
+		{"ServerInterface.List", "OrderService.Create", "service ServerInterface has the name of a member of ServiceAdapter"},
+		{"OrderService.List", "errorHandler.Create", "service ErrorHandler has the name of a member of ServiceAdapter"},
+	}
+	for _, test := range tests {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec(test.impl, test.extra)))
+		assert.NoError(t, err)
+		_, err = Generate(swagger, "api", Options{GenerateTypes: true, GenerateEchoServer: true})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+}
+
+func TestGatewayConfig(t *testing.T) {
+	loadSwagger := func() *openapi3.Swagger {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testGatewaySpec))
+		assert.NoError(t, err)
+		return swagger
+	}
+
+	code, err := Generate(loadSwagger(), "petstore", Options{GenerateGateway: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `url: "https://pets.example.com"`)
+	assert.Contains(t, code, `
+      - name: "petstore-GetPet"
+        methods: ["GET"]
+        paths: ["~/api/v1/pets/[^/]+$"]
+        regex_priority: 3
+        strip_path: false
+        plugins:
+          - name: key-auth
+            config:
+              key_names: ["X-API-Key"]
+`)
+	assert.Contains(t, code, `
+      - name: "petstore-ListPets"
+        methods: ["GET"]
+        paths: ["~/api/v1/pets$"]
+        regex_priority: 3
+        strip_path: false
+      - name:`)
+	assert.Contains(t, code, "# Not checked by the gateway: any of the security requirements of ApiKey, Bearer.")
+
+	code, err = Generate(loadSwagger(), "petstore", Options{
+		GenerateGateway: true,
+		GatewayFormat:   GatewayFormatNginx,
+		GatewayUpstream: "http://petstore.internal:8080/ignored",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `
+location ~ "^/api/v1/pets/[^/]+$" {
+    if ($request_method !~ "^(DELETE|GET)$") {
+        return 405;
+    }
+    # Not checked by the gateway: the security requirements of ApiKey, Bearer.
+    proxy_pass http://petstore.internal:8080;
+}
+`)
+	// The literal path goes before the one with a parameter.
+	assert.True(t, strings.Index(code, "/pets/mine$") < strings.Index(code, "/pets/[^/]+$"))
+
+	_, err = Generate(loadSwagger(), "petstore", Options{GenerateGateway: true, GenerateTypes: true})
+	assert.Error(t, err)
+	_, err = Generate(loadSwagger(), "petstore", Options{GenerateGateway: true, GatewayFormat: "haproxy"})
+	assert.Error(t, err)
+}
+
+func TestGatewayAuthNginxVariable(t *testing.T) {
+	assert.Equal(t, "http_x_api_key", GatewayAuth{In: "header", Name: "X-API-Key"}.NginxVariable())
+	assert.Equal(t, "arg_api_key", GatewayAuth{In: "query", Name: "api_key"}.NginxVariable())
+	assert.Equal(t, "cookie_session", GatewayAuth{In: "cookie", Name: "session"}.NginxVariable())
+}
+
 func TestExamplePetStoreParseFunction(t *testing.T) {
 
 	bodyBytes := []byte(`{"id": 5, "name": "testpet", "tag": "cat"}`)
@@ -79,6 +351,783 @@ func TestExamplePetStoreParseFunction(t *testing.T) {
 	assert.Equal(t, "cat", *findPetByIDResponse.JSON200.Tag)
 }
 
+func TestExamplePetStoreFakeClient(t *testing.T) {
+	name := "testpet"
+	fake := examplePetstoreClient.NewFakeClient().
+		FindPetByIdReturns(examplePetstoreClient.Pet{Id: 5, NewPet: examplePetstoreClient.NewPet{Name: name}}, nil)
+	client := &examplePetstoreClient.ClientWithResponses{ClientInterface: fake}
+
+	findPetByIDResponse, err := client.FindPetByIdWithResponse(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, findPetByIDResponse.StatusCode())
+	assert.NotNil(t, findPetByIDResponse.JSON200)
+	assert.Equal(t, int64(5), findPetByIDResponse.JSON200.Id)
+	assert.Equal(t, name, findPetByIDResponse.JSON200.Name)
+
+	calls := fake.FindPetByIdCalls()
+	assert.Len(t, calls, 1)
+	assert.Equal(t, int64(5), calls[0].Id)
+
+	_, err = client.DeletePetWithResponse(context.Background(), 5)
+	assert.Error(t, err, "unprogrammed operations should fail")
+	assert.Len(t, fake.DeletePetCalls(), 1)
+}
+
+func TestResponseContentTypeMatching(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	generate := func(matching string) string {
+		opts := Options{
+			GenerateClient:              true,
+			GenerateTypes:               true,
+			ResponseContentTypeMatching: matching,
+		}
+		code, err := Generate(swagger, "api", opts)
+		assert.NoError(t, err)
+		_, err = format.Source([]byte(code))
+		assert.NoError(t, err)
+		return code
+	}
+
+	code := generate("")
+	assert.Contains(t, code, `case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:`)
+	assert.NotContains(t, code, "ResponseContentTypeMatcher")
+
+	code = generate(ContentTypeMatchingStrict)
+	assert.Contains(t, code, `case runtime.MatchContentTypeStrict(rsp.Header.Get("Content-Type"), "application/json") && rsp.StatusCode == 200:`)
+	assert.NotContains(t, code, "ResponseContentTypeMatcher")
+
+	code = generate(ContentTypeMatchingCustom)
+	assert.Contains(t, code, "var ResponseContentTypeMatcher runtime.ContentTypeMatcher = runtime.MatchContentTypeStrict")
+	assert.Contains(t, code, `case ResponseContentTypeMatcher(rsp.Header.Get("Content-Type"), "application/json") && rsp.StatusCode == 200:`)
+
+	_, err = Generate(swagger, "api", Options{ResponseContentTypeMatching: "fuzzy"})
+	assert.Error(t, err)
+}
+
+func TestAsyncJobErrors(t *testing.T) {
+	spec := func(ext string) string {
+		return `
+openapi: "3.0.1"
+info:
+  title: Jobs
+  version: 1.0.0
+paths:
+  /jobs/{id}:
+    get:
+      operationId: getJob
+      x-async-job: ` + ext + `
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The job
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Job'
+components:
+  schemas:
+    Job:
+      type: object
+      properties:
+        state:
+          type: string
+          enum: [running, done]
+        count:
+          type: integer
+    JobWatcher:
+      type: string
+`
+	}
+	tests := []struct {
+		ext string
+		err string
+	}{
+		{"true", "the job has no status property holding its status"},
+		{"{status: count}", "the count property of the job must be a string"},
+		{"{status: state, result: output}", "the job has no output property holding its result"},
+		{"{status: state, name: Task}", "the terminal status succeeded isn't one of the statuses of the job: running, done"},
+		{"{status: state, succeeded: [done], failed: [done]}", "JobWatcher of GetJob clashes with a type of the components"},
+	}
+	for _, test := range tests {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec(test.ext)))
+		assert.NoError(t, err)
+		_, err = Generate(swagger, "api", Options{GenerateTypes: true, GenerateClient: true})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	spec := func(ext string) string {
+		return `
+openapi: "3.0.1"
+info:
+  title: Dashboards
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The user
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{id}/avatar:
+    get:
+      operationId: getAvatar
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The avatar
+          content:
+            image/png: {}
+  /dashboards:
+    get:
+      operationId: getDashboard
+      x-compose: ` + ext + `
+      parameters:
+        - name: user
+          in: query
+          schema:
+            type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: The dashboard
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  owner:
+                    $ref: '#/components/schemas/User'
+                  avatar:
+                    type: string
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	}
+	tests := []struct {
+		ext string
+		err string
+	}{
+		{"[getUser]", "failed to parse x-compose"},
+		{"{}", "x-compose must list the parts of the response"},
+		{"{team: getUser}", "the response has no team property to compose"},
+		{"{owner: getTeam}", `the owner property is composed of the unknown operation "getTeam"`},
+		{"{avatar: getAvatar}", "the avatar property can't be composed of getAvatar, which has no 2xx JSON response"},
+		{"{avatar: getUser}", "the avatar property is of type string, but getUser returns User"},
+		{"{owner: getUser}", "there's no id parameter to pass as its required id parameter"},
+		{"{owner: {operationId: getUser, parameters: {id: user}}}", "the optional user parameter can't be passed as its required id parameter"},
+		{"{owner: {operationId: getUser, parameters: {id: limit}}}", "the limit parameter is of type int, but its id parameter of type string"},
+		{"{owner: {operationId: getUser, parameters: {userId: user}}}", "it has no userId parameter to pass user as"},
+	}
+	for _, test := range tests {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec(test.ext)))
+		assert.NoError(t, err)
+		_, err = Generate(swagger, "api", Options{GenerateTypes: true, GenerateClient: true})
+		if assert.Error(t, err, test.ext) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+}
+
+func TestEncryptedInlineProperty(t *testing.T) {
+	spec := `
+openapi: "3.0.1"
+info:
+  title: Patients
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Patient:
+      type: object
+      properties:
+        contact:
+          type: object
+          properties:
+            phone:
+              type: string
+              x-encrypted: true
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+	_, err = Generate(swagger, "api", Options{GenerateTypes: true})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Patient.contact.phone is an encrypted property of an inline object")
+	}
+}
+
+func TestSLOErrors(t *testing.T) {
+	spec := func(ext string) string {
+		return `
+openapi: "3.0.1"
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      x-slo: ` + ext + `
+      responses:
+        '200':
+          description: The orders
+`
+	}
+	tests := []struct {
+		ext string
+		err string
+	}{
+		{"99.9", "failed to parse x-slo"},
+		{"{}", "x-slo needs an availability or a latency"},
+		{"{availability: 100}", "availability must be between 0 and 100%, exclusive"},
+		{"{latency: fast}", "invalid latency"},
+		{"{latency: -1s}", "latency must be positive"},
+		{"{availability: 99, latencyTarget: 95}", "latencyTarget needs a latency"},
+	}
+	for _, test := range tests {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec(test.ext)))
+		assert.NoError(t, err)
+		_, err = Generate(swagger, "api", Options{GenerateEchoServer: true, GenerateSLO: true})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec("{availability: 99}")))
+	assert.NoError(t, err)
+	_, err = Generate(swagger, "api", Options{GenerateTypes: true, GenerateSLO: true})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the SLOs require a server to be generated with them")
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	spec := func(in, schema, ext string) string {
+		return `
+openapi: "3.0.1"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: filter
+          in: ` + in + `
+          required: true
+          schema: ` + schema + `
+          x-filter-grammar: ` + ext + `
+      responses:
+        '200':
+          description: The pets
+`
+	}
+	tests := []struct {
+		in     string
+		schema string
+		ext    string
+		err    string
+	}{
+		{"header", "{type: string}", "{syntax: odata, fields: {name: string}}", "can only be used on query parameters"},
+		{"query", "{type: integer}", "{syntax: odata, fields: {name: string}}", "must be a string"},
+		{"query", "{type: string}", "odata", "failed to parse x-filter-grammar"},
+		{"query", "{type: string}", "{syntax: fiql, fields: {name: string}}", `unknown syntax "fiql"`},
+		{"query", "{type: string}", "{syntax: rsql}", "has no fields"},
+		{"query", "{type: string}", "{syntax: rsql, fields: {name: text}}", `has unknown type "text"`},
+		{"query", "{type: string}", "{syntax: rsql, fields: {pet_name: string, petName: string}}", "have the same Go name, PetName"},
+	}
+	for _, test := range tests {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec(test.in, test.schema, test.ext)))
+		assert.NoError(t, err)
+		_, err = Generate(swagger, "api", Options{GenerateTypes: true, GenerateClient: true})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+}
+
+func TestOperationLiterals(t *testing.T) {
+	// Operation IDs are quoted, whatever characters they hold.
+	spec := `
+openapi: "3.0.1"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: 'get"Pet\\'
+      x-slo: {availability: 99.9}
+      deprecated: true
+      responses:
+        '200':
+          description: The pet
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+	code, err := Generate(swagger, "api", Options{GenerateEchoServer: true, GenerateAudit: true, GenerateSLO: true, GenerateDeprecation: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `runtime.AuditOperation(ctx.Request().Context(), "get\"Pet\\\\")`)
+	assert.Contains(t, code, `runtime.SLOOperation(ctx.Request().Context(), "get\"Pet\\\\")`)
+	assert.Contains(t, code, `OperationID: "get\"Pet\\\\",`)
+	assert.Contains(t, code, "var OperationSLOs = map[string]runtime.SLO{\n\t"+`"get\"Pet\\\\": {`)
+	assert.Contains(t, code, `runtime.DeprecatedOperationCalled(ctx.Request().Context(), "get\"Pet\\\\")`)
+	assert.Contains(t, code, "var DeprecatedOperations = map[string]runtime.DeprecatedOperation{\n\t"+`"get\"Pet\\\\": {`)
+
+	swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+	code, err = Generate(swagger, "api", Options{GenerateChiServer: true, GenerateAudit: true, GenerateSLO: true, GenerateDeprecation: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `runtime.AuditOperation(ctx, "get\"Pet\\\\")`)
+	assert.Contains(t, code, `runtime.SLOOperation(ctx, "get\"Pet\\\\")`)
+	assert.Contains(t, code, `runtime.DeprecatedOperationCalled(ctx, "get\"Pet\\\\")`)
+}
+
+func TestDeprecationErrors(t *testing.T) {
+	spec := func(deprecated, sunset string) string {
+		return `
+openapi: "3.0.1"
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      deprecated: ` + deprecated + `
+      x-sunset: ` + sunset + `
+      responses:
+        '200':
+          description: The orders
+`
+	}
+	tests := []struct {
+		deprecated string
+		sunset     string
+		err        string
+	}{
+		{"false", "2027-01-01", "x-sunset is only allowed on deprecated operations"},
+		{"true", "soon", `x-sunset must be a date or a date-time, got "soon"`},
+		{"true", "[2027]", "failed to parse x-sunset"},
+	}
+	for _, test := range tests {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec(test.deprecated, test.sunset)))
+		assert.NoError(t, err)
+		_, err = Generate(swagger, "api", Options{GenerateChiServer: true, GenerateDeprecation: true})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+	}
+}
+
+func TestUnexpectedContentTypeErrors(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	opts := Options{
+		GenerateClient: true,
+		GenerateTypes:  true,
+	}
+	code, err := Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, code, "runtime.CheckResponseContentType")
+
+	opts.UnexpectedContentTypeErrors = true
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+	assert.Contains(t, code, `case rsp.StatusCode == 200:
+		err = runtime.CheckResponseContentType(rsp, bodyBytes, runtime.MatchContentTypeLenient, "application/json")`)
+	assert.Contains(t, code, `case rsp.StatusCode == 204:
+		break // No content-type`)
+
+	opts.ResponseContentTypeMatching = ContentTypeMatchingStrict
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, `err = runtime.CheckResponseContentType(rsp, bodyBytes, runtime.MatchContentTypeStrict, "application/json")`)
+}
+
+func TestTypedErrorNames(t *testing.T) {
+	spec := `
+openapi: "3.0.1"
+info:
+  title: Errors
+  version: 1.0.0
+paths:
+  /things:
+    get:
+      operationId: listThings
+      responses:
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Problem'
+        '409':
+          description: Conflict
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Problem'
+    post:
+      operationId: addThing
+      responses:
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  reason:
+                    type: string
+components:
+  schemas:
+    Problem:
+      type: object
+      properties:
+        message:
+          type: string
+    ConflictError:
+      type: string
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{GenerateClient: true, GenerateTypes: true}
+	code, err := Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, code, "NotFoundProblemError")
+
+	opts.TypedErrors = true
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+	assert.Contains(t, code, "type NotFoundProblemError struct {")
+	assert.Contains(t, code, "type NotFoundAddThingError struct {")
+	assert.Contains(t, code, "type ConflictResponseError struct {")
+	assert.Contains(t, code, "return response, NotFoundAddThingError{StatusCode: rsp.StatusCode, Body: *response.JSON404}")
+}
+
+func TestExampleTestsGeneration(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../internal/test/examples/examples.yaml")
+	assert.NoError(t, err)
+
+	code, err := Generate(swagger, "examples", Options{GenerateExamples: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "func TestSpecExamples(t *testing.T) {")
+	assert.Contains(t, code, `"testing"`)
+	assert.Contains(t, code, "name:    \"ListPetsJSON200/cats\",")
+	assert.Contains(t, code, "example: `{\"id\":3,\"name\":\"Rex\",\"owner\":{\"name\":\"Alex\"},\"tag\":\"dog\"}`,")
+
+	// Examples containing back quotes can't be raw strings
+	test := ExampleTest{JSON: "{\"name\":\"`tick`\"}"}
+	assert.Equal(t, `"{\"name\":\"`+"`tick`"+`\"}"`, test.Literal())
+}
+
+func TestEmbedExternalExamples(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: External examples
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+              examples:
+                cats:
+                  externalValue: https://example.com/cats.json
+                notes:
+                  externalValue: notes.txt
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+  examples:
+    cats:
+      externalValue: https://example.com/cats.json
+`
+	load := func() *openapi3.Swagger {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+		assert.NoError(t, err)
+		return swagger
+	}
+	content := map[string]string{
+		"https://example.com/cats.json": `[{"name": "Tom"}]`,
+		"notes.txt":                     "no cats",
+	}
+	var fetched []string
+	fetch := func(url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		data, found := content[url]
+		if !found {
+			return nil, fmt.Errorf("not found")
+		}
+		return []byte(data), nil
+	}
+
+	swagger := load()
+	lock, err := EmbedExternalExamples(swagger, fetch, ExampleLock{"https://example.com/gone.json": "sha256:00"})
+	assert.NoError(t, err)
+	// Each URL is fetched once, and URLs which the spec doesn't use any more
+	// are dropped from the lock.
+	assert.ElementsMatch(t, []string{"https://example.com/cats.json", "notes.txt"}, fetched)
+	assert.Len(t, lock, 2)
+	assert.Equal(t, "sha256:e14e498e4f4db4b873415ee29db4d2ff778594a922725d6c4c845a83a6986be2", lock["notes.txt"])
+
+	examples := swagger.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Examples
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Tom"}}, examples["cats"].Value.Value)
+	assert.Equal(t, "", examples["cats"].Value.ExternalValue)
+	assert.Equal(t, "no cats", examples["notes"].Value.Value)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Tom"}}, swagger.Components.Examples["cats"].Value.Value)
+
+	code, err := Generate(swagger, "pets", Options{GenerateExamples: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "name:    \"ListPetsJSON200/cats\",")
+
+	// The lock survives a round trip through its file.
+	parsed, err := ParseExampleLock(lock.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, lock, parsed)
+	_, err = ParseExampleLock([]byte("https://example.com/cats.json\n"))
+	assert.EqualError(t, err, `line 1 isn't of the form "<url> sha256:<hash>"`)
+
+	// Content which changed since it was locked is an error.
+	content["notes.txt"] = "one cat"
+	_, err = EmbedExternalExamples(load(), fetch, lock)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error embedding example notes of GET /pets: content of notes.txt has hash sha256:")
+	assert.Contains(t, err.Error(), "but the lock has "+lock["notes.txt"])
+
+	delete(content, "notes.txt")
+	_, err = EmbedExternalExamples(load(), fetch, ExampleLock{})
+	assert.EqualError(t, err, "error embedding example notes of GET /pets: error fetching notes.txt: not found")
+}
+
+func TestFuzzTestsGeneration(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../internal/test/fuzz/fuzz.yaml")
+	assert.NoError(t, err)
+
+	code, err := Generate(swagger, "fuzz", Options{GenerateFuzzTests: true})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(code, "//go:build go1.18\n// +build go1.18\n\n"))
+	assert.Contains(t, code, "func FuzzListBooks(f *testing.F) {")
+	assert.Contains(t, code, "f.Add(validate, uint64(0), `1`, `limit=10&tags=a`, `aaaaaaaa`)")
+	assert.Contains(t, code, `"authors":[{"name":"Ursula K. Le Guin"}]`)
+	assert.NotContains(t, code, `"id":`, "read only properties aren't sent")
+
+	files, err := GenerateFiles(swagger, "fuzz", Options{GenerateFuzzTests: true, GenerateEchoServer: true})
+	assert.NoError(t, err)
+	assert.Contains(t, files, "fuzz.gen_test.go")
+	assert.Contains(t, files, "server.gen.go")
+
+	_, err = Generate(swagger, "fuzz", Options{GenerateFuzzTests: true, GenerateEchoServer: true})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the fuzz tests have to be generated in a file of their own")
+	}
+}
+
+func TestRuntimePackage(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../examples/petstore-expanded/petstore-expanded.yaml")
+	assert.NoError(t, err)
+
+	opts := Options{GenerateClient: true, GenerateProvenance: true}
+	code, err := Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, `"github.com/shawnhankim/oapi-codegen/pkg/runtime"`)
+
+	opts.RuntimePackage = "corp.example.com/mirror/oapi-codegen/pkg/runtime"
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, `"corp.example.com/mirror/oapi-codegen/pkg/runtime"`)
+	assert.NotContains(t, code, `"github.com/shawnhankim/oapi-codegen/pkg/runtime"`)
+	assert.Contains(t, code, "-runtime-package=corp.example.com/mirror/oapi-codegen/pkg/runtime")
+
+	// Paths which don't end with runtime get an alias.
+	opts.RuntimePackage = "corp.example.com/vendor/oapiruntime"
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, `runtime "corp.example.com/vendor/oapiruntime"`)
+
+	opts.RuntimePackage = "corp.example.com/oapi runtime"
+	_, err = Generate(swagger, "api", opts)
+	assert.EqualError(t, err, `invalid runtime package path: "corp.example.com/oapi runtime"`)
+
+	// The other packages are imported from the directory of the runtime package.
+	spec := `
+openapi: "3.0.1"
+info:
+  title: Visits
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Visit:
+      type: object
+      properties:
+        day:
+          type: string
+          format: date
+        contact:
+          type: string
+          format: email
+`
+	swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+	opts = Options{GenerateTypes: true, GenerateSchemaInfo: true}
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, `openapi_types "github.com/shawnhankim/oapi-codegen/pkg/types"`)
+	assert.Contains(t, code, `"github.com/shawnhankim/oapi-codegen/pkg/schemainfo"`)
+
+	opts.RuntimePackage = "corp.example.com/mirror/oapi-codegen/pkg/runtime"
+	code, err = Generate(swagger, "api", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, `openapi_types "corp.example.com/mirror/oapi-codegen/pkg/types"`)
+	assert.Contains(t, code, `"corp.example.com/mirror/oapi-codegen/pkg/schemainfo"`)
+	assert.NotContains(t, code, `"github.com/shawnhankim/oapi-codegen/pkg/`)
+}
+
+func TestFieldPresenceMethodClash(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Field presence
+paths: {}
+components:
+  schemas:
+    Flag:
+      type: object
+      properties:
+        isSet:
+          type: boolean
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	_, err = Generate(swagger, "flags", Options{GenerateTypes: true})
+	assert.NoError(t, err)
+	_, err = Generate(swagger, "flags", Options{GenerateTypes: true, FieldPresence: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "property isSet of Flag has the name of a field presence method, IsSet")
+}
+
+func TestDateTimeOptions(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../internal/test/datetime/datetime.yaml")
+	assert.NoError(t, err)
+
+	code, err := Generate(swagger, "datetime", Options{GenerateTypes: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "type Timestamp time.Time")
+	assert.Contains(t, code, "At      time.Time     `json:\"at\"`")
+	assert.Contains(t, code, "func (t EventDayTime) MarshalText() ([]byte, error) {")
+	assert.NotContains(t, code, "type DateTime struct")
+
+	code, err = Generate(swagger, "datetime", Options{GenerateTypes: true, DateTimeLayout: "RFC3339"})
+	assert.NoError(t, err)
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+	assert.Contains(t, code, "type Timestamp = DateTime")
+	assert.Contains(t, code, `return []byte(t.Time.Format("2006-01-02T15:04:05Z07:00")), nil`)
+
+	code, err = Generate(swagger, "datetime", Options{GenerateTypes: true, DateTimeUTC: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `return []byte(t.Time.UTC().Format("2006-01-02T15:04:05.999999999Z07:00")), nil`)
+
+	_, err = Generate(swagger, "datetime", Options{GenerateTypes: true, DateTimeLayout: "seconds"})
+	assert.Error(t, err)
+
+	spec := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Date-time collision
+paths: {}
+components:
+  schemas:
+    DateTime:
+      type: string
+      format: date-time
+`
+	swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	assert.NoError(t, err)
+	_, err = Generate(swagger, "datetime", Options{GenerateTypes: true})
+	assert.NoError(t, err)
+	_, err = Generate(swagger, "datetime", Options{GenerateTypes: true, DateTimeUTC: true})
+	assert.EqualError(t, err, "error generating type definitions: date-time type DateTime has the name of the type generated for DateTime")
+}
+
+func TestSwagger2Extensions(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../internal/test/swagger2/swagger2.yaml")
+	assert.NoError(t, err)
+
+	// The extensions are ignored unless they're asked for.
+	code, err := Generate(swagger, "swagger2", Options{GenerateTypes: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "Nickname string   `json:\"nickname\"`")
+	assert.Contains(t, code, "Email    *string  `json:\"email,omitempty\"`")
+
+	code, err = Generate(swagger, "swagger2", Options{GenerateTypes: true, Swagger2Extensions: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "Nickname *string  `json:\"nickname\"`")
+	assert.Contains(t, code, "Email    string   `json:\"email,omitempty\"`")
+
+	swagger.Components.Schemas["Account"].Value.Properties["note"].Value.Extensions["x-omitempty"] = json.RawMessage(`"no"`)
+	_, err = Generate(swagger, "swagger2", Options{GenerateTypes: true, Swagger2Extensions: true})
+	assert.Error(t, err)
+}
+
 func TestFilterOperationsByTag(t *testing.T) {
 	packageName := "testswagger"
 	t.Run("include tags", func(t *testing.T) {
@@ -175,8 +1224,8 @@ type getTestByNameResponse struct {
 	// Check the client method signatures:
 	assert.Contains(t, code, "type GetTestByNameParams struct {")
 	assert.Contains(t, code, "Top *int `json:\"$top,omitempty\"`")
-	assert.Contains(t, code, "func (c *Client) GetTestByName(ctx context.Context, name string, params *GetTestByNameParams) (*http.Response, error) {")
-	assert.Contains(t, code, "func (c *ClientWithResponses) GetTestByNameWithResponse(ctx context.Context, name string, params *GetTestByNameParams) (*getTestByNameResponse, error) {")
+	assert.Contains(t, code, "func (c *Client) GetTestByName(ctx context.Context, name string, params *GetTestByNameParams, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) GetTestByNameWithResponse(ctx context.Context, name string, params *GetTestByNameParams, reqEditors ...RequestEditorFn) (*getTestByNameResponse, error) {")
 
 	// Make sure the generated code is valid:
 	linter := new(lint.Linter)
@@ -322,3 +1371,191 @@ components:
           type: string
           enum: [car, dog, oldage]
 `
+
+const testGatewaySpec = `
+openapi: "3.0.1"
+info:
+  version: 1.0.0
+  title: Gateway
+servers:
+  - url: https://{host}/api/v1
+    variables:
+      host:
+        default: pets.example.com
+security:
+  - ApiKey: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      security: []
+      responses:
+        200:
+          description: ok
+    post:
+      operationId: addPet
+      responses:
+        201:
+          description: ok
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      responses:
+        200:
+          description: ok
+    delete:
+      operationId: deletePet
+      security:
+        - Bearer: []
+      responses:
+        204:
+          description: ok
+  /pets/mine:
+    get:
+      operationId: listMyPets
+      security:
+        - Bearer: []
+        - ApiKey: []
+      responses:
+        200:
+          description: ok
+components:
+  securitySchemes:
+    ApiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    Bearer:
+      type: http
+      scheme: bearer
+`
+
+func TestTagClientNames(t *testing.T) {
+	const spec = `
+openapi: "3.0.1"
+info:
+  version: 1.0.0
+  title: Tag clients
+paths:
+  /files/{name}:
+    get:
+      operationId: getFile
+      tags: [files]
+      parameters:
+        - {name: name, in: path, required: true, schema: {type: string}}
+      responses:
+        200:
+          description: The whole file
+          content:
+            application/octet-stream: {schema: {type: string, format: binary}}
+        206:
+          description: A range of the file
+          content:
+            application/octet-stream: {schema: {type: string, format: binary}}
+    put:
+      operationId: putFile
+      tags: [files, uploads]
+      parameters:
+        - {name: name, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json: {schema: {$ref: '#/components/schemas/File'}}
+      responses:
+        204: {description: ok}
+components:
+  schemas:
+    File:
+      type: object
+      properties:
+        data: {type: string}
+`
+	loadSwagger := func() *openapi3.Swagger {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+		assert.NoError(t, err)
+		return swagger
+	}
+	opts := Options{
+		GenerateTypes:      true,
+		GenerateClient:     true,
+		GenerateTagClients: true,
+		GenerateFakeClient: true,
+		GenerateEchoServer: true,
+		GenerateInMemory:   true,
+	}
+	code, err := Generate(loadSwagger(), "api", opts)
+	assert.NoError(t, err)
+	file, err := parser.ParseFile(token.NewFileSet(), "api.gen.go", code, 0)
+	assert.NoError(t, err)
+
+	// The names reserved by DescribeTags must be those of the generated code:
+	// the members of Client and the package-level names ending in Client.
+	members := make(map[string]bool)
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				declared[decl.Name.Name] = true
+			} else if star, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok && fmt.Sprint(star.X) == "Client" && decl.Name.IsExported() {
+				members[decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				declared[ts.Name.Name] = true
+				if st, ok := ts.Type.(*ast.StructType); ok && ts.Name.Name == "Client" {
+					for _, field := range st.Fields.List {
+						for _, name := range field.Names {
+							if name.IsExported() {
+								members[name.Name] = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	ops, err := OperationDefinitions(loadSwagger())
+	assert.NoError(t, err)
+	tags, err := DescribeTags(ops, nil)
+	assert.NoError(t, err)
+	wantMembers := clientMembers(ops)
+	wantDeclared := make(map[string]bool)
+	for _, name := range clientPackageNames {
+		wantDeclared[name] = true
+	}
+	for _, tag := range tags {
+		wantMembers[tag.GoName] = true
+		wantDeclared[tag.GoName+"Client"] = true
+		wantDeclared["New"+tag.GoName+"Client"] = true
+	}
+	assert.Equal(t, wantMembers, members)
+	for name := range declared {
+		if !strings.HasSuffix(name, "Client") {
+			delete(declared, name)
+		}
+	}
+	assert.Equal(t, wantDeclared, declared)
+
+	// The sub-client of the tag fake would clash with the fake client.
+	swagger := loadSwagger()
+	swagger.Paths["/files/{name}"].Get.Tags = []string{"fake"}
+	_, err = Generate(swagger, "api", opts)
+	assert.EqualError(t, err, "error generating tag clients: the sub-client of tag 'fake' would clash with the generated FakeClient")
+
+	// And that of the tag files with the type of a component named so.
+	swagger = loadSwagger()
+	swagger.Components.Schemas["FilesClient"] = swagger.Components.Schemas["File"]
+	_, err = Generate(swagger, "api", opts)
+	assert.EqualError(t, err, "error generating tag clients: the sub-client of tag 'files' would clash with the generated FilesClient")
+}
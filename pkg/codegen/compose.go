@@ -0,0 +1,271 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// CompositionDefinition describes the response of an operation which
+// aggregates the responses of other operations, per x-compose, for which a
+// helper fanning out to them is generated.
+type CompositionDefinition struct {
+	ModelType string            // The Go type of the composed response
+	Parts     []CompositionPart // The parts of the response, sorted by property
+}
+
+// CompositionPart is a property of a composed response, holding the response
+// of another operation.
+type CompositionPart struct {
+	Property        Property // The property of the composed response
+	OperationId     string   // The Go name of the operation returning the part
+	SpecOperationId string   // The operationId of that operation in the spec
+	FieldName       string   // The field of its response type holding the part, eg, JSON200
+	Args            string   // The arguments of the call, after the context
+}
+
+// OperationIds returns the operationIds of the operations called, in the
+// order of the parts, for comments, eg, "getUser, listTeams and listOrders".
+func (c CompositionDefinition) OperationIds() string {
+	ids := make([]string, len(c.Parts))
+	for i, part := range c.Parts {
+		ids[i] = part.SpecOperationId
+	}
+	if len(ids) < 2 {
+		return strings.Join(ids, "")
+	}
+	return strings.Join(ids[:len(ids)-1], ", ") + " and " + ids[len(ids)-1]
+}
+
+// compositionPartExt is a part of x-compose: the operationId of the operation
+// returning it, and the parameters of the composed operation to pass as
+// those of that operation, keyed by the names of the latter. Parameters which
+// aren't listed get the parameter of the same name, if any.
+type compositionPartExt struct {
+	OperationID string            `json:"operationId"`
+	Parameters  map[string]string `json:"parameters"`
+}
+
+func (p *compositionPartExt) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.OperationID); err == nil {
+		return nil
+	}
+	type plain compositionPartExt
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+// describeCompositions reads the x-compose extension of the operations, and
+// describes the parts of their responses, and how to call the operations
+// returning them, which have to be among ops.
+func describeCompositions(ops []OperationDefinition) error {
+	bySpecID := make(map[string]*OperationDefinition, len(ops))
+	for i := range ops {
+		bySpecID[ops[i].SpecOperationId] = &ops[i]
+	}
+	for i := range ops {
+		op := &ops[i]
+		composition, err := describeComposition(op, bySpecID)
+		if err != nil {
+			return fmt.Errorf("operation %s %s: %s", op.Method, op.Path, err)
+		}
+		op.Composition = composition
+	}
+	return nil
+}
+
+// describeComposition describes the composed response of an operation, or
+// returns nil when it doesn't have the x-compose extension.
+func describeComposition(op *OperationDefinition, bySpecID map[string]*OperationDefinition) (*CompositionDefinition, error) {
+	raw, found := op.Spec.Extensions[extOpCompose]
+	if !found {
+		return nil, nil
+	}
+	rawJSON, ok := raw.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object, got %T", extOpCompose, raw)
+	}
+	var ext map[string]compositionPartExt
+	if err := json.Unmarshal(rawJSON, &ext); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", extOpCompose, err)
+	}
+	if len(ext) == 0 {
+		return nil, fmt.Errorf("%s must list the parts of the response", extOpCompose)
+	}
+	if op.IsProxy {
+		return nil, fmt.Errorf("%s can't be used with %s", extOpCompose, extOpProxy)
+	}
+
+	td, err := op.SuccessJSONResponse()
+	if err != nil {
+		return nil, err
+	}
+	if td == nil {
+		return nil, fmt.Errorf("%s requires a 2xx JSON response to compose", extOpCompose)
+	}
+	schema, err := responseGoSchema(op, td.ResponseName)
+	if err != nil {
+		return nil, err
+	}
+
+	composition := &CompositionDefinition{ModelType: td.Schema.TypeDecl()}
+	names := make([]string, 0, len(ext))
+	for name := range ext {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		partExt := ext[name]
+		var property *Property
+		for j, p := range schema.Properties {
+			if p.JsonFieldName == name {
+				property = &schema.Properties[j]
+			}
+		}
+		if property == nil {
+			return nil, fmt.Errorf("the response has no %s property to compose", name)
+		}
+		target, found := bySpecID[partExt.OperationID]
+		if !found {
+			return nil, fmt.Errorf("the %s property is composed of the unknown operation %q", name, partExt.OperationID)
+		}
+		if target.IsProxy || target.HasBody() {
+			return nil, fmt.Errorf("the %s property can't be composed of %s, which has a request body, or %s",
+				name, partExt.OperationID, extOpProxy)
+		}
+		targetTd, err := target.SuccessJSONResponse()
+		if err != nil {
+			return nil, err
+		}
+		if targetTd == nil {
+			return nil, fmt.Errorf("the %s property can't be composed of %s, which has no 2xx JSON response",
+				name, partExt.OperationID)
+		}
+		if property.Schema.TypeDecl() != targetTd.Schema.TypeDecl() {
+			return nil, fmt.Errorf("the %s property is of type %s, but %s returns %s",
+				name, property.Schema.TypeDecl(), partExt.OperationID, targetTd.Schema.TypeDecl())
+		}
+		args, err := compositionArgs(op, target, partExt.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("the %s property can't be composed of %s: %s", name, partExt.OperationID, err)
+		}
+		composition.Parts = append(composition.Parts, CompositionPart{
+			Property:        *property,
+			OperationId:     target.OperationId,
+			SpecOperationId: target.SpecOperationId,
+			FieldName:       targetTd.TypeName,
+			Args:            args,
+		})
+	}
+	return composition, nil
+}
+
+// compositionArgs returns the arguments of the call to target, after the
+// context, which pass on the parameters of op, as mapped by parameters.
+func compositionArgs(op, target *OperationDefinition, parameters map[string]string) (string, error) {
+	targetNames := make([]string, 0, len(parameters))
+	for targetName := range parameters {
+		targetNames = append(targetNames, targetName)
+	}
+	sort.Strings(targetNames)
+	for _, targetName := range targetNames {
+		sourceName := parameters[targetName]
+		if ParameterDefinitions(target.AllParams()).FindByName(targetName) == nil {
+			return "", fmt.Errorf("it has no %s parameter to pass %s as", targetName, sourceName)
+		}
+		if ParameterDefinitions(op.AllParams()).FindByName(sourceName) == nil {
+			return "", fmt.Errorf("there's no %s parameter to pass as %s", sourceName, targetName)
+		}
+	}
+
+	// arg returns the expression passing on the parameter of op which
+	// target gets as param, if any.
+	arg := func(param ParameterDefinition, pointer bool) (string, error) {
+		sourceName := param.ParamName
+		if mapped, found := parameters[sourceName]; found {
+			sourceName = mapped
+		}
+		source := ParameterDefinitions(op.AllParams()).FindByName(sourceName)
+		if source == nil {
+			if param.Required {
+				return "", fmt.Errorf("there's no %s parameter to pass as its required %s parameter", sourceName, param.ParamName)
+			}
+			return "", nil
+		}
+		if source.TypeDef() != param.TypeDef() {
+			return "", fmt.Errorf("the %s parameter is of type %s, but its %s parameter of type %s",
+				sourceName, source.TypeDef(), param.ParamName, param.TypeDef())
+		}
+		expr := source.GoVariableName()
+		sourcePointer := false
+		if source.In != "path" {
+			expr = "params." + source.GoName()
+			sourcePointer = !source.Required
+		}
+		switch {
+		case sourcePointer && !pointer:
+			return "", fmt.Errorf("the optional %s parameter can't be passed as its required %s parameter", sourceName, param.ParamName)
+		case pointer && !sourcePointer:
+			return "&" + expr, nil
+		}
+		return expr, nil
+	}
+
+	var args []string
+	for _, param := range target.PathParams {
+		expr, err := arg(param, false)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, expr)
+	}
+	if target.RequiresParamObject() {
+		var fields []string
+		for _, param := range target.Params() {
+			expr, err := arg(param, !param.Required)
+			if err != nil {
+				return "", err
+			}
+			if expr != "" {
+				fields = append(fields, param.GoName()+": "+expr)
+			}
+		}
+		args = append(args, "&"+target.OperationId+"Params{"+strings.Join(fields, ", ")+"}")
+	}
+	if len(args) == 0 {
+		return "", nil
+	}
+	return ", " + strings.Join(args, ", "), nil
+}
+
+// GenerateCompositions generates the helpers fanning out to the operations
+// which the responses described with x-compose are composed of.
+func GenerateCompositions(t *template.Template, ops []OperationDefinition) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "compose.tmpl", ops)
+	if err != nil {
+		return "", fmt.Errorf("error generating compositions: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for compositions: %s", err)
+	}
+	return buf.String(), nil
+}
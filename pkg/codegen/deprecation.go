@@ -0,0 +1,115 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// DeprecationDefinition describes a deprecated operation. It's generated into
+// a runtime.DeprecatedOperation.
+type DeprecationDefinition struct {
+	OperationId string // The operation ID as written in the spec
+	Method      string
+	Path        string
+	Sunset      time.Time // When the operation is going to be removed, if known
+}
+
+// SunsetDate returns the arguments of time.Date which give the sunset of the
+// operation.
+func (d DeprecationDefinition) SunsetDate() string {
+	s := d.Sunset
+	return fmt.Sprintf("%d, %d, %d, %d, %d, %d, 0, time.UTC",
+		s.Year(), s.Month(), s.Day(), s.Hour(), s.Minute(), s.Second())
+}
+
+// DescribeDeprecation describes an operation marked deprecated in the spec,
+// reading when it's going to be removed from its x-sunset extension, a date
+// such as 2025-06-30 or a date-time. It returns nil when the operation isn't
+// deprecated.
+func DescribeDeprecation(op OperationDefinition) (*DeprecationDefinition, error) {
+	raw, hasSunset := op.Spec.Extensions[extOpSunset]
+	if !op.Spec.Deprecated {
+		if hasSunset {
+			return nil, fmt.Errorf("%s is only allowed on deprecated operations", extOpSunset)
+		}
+		return nil, nil
+	}
+	deprecation := &DeprecationDefinition{
+		OperationId: op.SpecOperationId,
+		Method:      op.Method,
+		Path:        op.Path,
+	}
+	if !hasSunset {
+		return deprecation, nil
+	}
+	rawJSON, ok := raw.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a date, got %T", extOpSunset, raw)
+	}
+	var sunset string
+	if err := json.Unmarshal(rawJSON, &sunset); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", extOpSunset, err)
+	}
+	var err error
+	deprecation.Sunset, err = time.Parse("2006-01-02", sunset)
+	if err != nil {
+		deprecation.Sunset, err = time.Parse(time.RFC3339, sunset)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date or a date-time, got %q", extOpSunset, sunset)
+		}
+	}
+	deprecation.Sunset = deprecation.Sunset.UTC()
+	return deprecation, nil
+}
+
+// GenerateDeprecation generates DeprecatedOperations, which describes the
+// operations marked deprecated in the spec, along with the middleware of the
+// generated servers which tracks the requests to them.
+func GenerateDeprecation(t *template.Template, ops []OperationDefinition) (string, error) {
+	context := struct {
+		Operations []DeprecationDefinition
+		Echo       bool
+		Chi        bool
+	}{
+		Echo: globalState.options.GenerateEchoServer,
+		Chi:  globalState.options.GenerateChiServer,
+	}
+	for _, op := range ops {
+		deprecation, err := DescribeDeprecation(op)
+		if err != nil {
+			return "", fmt.Errorf("error describing the deprecation of %s: %s", op.OperationId, err)
+		}
+		if deprecation != nil {
+			context.Operations = append(context.Operations, *deprecation)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "deprecation.tmpl", context)
+	if err != nil {
+		return "", fmt.Errorf("error generating deprecated operations: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for deprecated operations: %s", err)
+	}
+	return buf.String(), nil
+}
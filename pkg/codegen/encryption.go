@@ -0,0 +1,91 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// encryptedTypes returns the types with properties marked with x-encrypted,
+// which get MarshalJSON and UnmarshalJSON methods encrypting them, except
+// those with additional properties, which already have theirs. It also
+// returns whether any property is encrypted at all. Encrypted properties of
+// inline objects, which can't have methods, are rejected.
+func encryptedTypes(typeDefs []TypeDefinition) ([]TypeDefinition, bool, error) {
+	var types []TypeDefinition
+	anyEncrypted := false
+	for _, td := range typeDefs {
+		if td.Schema.DefineViaAlias {
+			continue
+		}
+		if len(td.Schema.EncryptedProperties()) != 0 {
+			anyEncrypted = true
+			if !td.Schema.HasAdditionalProperties {
+				types = append(types, td)
+			}
+		}
+		for _, p := range td.Schema.Properties {
+			if err := checkInlineEncryption(p.Schema, []string{td.TypeName, p.JsonFieldName}); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	return types, anyEncrypted, nil
+}
+
+// checkInlineEncryption returns an error when an inline object schema, which
+// has no type of its own, has properties marked with x-encrypted.
+func checkInlineEncryption(schema Schema, path []string) error {
+	if schema.IsRef() {
+		return nil
+	}
+	for _, p := range schema.Properties {
+		propertyPath := append(path, p.JsonFieldName)
+		if p.Encrypted {
+			return fmt.Errorf("%s is an encrypted property of an inline object, which has to be moved to the components for %s to work",
+				strings.Join(propertyPath, "."), extPropEncrypted)
+		}
+		if err := checkInlineEncryption(p.Schema, propertyPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateEncryptedFields generates the JSON methods of the types with
+// encrypted properties, and the EncryptedFieldCipher variable which they use.
+func GenerateEncryptedFields(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	types, anyEncrypted, err := encryptedTypes(typeDefs)
+	if err != nil {
+		return "", err
+	}
+	if !anyEncrypted {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = t.ExecuteTemplate(w, "encrypted-fields.tmpl", types)
+	if err != nil {
+		return "", fmt.Errorf("error generating encrypted fields: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for encrypted fields: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ErrorResponse is a JSON error response of an operation, which the Parse
+// functions of the client return as a typed error, with the TypedErrors
+// option.
+type ErrorResponse struct {
+	ResponseName string    // The status of the response, eg, 404, 4XX or default
+	FieldName    string    // The field of the response type holding the body, eg, JSON404
+	TypeName     string    // The name of the error type, eg, NotFoundError
+	BodyType     string    // The Go type of the body
+	Code         *Property // The scalar code property of the body, if any
+	Message      *Property // The string message property of the body, if any
+}
+
+// CodeZero returns the zero value of the code property, which matches any
+// code in Is.
+func (r ErrorResponse) CodeZero() string {
+	if r.Code.Schema.GoType == "string" {
+		return `""`
+	}
+	return "0"
+}
+
+// Condition returns the condition on a parsed response under which it holds
+// this error. Default responses are only errors for 4xx and 5xx statuses.
+func (r ErrorResponse) Condition() string {
+	condition := fmt.Sprintf("response.%s != nil", r.FieldName)
+	if r.ResponseName == "default" {
+		condition = "rsp.StatusCode >= 400 && " + condition
+	}
+	return condition
+}
+
+// comparableScalars are the Go types of code properties, which can be
+// compared in Is.
+var comparableScalars = []string{"string", "int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64"}
+
+// describeErrorResponses returns the JSON responses of an operation with 4xx
+// and 5xx statuses, as well as the default one, without their type names,
+// which are given by nameErrorTypes.
+func describeErrorResponses(op *OperationDefinition) ([]ErrorResponse, error) {
+	if op.IsProxy {
+		return nil, nil
+	}
+	tds, err := op.GetResponseTypeDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	var responses []ErrorResponse
+	for _, td := range tds {
+		if !strings.HasPrefix(td.TypeName, "JSON") || !isErrorResponseName(td.ResponseName) {
+			continue
+		}
+		response := ErrorResponse{
+			ResponseName: td.ResponseName,
+			FieldName:    td.TypeName,
+			BodyType:     td.Schema.TypeDecl(),
+		}
+		schema, err := responseGoSchema(op, td.ResponseName)
+		if err != nil {
+			return nil, err
+		}
+		for i, p := range schema.Properties {
+			switch {
+			case p.JsonFieldName == "code" && StringInArray(p.Schema.GoType, comparableScalars):
+				response.Code = &schema.Properties[i]
+			case p.JsonFieldName == "message" && p.Schema.GoType == "string":
+				response.Message = &schema.Properties[i]
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// isErrorResponseName returns whether a response is an error one.
+func isErrorResponseName(name string) bool {
+	if name == "default" {
+		return true
+	}
+	if IsStatusCodeRange(name) {
+		return name[0] == '4' || name[0] == '5'
+	}
+	status, err := strconv.Atoi(name)
+	return err == nil && status >= 400
+}
+
+// responseGoSchema describes the JSON body of a response of op, with its
+// properties. The properties of referenced schemas aren't described, so the
+// referenced value is described again.
+func responseGoSchema(op *OperationDefinition, responseName string) (Schema, error) {
+	schemaRef := jsonResponseSchema(op.Spec.Responses[responseName].Value)
+	return GenerateGoSchema(schemaRef, []string{op.OperationId, responseName})
+}
+
+// jsonResponseSchema returns the schema of the first JSON content of a
+// response, without its reference.
+func jsonResponseSchema(response *openapi3.Response) *openapi3.SchemaRef {
+	for _, contentType := range SortedContentKeys(response.Content) {
+		content := response.Content[contentType]
+		if StringInArray(contentType, contentTypesJSON) && content.Schema != nil {
+			return &openapi3.SchemaRef{Value: content.Schema.Value}
+		}
+	}
+	return nil
+}
+
+// errorTypeBaseName returns the name of the error type for a response, without
+// the Error suffix, eg, NotFound for 404 and Client for 4XX.
+func errorTypeBaseName(responseName string) string {
+	switch {
+	case responseName == "default":
+		return "Default"
+	case IsStatusCodeRange(responseName) && responseName[0] == '4':
+		return "Client"
+	case IsStatusCodeRange(responseName):
+		return "Server"
+	}
+	status, _ := strconv.Atoi(responseName)
+	text := http.StatusText(status)
+	if text == "" {
+		return "Status" + responseName
+	}
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = UppercaseFirstCharacter(word)
+	}
+	return strings.TrimSuffix(strings.Join(words, ""), "Error")
+}
+
+// nameErrorTypes names the types of the error responses of the operations
+// after their status, eg, NotFoundError. When responses with the same status
+// have different bodies, the name of the body type, or of the first operation
+// declaring it for inline schemas, goes between the two, eg,
+// NotFoundProblemError. Names which clash with a type from the components get
+// "Response" before "Error".
+func nameErrorTypes(ops []OperationDefinition, reserved map[string]bool) {
+	// The distinct body types of each base name, in order.
+	bodyTypes := make(map[string][]string)
+	firstOps := make(map[string]string)
+	for _, op := range ops {
+		for _, response := range op.ErrorResponses {
+			base := errorTypeBaseName(response.ResponseName)
+			if !StringInArray(response.BodyType, bodyTypes[base]) {
+				bodyTypes[base] = append(bodyTypes[base], response.BodyType)
+				firstOps[base+" "+response.BodyType] = op.OperationId
+			}
+		}
+	}
+	for i := range ops {
+		for j := range ops[i].ErrorResponses {
+			response := &ops[i].ErrorResponses[j]
+			name := errorTypeBaseName(response.ResponseName)
+			if len(bodyTypes[name]) > 1 {
+				if goIdentifierRe.MatchString(response.BodyType) {
+					name += UppercaseFirstCharacter(response.BodyType)
+				} else {
+					name += firstOps[name+" "+response.BodyType]
+				}
+			}
+			if reserved[name+"Error"] {
+				name += "Response"
+			}
+			response.TypeName = name + "Error"
+		}
+	}
+}
+
+// componentTypeNames returns the names of the types generated for the
+// components of the spec.
+func componentTypeNames(swagger *openapi3.Swagger) map[string]bool {
+	names := make(map[string]bool)
+	for name := range swagger.Components.Schemas {
+		names[SchemaNameToTypeName(name)] = true
+	}
+	for name := range swagger.Components.Parameters {
+		names[SchemaNameToTypeName(name)] = true
+	}
+	for name := range swagger.Components.RequestBodies {
+		names[SchemaNameToTypeName(name)] = true
+	}
+	for name := range swagger.Components.Responses {
+		names[SchemaNameToTypeName(name)] = true
+	}
+	return names
+}
+
+// ErrorTypes returns the distinct error types of the operations, in the order
+// they're first declared.
+func ErrorTypes(ops []OperationDefinition) []ErrorResponse {
+	var types []ErrorResponse
+	seen := make(map[string]bool)
+	for _, op := range ops {
+		for _, response := range op.ErrorResponses {
+			if !seen[response.TypeName] {
+				seen[response.TypeName] = true
+				types = append(types, response)
+			}
+		}
+	}
+	return types
+}
+
+// GenerateErrorTypes generates the error types of the error responses, which
+// the Parse functions of the client return with the TypedErrors option.
+func GenerateErrorTypes(t *template.Template, ops []OperationDefinition) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "error-types.tmpl", ErrorTypes(ops))
+	if err != nil {
+		return "", fmt.Errorf("error generating error types: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for error types: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// ExampleTest describes a single case of the generated example tests: an
+// example from the spec, and the generated type which it should fit.
+type ExampleTest struct {
+	Name     string // The name of the test case, eg, "Pet" or "FindPetsJSON200/cats"
+	TypeDecl string // The Go type which the example is unmarshaled into
+	JSON     string // The JSON encoding of the example
+}
+
+// Literal returns the JSON of the example as a Go string literal.
+func (e ExampleTest) Literal() string {
+	return goStringLiteral(e.JSON)
+}
+
+// goStringLiteral returns a Go string literal of s, a raw one unless s has
+// backquotes or characters which can't be in one.
+func goStringLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r\x00") || !utf8.ValidString(s) {
+		return fmt.Sprintf("%q", s)
+	}
+	return "`" + s + "`"
+}
+
+// This collects the examples of component schemas, of component responses,
+// and of the JSON responses of all operations, along with the generated types
+// which they belong to.
+func DescribeExampleTests(swagger *openapi3.Swagger, ops []OperationDefinition) ([]ExampleTest, error) {
+	var tests []ExampleTest
+
+	for _, schemaName := range SortedSchemaKeys(swagger.Components.Schemas) {
+		schemaRef := swagger.Components.Schemas[schemaName]
+		if schemaRef.Value == nil || schemaRef.Value.Example == nil {
+			continue
+		}
+		test, err := newExampleTest(SchemaNameToTypeName(schemaName), SchemaNameToTypeName(schemaName), schemaRef.Value.Example)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error encoding example of schema %s", schemaName))
+		}
+		tests = append(tests, test)
+	}
+
+	for _, responseName := range SortedResponsesKeys(swagger.Components.Responses) {
+		responseRef := swagger.Components.Responses[responseName]
+		if responseRef.Value == nil {
+			continue
+		}
+		jsonResponse, found := responseRef.Value.Content["application/json"]
+		if !found {
+			continue
+		}
+		typeName := SchemaNameToTypeName(responseName)
+		mediaTests, err := mediaTypeExampleTests(typeName, typeName, jsonResponse)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error encoding example of response %s", responseName))
+		}
+		tests = append(tests, mediaTests...)
+	}
+
+	for _, op := range ops {
+		typeDefinitions, err := op.GetResponseTypeDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		for _, typeDefinition := range typeDefinitions {
+			if !strings.HasPrefix(typeDefinition.TypeName, "JSON") || typeDefinition.Schema.TypeDecl() == "interface{}" {
+				continue
+			}
+			responseRef := op.Spec.Responses[typeDefinition.ResponseName]
+			if responseRef == nil || responseRef.Value == nil {
+				continue
+			}
+			for _, contentTypeName := range SortedContentKeys(responseRef.Value.Content) {
+				if !StringInArray(contentTypeName, contentTypesJSON) {
+					continue
+				}
+				mediaTests, err := mediaTypeExampleTests(op.OperationId+typeDefinition.TypeName,
+					typeDefinition.Schema.TypeDecl(), responseRef.Value.Content[contentTypeName])
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("error encoding example of %s response %s",
+						op.OperationId, typeDefinition.ResponseName))
+				}
+				tests = append(tests, mediaTests...)
+			}
+		}
+	}
+	return tests, nil
+}
+
+// This returns a test for the example of a media type, and one for each of
+// its named examples. Examples which are only available externally are
+// skipped.
+func mediaTypeExampleTests(name string, typeDecl string, mediaType *openapi3.MediaType) ([]ExampleTest, error) {
+	var tests []ExampleTest
+	if mediaType.Example != nil {
+		test, err := newExampleTest(name, typeDecl, mediaType.Example)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, test)
+	}
+
+	exampleNames := make([]string, 0, len(mediaType.Examples))
+	for exampleName := range mediaType.Examples {
+		exampleNames = append(exampleNames, exampleName)
+	}
+	sort.Strings(exampleNames)
+	for _, exampleName := range exampleNames {
+		exampleRef := mediaType.Examples[exampleName]
+		if exampleRef.Value == nil || exampleRef.Value.Value == nil {
+			continue
+		}
+		test, err := newExampleTest(name+"/"+exampleName, typeDecl, exampleRef.Value.Value)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+func newExampleTest(name string, typeDecl string, example interface{}) (ExampleTest, error) {
+	buf, err := json.Marshal(example)
+	if err != nil {
+		return ExampleTest{}, err
+	}
+	return ExampleTest{
+		Name:     name,
+		TypeDecl: typeDecl,
+		JSON:     string(buf),
+	}, nil
+}
+
+// This generates a table driven test, which checks that every example in the
+// spec survives a round trip through its generated type.
+func GenerateExampleTests(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition) (string, error) {
+	tests, err := DescribeExampleTests(swagger, ops)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err = t.ExecuteTemplate(w, "example-tests.tmpl", tests)
+	if err != nil {
+		return "", fmt.Errorf("error generating example tests: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for example tests: %s", err)
+	}
+	return buf.String(), nil
+}
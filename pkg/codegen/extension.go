@@ -0,0 +1,160 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// extPropGoTimeFormat overrides the layout used to marshal a date-time
+	// schema, as a Go time layout or one of the names in timeLayoutNames.
+	extPropGoTimeFormat = "x-go-time-format"
+
+	// extPropEncrypted marks a property whose value is encrypted in payloads.
+	// The types holding it encrypt it when they're marshaled, and decrypt it
+	// when they're unmarshaled, with a runtime.FieldCipher.
+	extPropEncrypted = "x-encrypted"
+
+	// These Swagger 2 extensions, which specs often keep after conversion to
+	// OpenAPI 3, set the optionality of properties. They're only interpreted
+	// with Options.Swagger2Extensions.
+	extPropNullable   = "x-nullable"   // go-swagger and most other toolchains
+	extPropIsNullable = "x-isnullable" // NSwag
+	extPropOmitEmpty  = "x-omitempty"  // go-swagger
+
+	// extOpGoName overrides the Go name of an operation, which is otherwise
+	// derived from its operationId. extGoName is accepted on operations too,
+	// as go-swagger uses it for the same purpose.
+	extOpGoName = "x-go-operation-name"
+	extGoName   = "x-go-name"
+
+	// extOpAudit describes an operation for audit logging, as an object with
+	// resource, action and sensitivity strings.
+	extOpAudit = "x-audit"
+	// extParamSensitive marks a parameter, or its schema, whose value is
+	// redacted from audit events.
+	extParamSensitive = "x-sensitive"
+
+	// extOpSLO declares the SLO of an operation, as an object with an
+	// availability percentage, and a latency duration within which
+	// latencyTarget percent of the requests have to be handled.
+	extOpSLO = "x-slo"
+
+	// extOpSunset is the date when a deprecated operation is going to be
+	// removed.
+	extOpSunset = "x-sunset"
+
+	// extOpProxy marks an operation whose requests and responses are passed
+	// through, such as a gateway endpoint. Server wrappers hand the request to
+	// the handler without binding its parameters, and clients return the
+	// response without reading it.
+	extOpProxy = "x-proxy"
+
+	// extOpGoImpl delegates an operation to a method of an existing business
+	// service, written as Service.Method, for which generated adapters
+	// convert the parameters and body, and map the results to responses.
+	extOpGoImpl = "x-go-impl"
+
+	// extOpAsyncJob marks the status operation of an asynchronous job, for
+	// which a watcher polling the operation until the job reaches a terminal
+	// status is generated. It's either true, or an object naming the status
+	// and result properties of the job, and its terminal statuses.
+	extOpAsyncJob = "x-async-job"
+
+	// extOpCompose declares that the response of an operation aggregates the
+	// responses of other operations, as an object mapping properties of the
+	// response to the operationId of the operation returning them, or to an
+	// object with the operationId and the parameters to call it with.
+	extOpCompose = "x-compose"
+
+	// extParamFilterGrammar marks a free-form query parameter holding a
+	// filter expression, as an object with the syntax of the expression, and
+	// the type of each field it can compare, for which a typed query builder
+	// is generated.
+	extParamFilterGrammar = "x-filter-grammar"
+)
+
+// extString returns the string value of the named extension, and whether it
+// was present at all.
+func extString(extensions map[string]interface{}, name string) (string, bool, error) {
+	raw, found := extensions[name]
+	if !found {
+		return "", false, nil
+	}
+	var value string
+	switch v := raw.(type) {
+	case json.RawMessage:
+		if err := json.Unmarshal(v, &value); err != nil {
+			return "", true, fmt.Errorf("failed to parse %s as a string: %s", name, err)
+		}
+	case string:
+		value = v
+	default:
+		return "", true, fmt.Errorf("%s must be a string, got %T", name, raw)
+	}
+	return value, true, nil
+}
+
+// extBool returns the boolean value of the named extension, and whether it
+// was present at all.
+func extBool(extensions map[string]interface{}, name string) (bool, bool, error) {
+	raw, found := extensions[name]
+	if !found {
+		return false, false, nil
+	}
+	var value bool
+	switch v := raw.(type) {
+	case json.RawMessage:
+		if err := json.Unmarshal(v, &value); err != nil {
+			return false, true, fmt.Errorf("failed to parse %s as a boolean: %s", name, err)
+		}
+	case bool:
+		value = v
+	default:
+		return false, true, fmt.Errorf("%s must be a boolean, got %T", name, raw)
+	}
+	return value, true, nil
+}
+
+// swagger2Optionality reads the optionality of a property from the Swagger 2
+// extensions of its schema. nullable counts as x-nullable: true, since that's
+// what converters turn x-nullable into.
+func swagger2Optionality(schema *openapi3.Schema) (nullable *bool, omitEmpty *bool, err error) {
+	for _, name := range []string{extPropNullable, extPropIsNullable} {
+		value, found, err := extBool(schema.Extensions, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if found {
+			nullable = &value
+			break
+		}
+	}
+	if nullable == nil && schema.Nullable {
+		value := true
+		nullable = &value
+	}
+	value, found, err := extBool(schema.Extensions, extPropOmitEmpty)
+	if err != nil {
+		return nil, nil, err
+	}
+	if found {
+		omitEmpty = &value
+	}
+	return nullable, omitEmpty, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExampleLock holds the hashes of the external examples of a spec, by URL, in
+// the same "sha256:" form as SpecHash, so that a change of their content
+// can't go unnoticed between two generations.
+type ExampleLock map[string]string
+
+// ParseExampleLock parses the content of a lock file, made of lines of a URL
+// and a hash separated by a space, like go.sum. Empty lines are ignored.
+func ParseExampleLock(data []byte) (ExampleLock, error) {
+	lock := ExampleLock{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "sha256:") {
+			return nil, fmt.Errorf("line %d isn't of the form \"<url> sha256:<hash>\"", line)
+		}
+		lock[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// Bytes returns the content of the lock file, sorted by URL.
+func (l ExampleLock) Bytes() []byte {
+	urls := make([]string, 0, len(l))
+	for u := range l {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+
+	var buf bytes.Buffer
+	for _, u := range urls {
+		fmt.Fprintf(&buf, "%s %s\n", u, l[u])
+	}
+	return buf.Bytes()
+}
+
+// ExampleFetcher returns the content at the externalValue URL of an example.
+type ExampleFetcher func(url string) ([]byte, error)
+
+// NewExampleFetcher returns a fetcher of http and https URLs, and of files,
+// given as file URLs or as paths relative to baseDir, usually the directory
+// of the spec.
+func NewExampleFetcher(baseDir string) ExampleFetcher {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return func(rawURL string) ([]byte, error) {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "http", "https":
+			rsp, err := client.Get(rawURL)
+			if err != nil {
+				return nil, err
+			}
+			defer rsp.Body.Close()
+			if rsp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected status %s", rsp.Status)
+			}
+			return ioutil.ReadAll(rsp.Body)
+		case "file":
+			return ioutil.ReadFile(filepath.FromSlash(u.Path))
+		case "":
+			path := filepath.FromSlash(u.Path)
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+			return ioutil.ReadFile(path)
+		default:
+			return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+		}
+	}
+}
+
+// EmbedExternalExamples fetches the examples of the spec which only have an
+// externalValue, and embeds their content as their value, so that the
+// generated example tests, fakes and embedded spec don't need network access.
+// Content which parses as JSON is embedded as such, other content as a
+// string.
+//
+// The content of every URL found in lock has to match its hash there. The
+// returned lock holds the hashes of all the external examples of the spec,
+// to be saved for the next generation.
+func EmbedExternalExamples(swagger *openapi3.Swagger, fetch ExampleFetcher, lock ExampleLock) (ExampleLock, error) {
+	embedder := &exampleEmbedder{
+		fetch:    fetch,
+		lock:     lock,
+		newLock:  ExampleLock{},
+		contents: map[string]interface{}{},
+	}
+
+	if err := embedder.examples("components", swagger.Components.Examples); err != nil {
+		return nil, err
+	}
+	for _, name := range SortedParameterKeys(swagger.Components.Parameters) {
+		if err := embedder.parameter("parameter "+name, swagger.Components.Parameters[name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range SortedHeaderKeys(swagger.Components.Headers) {
+		if err := embedder.header("header "+name, swagger.Components.Headers[name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range SortedRequestBodyKeys(swagger.Components.RequestBodies) {
+		if ref := swagger.Components.RequestBodies[name]; ref.Value != nil {
+			if err := embedder.content("request body "+name, ref.Value.Content); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, name := range SortedResponsesKeys(swagger.Components.Responses) {
+		if err := embedder.response("response "+name, swagger.Components.Responses[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range SortedPathsKeys(swagger.Paths) {
+		pathItem := swagger.Paths[path]
+		for _, param := range pathItem.Parameters {
+			if err := embedder.parameter("path "+path, param); err != nil {
+				return nil, err
+			}
+		}
+		for _, method := range SortedOperationsKeys(pathItem.Operations()) {
+			op := pathItem.Operations()[method]
+			where := fmt.Sprintf("%s %s", method, path)
+			for _, param := range op.Parameters {
+				if err := embedder.parameter(where, param); err != nil {
+					return nil, err
+				}
+			}
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				if err := embedder.content(where, op.RequestBody.Value.Content); err != nil {
+					return nil, err
+				}
+			}
+			for _, status := range SortedResponsesKeys(op.Responses) {
+				if err := embedder.response(where, op.Responses[status]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return embedder.newLock, nil
+}
+
+// exampleEmbedder fetches every URL once, however many examples refer to it.
+type exampleEmbedder struct {
+	fetch    ExampleFetcher
+	lock     ExampleLock
+	newLock  ExampleLock
+	contents map[string]interface{}
+}
+
+func (e *exampleEmbedder) parameter(where string, ref *openapi3.ParameterRef) error {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	if err := e.examples(where, ref.Value.Examples); err != nil {
+		return err
+	}
+	return e.content(where, ref.Value.Content)
+}
+
+func (e *exampleEmbedder) header(where string, ref *openapi3.HeaderRef) error {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	if err := e.examples(where, ref.Value.Examples); err != nil {
+		return err
+	}
+	return e.content(where, ref.Value.Content)
+}
+
+func (e *exampleEmbedder) response(where string, ref *openapi3.ResponseRef) error {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	for _, name := range SortedHeaderKeys(ref.Value.Headers) {
+		if err := e.header(where, ref.Value.Headers[name]); err != nil {
+			return err
+		}
+	}
+	return e.content(where, ref.Value.Content)
+}
+
+func (e *exampleEmbedder) content(where string, content openapi3.Content) error {
+	for _, mediaType := range SortedContentKeys(content) {
+		if mt := content[mediaType]; mt != nil {
+			if err := e.examples(where, mt.Examples); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exampleEmbedder) examples(where string, examples openapi3.Examples) error {
+	for _, name := range SortedExampleKeys(examples) {
+		ref := examples[name]
+		if ref == nil || ref.Value == nil || ref.Value.ExternalValue == "" || ref.Value.Value != nil {
+			continue
+		}
+		value, err := e.fetchValue(ref.Value.ExternalValue)
+		if err != nil {
+			return fmt.Errorf("error embedding example %s of %s: %s", name, where, err)
+		}
+		ref.Value.Value = value
+		ref.Value.ExternalValue = ""
+	}
+	return nil
+}
+
+func (e *exampleEmbedder) fetchValue(rawURL string) (interface{}, error) {
+	if value, found := e.contents[rawURL]; found {
+		return value, nil
+	}
+	data, err := e.fetch(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %s", rawURL, err)
+	}
+	sum := sha256.Sum256(data)
+	hash := "sha256:" + hex.EncodeToString(sum[:])
+	if locked, found := e.lock[rawURL]; found && locked != hash {
+		return nil, fmt.Errorf("content of %s has hash %s, but the lock has %s, remove it from the lock to accept the new content", rawURL, hash, locked)
+	}
+	e.newLock[rawURL] = hash
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		value = string(data)
+	}
+	e.contents[rawURL] = value
+	return value, nil
+}
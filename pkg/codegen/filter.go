@@ -0,0 +1,150 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// FilterDefinition describes a query parameter holding a filter expression,
+// per x-filter-grammar, for which a typed query builder is generated.
+type FilterDefinition struct {
+	Name       string        // The prefix of the generated identifiers, eg, FindPetsFilter for FindPetsFilterFields
+	ParamsType string        // The parameters object of the operation
+	ParamName  string        // The name of the parameter in the spec
+	FieldName  string        // The field of the parameters object holding the parameter
+	TypeDecl   string        // The Go type of the parameter
+	Pointer    bool          // Whether the field of the parameter is a pointer
+	Syntax     string        // The grammar of the expression, one of the keys of filterGrammars
+	Fields     []FilterField // The fields which expressions compare, sorted by name
+}
+
+// Grammar returns the runtime.FilterGrammar formatting the expressions.
+func (f FilterDefinition) Grammar() string {
+	return filterGrammars[f.Syntax]
+}
+
+// FilterField is a field which the expressions of a filter parameter compare.
+type FilterField struct {
+	Name   string // The name of the field in the expressions
+	GoName string // The name of the field in the generated XxxFields variable
+	Type   string // The runtime type of the field, eg, runtime.StringFilterField
+}
+
+// filterGrammars are the runtime.FilterGrammars of the supported syntaxes.
+var filterGrammars = map[string]string{
+	"odata": "runtime.ODataFilterGrammar",
+	"rsql":  "runtime.RSQLFilterGrammar",
+}
+
+// filterFieldTypes are the runtime types of the fields of filters, by type.
+var filterFieldTypes = map[string]string{
+	"string":    "runtime.StringFilterField",
+	"integer":   "runtime.IntFilterField",
+	"number":    "runtime.NumberFilterField",
+	"boolean":   "runtime.BoolFilterField",
+	"date-time": "runtime.TimeFilterField",
+}
+
+// describeFilters reads the x-filter-grammar extension of the query
+// parameters of an operation, an object with the syntax of the expressions,
+// and their fields, mapped to one of the types of filterFieldTypes.
+func describeFilters(op *OperationDefinition) ([]FilterDefinition, error) {
+	var filters []FilterDefinition
+	for _, param := range op.AllParams() {
+		raw, found := param.Spec.Extensions[extParamFilterGrammar]
+		if !found {
+			continue
+		}
+		if param.In != "query" {
+			return nil, fmt.Errorf("%s can only be used on query parameters, not on %s parameter %s", extParamFilterGrammar, param.In, param.ParamName)
+		}
+		if param.Spec.Schema == nil || param.Spec.Schema.Value == nil || param.Spec.Schema.Value.Type != "string" {
+			return nil, fmt.Errorf("parameter %s with %s must be a string", param.ParamName, extParamFilterGrammar)
+		}
+		rawJSON, ok := raw.(json.RawMessage)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an object, got %T", extParamFilterGrammar, raw)
+		}
+		var ext struct {
+			Syntax string            `json:"syntax"`
+			Fields map[string]string `json:"fields"`
+		}
+		if err := json.Unmarshal(rawJSON, &ext); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of parameter %s: %s", extParamFilterGrammar, param.ParamName, err)
+		}
+		if _, found := filterGrammars[ext.Syntax]; !found {
+			return nil, fmt.Errorf("unknown syntax %q in %s of parameter %s, expected one of %s",
+				ext.Syntax, extParamFilterGrammar, param.ParamName, strings.Join(SortedStringKeys(filterGrammars), ", "))
+		}
+		if len(ext.Fields) == 0 {
+			return nil, fmt.Errorf("%s of parameter %s has no fields", extParamFilterGrammar, param.ParamName)
+		}
+
+		filter := FilterDefinition{
+			Name:       op.OperationId + SchemaNameToTypeName(param.ParamName),
+			ParamsType: op.OperationId + "Params",
+			ParamName:  param.ParamName,
+			FieldName:  SchemaNameToTypeName(param.ParamName),
+			TypeDecl:   param.TypeDef(),
+			Pointer:    param.IndirectOptional(),
+			Syntax:     ext.Syntax,
+		}
+		goNames := map[string]string{}
+		for _, name := range SortedStringKeys(ext.Fields) {
+			fieldType, found := filterFieldTypes[ext.Fields[name]]
+			if !found {
+				return nil, fmt.Errorf("field %s in %s of parameter %s has unknown type %q, expected one of %s",
+					name, extParamFilterGrammar, param.ParamName, ext.Fields[name], strings.Join(SortedStringKeys(filterFieldTypes), ", "))
+			}
+			goName := SchemaNameToTypeName(name)
+			if other, found := goNames[goName]; found {
+				return nil, fmt.Errorf("fields %s and %s in %s of parameter %s have the same Go name, %s",
+					other, name, extParamFilterGrammar, param.ParamName, goName)
+			}
+			goNames[goName] = name
+			filter.Fields = append(filter.Fields, FilterField{Name: name, GoName: goName, Type: fieldType})
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// GenerateFilters generates the typed query builders of the filter parameters
+// of the operations.
+func GenerateFilters(t *template.Template, ops []OperationDefinition) (string, error) {
+	var filters []FilterDefinition
+	for _, op := range ops {
+		filters = append(filters, op.Filters...)
+	}
+	if len(filters) == 0 {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "filters.tmpl", filters)
+	if err != nil {
+		return "", fmt.Errorf("error generating filters: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for filters: %s", err)
+	}
+	return buf.String(), nil
+}
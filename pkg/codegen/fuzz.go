@@ -0,0 +1,314 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// FuzzTest describes the fuzz target of an operation, which sends requests
+// mutated from a seed which is valid according to the spec.
+type FuzzTest struct {
+	OperationId  string // The Go name of the operation
+	Method       string
+	Path         string
+	PathParams   []ParameterDefinition
+	HeaderParams []ParameterDefinition
+	HasQuery     bool
+	ContentType  string // The content type of the body, empty without a body
+
+	PathSeeds   []string // The seed values of PathParams
+	HeaderSeeds []string // The seed values of HeaderParams
+	QuerySeed   string   // The seed query, encoded
+	BodySeed    string   // The seed body
+}
+
+// Params returns the parameters of the fuzz function which follow the
+// validate and mutation ones.
+func (f FuzzTest) Params() string {
+	var params []string
+	for _, param := range f.PathParams {
+		params = append(params, param.GoVariableName()+" string")
+	}
+	if f.HasQuery {
+		params = append(params, "rawQuery string")
+	}
+	for _, param := range f.HeaderParams {
+		params = append(params, param.GoVariableName()+" string")
+	}
+	if f.ContentType != "" {
+		params = append(params, "rawBody []byte")
+	}
+	return strings.Join(params, ", ")
+}
+
+// Seed returns the values of Params in the seed corpus.
+func (f FuzzTest) Seed() string {
+	var seed []string
+	for _, value := range f.PathSeeds {
+		seed = append(seed, goStringLiteral(value))
+	}
+	if f.HasQuery {
+		seed = append(seed, goStringLiteral(f.QuerySeed))
+	}
+	for _, value := range f.HeaderSeeds {
+		seed = append(seed, goStringLiteral(value))
+	}
+	if f.ContentType != "" {
+		seed = append(seed, "[]byte("+goStringLiteral(f.BodySeed)+")")
+	}
+	return strings.Join(seed, ", ")
+}
+
+// DescribeFuzzTests describes the fuzz targets of the operations, seeding
+// them with the examples of the spec, or else with values made up from the
+// schemas. Cookie parameters aren't fuzzed.
+func DescribeFuzzTests(ops []OperationDefinition) ([]FuzzTest, error) {
+	var tests []FuzzTest
+	for _, op := range ops {
+		test := FuzzTest{
+			OperationId:  op.OperationId,
+			Method:       op.Method,
+			Path:         op.Path,
+			PathParams:   op.PathParams,
+			HeaderParams: op.HeaderParams,
+			HasQuery:     len(op.QueryParams) != 0,
+		}
+		for _, param := range op.PathParams {
+			value, err := sampleParamValue(param)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error making up path parameter %s of %s", param.ParamName, op.OperationId)
+			}
+			test.PathSeeds = append(test.PathSeeds, strings.Join(value, ","))
+		}
+		for _, param := range op.HeaderParams {
+			value, err := sampleParamValue(param)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error making up header %s of %s", param.ParamName, op.OperationId)
+			}
+			test.HeaderSeeds = append(test.HeaderSeeds, strings.Join(value, ","))
+		}
+		query := url.Values{}
+		for _, param := range op.QueryParams {
+			values, err := sampleParamValue(param)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error making up query parameter %s of %s", param.ParamName, op.OperationId)
+			}
+			if len(values) > 1 && !param.Explode() {
+				values = []string{strings.Join(values, ",")}
+			}
+			query[param.ParamName] = values
+		}
+		test.QuerySeed = query.Encode()
+
+		if op.HasBody() {
+			content := op.Spec.RequestBody.Value.Content
+			contentTypes := SortedContentKeys(content)
+			if len(op.Bodies) != 0 {
+				contentTypes = []string{op.Bodies[0].ContentType}
+			}
+			if len(contentTypes) != 0 {
+				test.ContentType = contentTypes[0]
+				if StringInArray(test.ContentType, contentTypesJSON) {
+					body, err := json.Marshal(sampleMediaTypeValue(content[test.ContentType]))
+					if err != nil {
+						return nil, errors.Wrapf(err, "error making up the body of %s", op.OperationId)
+					}
+					test.BodySeed = string(body)
+				}
+			}
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// sampleParamValue returns a valid value of a parameter, as strings, one per
+// element of arrays. Objects, which would need to be serialized according to
+// the style of the parameter, get no value.
+func sampleParamValue(param ParameterDefinition) ([]string, error) {
+	if param.Spec.Example != nil {
+		return sampleStrings(param.Spec.Example)
+	}
+	if param.Spec.Schema == nil {
+		mediaType := param.Spec.Content.Get("application/json")
+		if mediaType == nil {
+			return nil, nil
+		}
+		value, err := json.Marshal(sampleMediaTypeValue(mediaType))
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(value)}, nil
+	}
+	return sampleStrings(sampleValue(param.Spec.Schema, 0))
+}
+
+func sampleStrings(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil, map[string]interface{}:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		var values []string
+		for _, element := range v {
+			strs, err := sampleStrings(element)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, strs...)
+		}
+		return values, nil
+	}
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return []string{string(buf)}, nil
+}
+
+// sampleMediaTypeValue returns the example of a media type, or its first
+// named example, or else a value made up from its schema.
+func sampleMediaTypeValue(mediaType *openapi3.MediaType) interface{} {
+	if mediaType.Example != nil {
+		return mediaType.Example
+	}
+	names := make([]string, 0, len(mediaType.Examples))
+	for name, example := range mediaType.Examples {
+		if example.Value != nil && example.Value.Value != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) != 0 {
+		sort.Strings(names)
+		return mediaType.Examples[names[0]].Value.Value
+	}
+	return sampleValue(mediaType.Schema, 0)
+}
+
+// maxSampleDepth bounds the nesting of made up values, as schemas can be
+// recursive.
+const maxSampleDepth = 5
+
+// sampleValue makes up a value which is valid according to a schema, as far
+// as its example, default, enum, type, format and limits tell.
+func sampleValue(schemaRef *openapi3.SchemaRef, depth int) interface{} {
+	if schemaRef == nil || schemaRef.Value == nil || depth > maxSampleDepth {
+		return nil
+	}
+	schema := schemaRef.Value
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) != 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) != 0:
+		merged := map[string]interface{}{}
+		for _, ref := range schema.AllOf {
+			if object, ok := sampleValue(ref, depth+1).(map[string]interface{}); ok {
+				for name, value := range object {
+					merged[name] = value
+				}
+			}
+		}
+		return merged
+	case len(schema.OneOf) != 0:
+		return sampleValue(schema.OneOf[0], depth+1)
+	case len(schema.AnyOf) != 0:
+		return sampleValue(schema.AnyOf[0], depth+1)
+	}
+
+	switch schema.Type {
+	case "array":
+		if depth == maxSampleDepth {
+			return []interface{}{}
+		}
+		return []interface{}{sampleValue(schema.Items, depth+1)}
+	case "integer", "number":
+		value := 1.0
+		if schema.Min != nil && value < *schema.Min {
+			value = *schema.Min
+		}
+		if schema.Max != nil && value > *schema.Max {
+			value = *schema.Max
+		}
+		return value
+	case "boolean":
+		return true
+	case "string":
+		switch schema.Format {
+		case "date":
+			return "2020-01-01"
+		case "date-time":
+			return "2020-01-01T00:00:00Z"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		case "byte":
+			return "YQ=="
+		}
+		length := int(schema.MinLength)
+		if length == 0 {
+			length = 1
+		}
+		return strings.Repeat("a", length)
+	}
+
+	object := map[string]interface{}{}
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		if schema.Properties[name].Value != nil && schema.Properties[name].Value.ReadOnly {
+			continue
+		}
+		if value := sampleValue(schema.Properties[name], depth+1); value != nil {
+			object[name] = value
+		}
+	}
+	return object
+}
+
+// GenerateFuzzTests generates a fuzz target per operation, which sends
+// structurally mutated requests through the echo server of the package, with
+// and without request validation.
+func GenerateFuzzTests(t *template.Template, ops []OperationDefinition) (string, error) {
+	tests, err := DescribeFuzzTests(ops)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = t.ExecuteTemplate(w, "fuzz-tests.tmpl", tests)
+	if err != nil {
+		return "", fmt.Errorf("error generating fuzz tests: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for fuzz tests: %s", err)
+	}
+	return buf.String(), nil
+}
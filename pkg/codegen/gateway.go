@@ -0,0 +1,309 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// These are the valid values of Options.GatewayFormat.
+const (
+	// Kong declarative configuration, in YAML.
+	GatewayFormatKong = "kong"
+	// NGINX locations, to be included in a server block.
+	GatewayFormatNginx = "nginx"
+)
+
+// GatewayAuth is the credential which a gateway checks for before routing a
+// request.
+type GatewayAuth struct {
+	Scheme string // The name of the security scheme in the spec
+	Type   string // "api-key", "basic" or "bearer"
+	In     string // Where an API key is sent: "header", "query" or "cookie"
+	Name   string // The name of the header, query parameter or cookie holding an API key
+}
+
+// NginxVariable returns the name of the NGINX variable, without the "$", which
+// holds an API key.
+func (a GatewayAuth) NginxVariable() string {
+	name := strings.ToLower(strings.Replace(a.Name, "-", "_", -1))
+	switch a.In {
+	case "query":
+		return "arg_" + name
+	case "cookie":
+		return "cookie_" + name
+	default:
+		return "http_" + name
+	}
+}
+
+// GatewayRoute describes the route to a single operation.
+type GatewayRoute struct {
+	Name    string       // The operation ID
+	Method  string       // GET, POST, DELETE, etc.
+	Path    string       // The path template, including the base path of the server
+	Regex   string       // A regular expression matching Path, anchored at its end; gateways anchor it at the start
+	Literal int          // The number of literal path segments, which makes routes more specific
+	Auth    *GatewayAuth // The credential to check for, nil when the gateway can't check it
+	Schemes []string     // The security requirements, as written in the spec, when Auth can't express them
+}
+
+// GatewayLocation groups the routes of a path, for gateways which route on
+// paths only.
+type GatewayLocation struct {
+	Path    string
+	Regex   string
+	Methods []string
+	Auth    *GatewayAuth // Set when all the routes check for the same credential
+	Schemes []string     // The security requirements of routes which Auth doesn't cover
+}
+
+// GatewayConfig is the routing configuration of a gateway in front of the
+// service.
+type GatewayConfig struct {
+	Service   string // The name of the service
+	Upstream  string // The URL of the service, with scheme and host only
+	Routes    []GatewayRoute
+	Locations []GatewayLocation
+}
+
+var gatewayPathParamRe = regexp.MustCompile(`^\{[^}]+\}$`)
+
+// DescribeGateway derives the gateway routes of the operations from their
+// paths, the first server of the spec and the security requirements. upstream
+// overrides the scheme and host of the server when it's not empty.
+func DescribeGateway(swagger *openapi3.Swagger, ops []OperationDefinition, service, upstream string) (*GatewayConfig, error) {
+	var basePath string
+	if len(swagger.Servers) != 0 {
+		serverURL, err := url.Parse(serverURLWithDefaults(swagger.Servers[0]))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing server URL: %s", err)
+		}
+		basePath = strings.TrimSuffix(serverURL.Path, "/")
+		if upstream == "" && serverURL.IsAbs() {
+			upstream = serverURL.Scheme + "://" + serverURL.Host
+		}
+	}
+	if upstream == "" {
+		return nil, fmt.Errorf("the spec has no absolute server URL, so the upstream has to be given")
+	}
+	upstreamURL, err := url.Parse(upstream)
+	if err != nil || !upstreamURL.IsAbs() || upstreamURL.Host == "" {
+		return nil, fmt.Errorf("invalid upstream URL: %s", upstream)
+	}
+
+	config := &GatewayConfig{
+		Service:  service,
+		Upstream: upstreamURL.Scheme + "://" + upstreamURL.Host,
+	}
+	locations := make(map[string]*GatewayLocation)
+	for _, op := range ops {
+		security := swagger.Security
+		if op.Spec.Security != nil {
+			security = *op.Spec.Security
+		}
+		auth, schemes, err := describeGatewayAuth(swagger, security)
+		if err != nil {
+			return nil, fmt.Errorf("error describing the security of %s: %s", op.OperationId, err)
+		}
+
+		path := basePath + op.Path
+		route := GatewayRoute{
+			Name:    op.OperationId,
+			Method:  op.Method,
+			Path:    path,
+			Auth:    auth,
+			Schemes: schemes,
+		}
+		route.Regex, route.Literal = gatewayPathRegex(path)
+		config.Routes = append(config.Routes, route)
+
+		location, found := locations[path]
+		if !found {
+			location = &GatewayLocation{Path: path, Regex: route.Regex, Auth: auth}
+			locations[path] = location
+		}
+		location.Methods = append(location.Methods, route.Method)
+		if location.Auth != nil && (auth == nil || *auth != *location.Auth) {
+			location.Auth = nil
+		}
+		location.Schemes = appendMissing(location.Schemes, schemes...)
+		if auth != nil {
+			location.Schemes = appendMissing(location.Schemes, auth.Scheme)
+		}
+	}
+
+	for _, location := range locations {
+		if location.Auth != nil {
+			location.Schemes = nil
+		}
+		sort.Strings(location.Schemes)
+		config.Locations = append(config.Locations, *location)
+	}
+	// More specific paths go first, as gateways which match regular
+	// expressions in order pick the first match.
+	sort.SliceStable(config.Routes, func(i, j int) bool {
+		return gatewayPathLess(config.Routes[i].Path, config.Routes[j].Path)
+	})
+	sort.Slice(config.Locations, func(i, j int) bool {
+		return gatewayPathLess(config.Locations[i].Path, config.Locations[j].Path)
+	})
+	return config, nil
+}
+
+// describeGatewayAuth returns the credential which a gateway can check for
+// an operation. That's only possible when there's a single requirement, of a
+// single scheme; otherwise the names of the schemes are returned, unless the
+// operation can be called anonymously.
+func describeGatewayAuth(swagger *openapi3.Swagger, security openapi3.SecurityRequirements) (*GatewayAuth, []string, error) {
+	var schemes []string
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			// One of the alternatives is no authentication at all.
+			return nil, nil, nil
+		}
+		for name := range requirement {
+			schemes = appendMissing(schemes, name)
+		}
+	}
+	sort.Strings(schemes)
+	if len(security) != 1 || len(security[0]) != 1 {
+		return nil, schemes, nil
+	}
+
+	name := schemes[0]
+	schemeRef, found := swagger.Components.SecuritySchemes[name]
+	if !found || schemeRef.Value == nil {
+		return nil, nil, fmt.Errorf("unknown security scheme %s", name)
+	}
+	scheme := schemeRef.Value
+	auth := &GatewayAuth{Scheme: name}
+	switch {
+	case scheme.Type == "apiKey":
+		auth.Type = "api-key"
+		auth.In = scheme.In
+		auth.Name = scheme.Name
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		auth.Type = "basic"
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"),
+		scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+		auth.Type = "bearer"
+	default:
+		return nil, schemes, nil
+	}
+	return auth, nil, nil
+}
+
+// gatewayPathRegex turns a path template into a regular expression anchored at
+// its end, in which every parameter matches a single path segment. It also returns the
+// number of literal segments.
+func gatewayPathRegex(path string) (string, int) {
+	segments := strings.Split(path, "/")
+	literal := 0
+	for i, segment := range segments {
+		if gatewayPathParamRe.MatchString(segment) {
+			segments[i] = "[^/]+"
+		} else {
+			segments[i] = regexp.QuoteMeta(segment)
+			if segment != "" {
+				literal++
+			}
+		}
+	}
+	return strings.Join(segments, "/") + "$", literal
+}
+
+// gatewayPathLess orders paths segment by segment, with literal segments
+// before parameters.
+func gatewayPathLess(a, b string) bool {
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		aParam := gatewayPathParamRe.MatchString(as[i])
+		bParam := gatewayPathParamRe.MatchString(bs[i])
+		if aParam != bParam {
+			return bParam
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) > len(bs)
+}
+
+// serverURLWithDefaults substitutes the default values of the variables of
+// a server URL.
+func serverURLWithDefaults(server *openapi3.Server) string {
+	serverURL := server.URL
+	for name, variable := range server.Variables {
+		if variable == nil {
+			continue
+		}
+		if value, ok := variable.Default.(string); ok {
+			serverURL = strings.Replace(serverURL, "{"+name+"}", value, -1)
+		}
+	}
+	return serverURL
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		if !StringInArray(value, list) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// GenerateGatewayConfig generates the routing configuration of a gateway in
+// front of the service, in the format of Options.GatewayFormat.
+func GenerateGatewayConfig(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition, service string) (string, error) {
+	opts := globalState.options
+	config, err := DescribeGateway(swagger, ops, service, opts.GatewayUpstream)
+	if err != nil {
+		return "", err
+	}
+
+	var templateName string
+	switch opts.GatewayFormat {
+	case GatewayFormatKong, "":
+		templateName = "gateway-kong.tmpl"
+	case GatewayFormatNginx:
+		templateName = "gateway-nginx.tmpl"
+	default:
+		return "", fmt.Errorf("unknown gateway format: %s", opts.GatewayFormat)
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = t.ExecuteTemplate(w, templateName, config)
+	if err != nil {
+		return "", fmt.Errorf("error generating gateway config: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for gateway config: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ServerHost is a host which the servers of the spec are reached at, along
+// with the base paths of the API on it.
+type ServerHost struct {
+	Host      string   // The host, with the port if there is one
+	BasePaths []string // The base paths, without trailing slashes
+}
+
+// ServerHosts returns the hosts of the servers of the spec, in order, with
+// the default values of their variables. Servers with relative URLs have no
+// host, so they're left out.
+func ServerHosts(swagger *openapi3.Swagger) ([]ServerHost, error) {
+	var hosts []ServerHost
+	index := make(map[string]int)
+	for _, server := range swagger.Servers {
+		if server == nil {
+			continue
+		}
+		serverURL, err := url.Parse(serverURLWithDefaults(server))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing server URL %s: %s", server.URL, err)
+		}
+		if serverURL.Host == "" {
+			continue
+		}
+		basePath := strings.TrimSuffix(serverURL.Path, "/")
+		i, found := index[serverURL.Host]
+		if !found {
+			i = len(hosts)
+			index[serverURL.Host] = i
+			hosts = append(hosts, ServerHost{Host: serverURL.Host})
+		}
+		if !StringInArray(basePath, hosts[i].BasePaths) {
+			hosts[i].BasePaths = append(hosts[i].BasePaths, basePath)
+		}
+	}
+	return hosts, nil
+}
+
+// GenerateHostRegistration generates RegisterHandlersForHosts, which adds the
+// echo routes to the hosts of the servers. Nothing is generated when no
+// server has a host.
+func GenerateHostRegistration(t *template.Template, swagger *openapi3.Swagger) (string, error) {
+	hosts, err := ServerHosts(swagger)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = t.ExecuteTemplate(w, "register-hosts.tmpl", hosts)
+	if err != nil {
+		return "", fmt.Errorf("error generating host registration: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for host registration: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -18,12 +18,21 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"text/template"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// SpecShard is a part of the embedded spec. The shard of the empty tag holds
+// everything but the tagged operations.
+type SpecShard struct {
+	Tag   string
+	Parts []string
+}
+
 // This generates a gzipped, base64 encoded JSON representation of the
 // swagger definition, which we embed inside the generated code.
 func GenerateInlinedSpec(t *template.Template, swagger *openapi3.Swagger) (string, error) {
@@ -33,19 +42,49 @@ func GenerateInlinedSpec(t *template.Template, swagger *openapi3.Swagger) (strin
 		return "", fmt.Errorf("error marshaling swagger: %s", err)
 	}
 
+	context := struct {
+		Parts  []string
+		Shards []SpecShard
+	}{}
+	if globalState.options.ShardSpecByTag {
+		context.Shards, err = shardSpecByTag(encoded)
+	} else {
+		context.Parts, err = encodeSpec(encoded)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Generate inline code.
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = t.ExecuteTemplate(w, "inline.tmpl", context)
+	if err != nil {
+		return "", fmt.Errorf("error generating inlined spec: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for inlined spec: %s", err)
+	}
+	return buf.String(), nil
+}
+
+// encodeSpec gzips and base64 encodes a JSON document, and chops the result
+// into lines.
+func encodeSpec(encoded []byte) ([]string, error) {
 	// gzip
 	var buf bytes.Buffer
 	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
 	if err != nil {
-		return "", fmt.Errorf("error creating gzip compressor: %s", err)
+		return nil, fmt.Errorf("error creating gzip compressor: %s", err)
 	}
 	_, err = zw.Write(encoded)
 	if err != nil {
-		return "", fmt.Errorf("error gzipping swagger file: %s", err)
+		return nil, fmt.Errorf("error gzipping swagger file: %s", err)
 	}
 	err = zw.Close()
 	if err != nil {
-		return "", fmt.Errorf("error gzipping swagger file: %s", err)
+		return nil, fmt.Errorf("error gzipping swagger file: %s", err)
 	}
 	str := base64.StdEncoding.EncodeToString(buf.Bytes())
 
@@ -61,17 +100,86 @@ func GenerateInlinedSpec(t *template.Template, swagger *openapi3.Swagger) (strin
 	if len(str) > 0 {
 		parts = append(parts, str)
 	}
+	return parts, nil
+}
 
-	// Generate inline code.
-	buf.Reset()
-	w := bufio.NewWriter(&buf)
-	err = t.ExecuteTemplate(w, "inline.tmpl", parts)
+// shardSpecByTag splits a JSON spec into a shard holding everything but the
+// tagged operations, and one shard per tag holding the paths of the
+// operations whose first tag it is, as {"path": {"method": operation}}.
+func shardSpecByTag(encoded []byte) ([]SpecShard, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return nil, fmt.Errorf("error unmarshaling swagger: %s", err)
+	}
+
+	tagged := make(map[string]map[string]map[string]interface{})
+	paths, _ := doc["paths"].(map[string]interface{})
+	for path, item := range paths {
+		pathItem, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for method, op := range pathItem {
+			if !isOperationMethod(method) {
+				continue
+			}
+			opMap, ok := op.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			tags, _ := opMap["tags"].([]interface{})
+			if len(tags) == 0 {
+				continue
+			}
+			tag, ok := tags[0].(string)
+			if !ok {
+				continue
+			}
+			if tagged[tag] == nil {
+				tagged[tag] = make(map[string]map[string]interface{})
+			}
+			if tagged[tag][path] == nil {
+				tagged[tag][path] = make(map[string]interface{})
+			}
+			tagged[tag][path][method] = op
+			delete(pathItem, method)
+		}
+	}
+
+	base, err := json.Marshal(doc)
 	if err != nil {
-		return "", fmt.Errorf("error generating inlined spec: %s", err)
+		return nil, fmt.Errorf("error marshaling swagger: %s", err)
 	}
-	err = w.Flush()
+	parts, err := encodeSpec(base)
 	if err != nil {
-		return "", fmt.Errorf("error flushing output buffer for inlined spec: %s", err)
+		return nil, err
 	}
-	return buf.String(), nil
+	shards := []SpecShard{{Parts: parts}}
+
+	tags := make([]string, 0, len(tagged))
+	for tag := range tagged {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		shard, err := json.Marshal(tagged[tag])
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling operations tagged '%s': %s", tag, err)
+		}
+		parts, err := encodeSpec(shard)
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, SpecShard{Tag: tag, Parts: parts})
+	}
+	return shards, nil
+}
+
+// isOperationMethod tells whether a key of a JSON path item is an operation.
+func isOperationMethod(key string) bool {
+	switch key {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	}
+	return false
 }
@@ -17,6 +17,10 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
@@ -127,6 +131,145 @@ func (pd ParameterDefinition) IndirectOptional() bool {
 	return !pd.Required && !pd.Schema.SkipOptionalPointer
 }
 
+// These are the Go types of parameters which we can convert directly with
+// strconv, instead of binding them via reflection in the runtime.
+var simpleScalarTypes = map[string]bool{
+	"string":  true,
+	"int":     true,
+	"int32":   true,
+	"int64":   true,
+	"float32": true,
+	"float64": true,
+	"bool":    true,
+}
+
+// IsSimpleScalar returns true for styled parameters of simple style, which
+// are of a plain scalar type. These don't need any splitting, so the server
+// wrappers convert them directly.
+func (pd ParameterDefinition) IsSimpleScalar() bool {
+	return pd.IsStyled() && pd.Style() == "simple" && simpleScalarTypes[pd.TypeDef()]
+}
+
+// scalarLiteral returns the Go literal of a value of a scalar schema, as
+// decoded from the spec, and false when the schema isn't a simple scalar, or
+// the value doesn't match it.
+func scalarLiteral(schema *openapi3.Schema, value interface{}) (string, bool) {
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+	default:
+		return "", false
+	}
+	goSchema, err := GenerateGoSchema(openapi3.NewSchemaRef("", schema), nil)
+	if err != nil {
+		return "", false
+	}
+	switch goSchema.GoType {
+	case "string":
+		s, ok := value.(string)
+		return strconv.Quote(s), ok
+	case "int", "int32", "int64":
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) {
+			return "", false
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), true
+	case "float32", "float64":
+		f, ok := value.(float64)
+		return strconv.FormatFloat(f, 'g', -1, 64), ok
+	case "bool":
+		b, ok := value.(bool)
+		return strconv.FormatBool(b), ok
+	}
+	return "", false
+}
+
+// valueSchema returns the schema of the parameter, or of its items for arrays,
+// along with whether it's an array.
+func (pd ParameterDefinition) valueSchema() (*openapi3.Schema, bool) {
+	if pd.Spec == nil || pd.Spec.Schema == nil || pd.Spec.Schema.Value == nil {
+		return nil, false
+	}
+	schema := pd.Spec.Schema.Value
+	if schema.Type == "array" {
+		if schema.Items == nil || schema.Items.Value == nil {
+			return nil, true
+		}
+		return schema.Items.Value, true
+	}
+	return schema, false
+}
+
+// DefaultValue returns a Go expression for the default value of an optional
+// parameter, which generated servers use when it's absent. It's empty when
+// the parameter has no default, or when it isn't a scalar or an array of
+// scalars.
+func (pd ParameterDefinition) DefaultValue() string {
+	if !pd.IndirectOptional() || pd.Spec.Schema == nil || pd.Spec.Schema.Value == nil || pd.Spec.Schema.Value.Default == nil {
+		return ""
+	}
+	schema, isArray := pd.valueSchema()
+	if schema == nil {
+		return ""
+	}
+	value := pd.Spec.Schema.Value.Default
+	if !isArray {
+		literal, ok := scalarLiteral(schema, value)
+		if !ok {
+			return ""
+		}
+		return pd.TypeDef() + "(" + literal + ")"
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		return ""
+	}
+	literals := make([]string, len(values))
+	for i, v := range values {
+		if literals[i], ok = scalarLiteral(schema, v); !ok {
+			return ""
+		}
+	}
+	return pd.TypeDef() + "{" + strings.Join(literals, ", ") + "}"
+}
+
+// AllowedValues returns the enum of the parameter, or of its items for
+// arrays, formatted as runtime.NotAllowedValue formats values. It's empty
+// when there's no enum, or when the values aren't scalars.
+func (pd ParameterDefinition) AllowedValues() []string {
+	schema, _ := pd.valueSchema()
+	if schema == nil || len(schema.Enum) == 0 {
+		return nil
+	}
+	allowed := make([]string, len(schema.Enum))
+	for i, value := range schema.Enum {
+		literal, ok := scalarLiteral(schema, value)
+		if !ok {
+			return nil
+		}
+		if s, isString := value.(string); isString {
+			literal = s
+		}
+		allowed[i] = literal
+	}
+	return allowed
+}
+
+// AllowedValuesLiteral returns AllowedValues as a Go []string literal.
+func (pd ParameterDefinition) AllowedValuesLiteral() string {
+	allowed := pd.AllowedValues()
+	quoted := make([]string, len(allowed))
+	for i, value := range allowed {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// AllowedValuesList returns AllowedValues as a quoted, comma separated list,
+// for error messages.
+func (pd ParameterDefinition) AllowedValuesList() string {
+	return strconv.Quote(strings.Join(pd.AllowedValues(), ", "))
+}
+
 type ParameterDefinitions []ParameterDefinition
 
 func (p ParameterDefinitions) FindByName(name string) *ParameterDefinition {
@@ -185,17 +328,27 @@ func DescribeSecurityDefinition(securityRequirements openapi3.SecurityRequiremen
 	outDefs := make([]SecurityDefinition, 0)
 
 	for _, sr := range securityRequirements {
-		for k, v := range sr {
-			outDefs = append(outDefs, SecurityDefinition{ProviderName: k, Scopes: v})
+		for _, k := range sortedSecuritySchemes(sr) {
+			outDefs = append(outDefs, SecurityDefinition{ProviderName: k, Scopes: sr[k]})
 		}
 	}
 
 	return outDefs
 }
 
+func sortedSecuritySchemes(requirement openapi3.SecurityRequirement) []string {
+	schemes := make([]string, 0, len(requirement))
+	for scheme := range requirement {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
 // This structure describes an Operation
 type OperationDefinition struct {
-	OperationId string // The operation_id description from Swagger, used to generate function names
+	OperationId     string // The operation_id description from Swagger, used to generate function names
+	SpecOperationId string // The operation_id as written in the spec, or the default one when it's missing
 
 	PathParams          []ParameterDefinition // Parameters in the path, eg, /path/:param
 	HeaderParams        []ParameterDefinition // Parameters in HTTP headers
@@ -208,7 +361,17 @@ type OperationDefinition struct {
 	Summary             string                  // Summary string from Swagger, used to generate a comment
 	Method              string                  // GET, POST, DELETE, etc.
 	Path                string                  // The Swagger path for the operation, like /resource/{id}
+	IsProxy             bool                    // Whether requests and responses are passed through unparsed, per x-proxy
+	Impl                *ServiceMethod          // The method of a business service implementing the operation, per x-go-impl
+	ErrorResponses      []ErrorResponse         // The JSON error responses, which the client returns as typed errors
+	AsyncJob            *AsyncJobDefinition     // The job whose status the operation returns, per x-async-job
+	Composition         *CompositionDefinition  // The operations which the response aggregates, per x-compose
+	Filters             []FilterDefinition      // The query parameters holding filter expressions, per x-filter-grammar
 	Spec                *openapi3.Operation
+
+	// Security holds the alternative security requirements of the operation,
+	// or else those of the spec.
+	Security openapi3.SecurityRequirements
 }
 
 // Returns the list of all parameters except Path parameters. Path parameters
@@ -241,6 +404,38 @@ func (o *OperationDefinition) HasBody() bool {
 	return o.Spec.RequestBody != nil
 }
 
+// SecurityLiteral returns the security requirements of the operation as a
+// runtime.SecurityRequirements literal, or "" when it has none.
+func (o *OperationDefinition) SecurityLiteral() string {
+	if len(o.Security) == 0 {
+		return ""
+	}
+	requirements := make([]string, len(o.Security))
+	for i, requirement := range o.Security {
+		schemes := make([]string, 0, len(requirement))
+		for _, scheme := range sortedSecuritySchemes(requirement) {
+			scopes := make([]string, len(requirement[scheme]))
+			for j, scope := range requirement[scheme] {
+				scopes[j] = fmt.Sprintf("%q", scope)
+			}
+			schemes = append(schemes, fmt.Sprintf("%q: {%s}", scheme, strings.Join(scopes, ", ")))
+		}
+		requirements[i] = "{" + strings.Join(schemes, ", ") + "}"
+	}
+	return "runtime.SecurityRequirements{" + strings.Join(requirements, ", ") + "}"
+}
+
+// HasPartialContent tells whether the operation documents a 206 response, in
+// which case the client gets helpers to request byte ranges. Only operations
+// without a request body qualify, since ranges apply to retrievals.
+func (o *OperationDefinition) HasPartialContent() bool {
+	if o.HasBody() {
+		return false
+	}
+	_, found := o.Spec.Responses["206"]
+	return found
+}
+
 // This returns the Operations summary as a multi line comment
 func (o *OperationDefinition) SummaryAsComment() string {
 	if o.Summary == "" {
@@ -278,16 +473,23 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]TypeDefinition, er
 						return nil, errors.Wrap(err, fmt.Sprintf("Unable to determine Go type for %s.%s", o.OperationId, contentTypeName))
 					}
 
+					// Ranges of status codes are named in upper case, JSON2XX
+					// for example, however they're written in the spec.
+					typeSuffix := ToCamelCase(responseName)
+					if IsStatusCodeRange(responseName) {
+						typeSuffix = strings.ToUpper(responseName)
+					}
+
 					var typeName string
 					switch {
 					case StringInArray(contentTypeName, contentTypesJSON):
-						typeName = fmt.Sprintf("JSON%s", ToCamelCase(responseName))
+						typeName = fmt.Sprintf("JSON%s", typeSuffix)
 					// YAML:
 					case StringInArray(contentTypeName, contentTypesYAML):
-						typeName = fmt.Sprintf("YAML%s", ToCamelCase(responseName))
+						typeName = fmt.Sprintf("YAML%s", typeSuffix)
 					// XML:
 					case StringInArray(contentTypeName, contentTypesXML):
-						typeName = fmt.Sprintf("XML%s", ToCamelCase(responseName))
+						typeName = fmt.Sprintf("XML%s", typeSuffix)
 					default:
 						continue
 					}
@@ -312,6 +514,22 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]TypeDefinition, er
 	return tds, nil
 }
 
+// Returns the type definition of the first successful (2xx) JSON response of
+// this operation, or nil if the operation doesn't declare one. Declared status
+// codes come before the 2XX range.
+func (o *OperationDefinition) SuccessJSONResponse() (*TypeDefinition, error) {
+	tds, err := o.GetResponseTypeDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	for _, td := range tds {
+		if strings.HasPrefix(td.ResponseName, "2") && strings.HasPrefix(td.TypeName, "JSON") {
+			return &td, nil
+		}
+	}
+	return nil, nil
+}
+
 // This describes a request body
 type RequestBodyDefinition struct {
 	// Is this body required, or optional?
@@ -330,6 +548,27 @@ type RequestBodyDefinition struct {
 	// Whether this is the default body type. For an operation named OpFoo, we
 	// will not add suffixes like OpFooJSONBody for this one.
 	Default bool
+
+	// Whether the Go type of the body is an interface, as for oneOf schemas,
+	// in which case it can't have methods.
+	IsInterface bool
+
+	// Whether the body has properties marked with x-encrypted, in which case
+	// the JSON methods of its type, which encrypt them, have to be forwarded.
+	Encrypted bool
+
+	// Whether the type of the body tracks field presence, in which case its
+	// UnmarshalJSON method has to be forwarded.
+	TracksPresence bool
+
+	// The name of the method returning the hash of the body, Hash, or
+	// RequestHash when a field is already named Hash. It's empty when the body
+	// can't have one.
+	HashMethod string
+
+	// The Go type whose JSON methods are forwarded, which is the component
+	// type when the schema of the body is a reference.
+	ValueType string
 }
 
 // Returns the Go type definition for a request body
@@ -367,9 +606,34 @@ func FilterParameterDefinitionByType(params []ParameterDefinition, in string) []
 	return out
 }
 
+var goIdentifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// operationGoName returns the Go name of an operation, as set with the
+// x-go-operation-name or x-go-name extensions, or an empty string when
+// neither is set. Its first letter is uppercased, as the methods generated
+// for the operation have to be exported.
+func operationGoName(op *openapi3.Operation) (string, error) {
+	for _, name := range []string{extOpGoName, extGoName} {
+		goName, found, err := extString(op.Extensions, name)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			continue
+		}
+		if !goIdentifierRe.MatchString(goName) {
+			return "", fmt.Errorf("%s is not a valid Go identifier: %q", name, goName)
+		}
+		return UppercaseFirstCharacter(goName), nil
+	}
+	return "", nil
+}
+
 // OperationDefinitions returns all operations for a swagger definition.
 func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, error) {
 	var operations []OperationDefinition
+	// The operations by Go name, to report clashes.
+	goNames := make(map[string]string)
 
 	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
 		pathItem := swagger.Paths[requestPath]
@@ -385,6 +649,7 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 		pathOps := pathItem.Operations()
 		for _, opName := range SortedOperationsKeys(pathOps) {
 			op := pathOps[opName]
+			specOperationId := op.OperationID
 			// We rely on OperationID to generate function names, it's required
 			if op.OperationID == "" {
 				op.OperationID, err = generateDefaultOperationID(opName, requestPath)
@@ -393,13 +658,27 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 						opName, requestPath, err)
 				}
 				op.OperationID = op.OperationID
+				specOperationId = op.OperationID
 			} else {
 				op.OperationID = ToCamelCase(op.OperationID)
 			}
+			goName, err := operationGoName(op)
+			if err != nil {
+				return nil, fmt.Errorf("error reading the Go name of %s %s: %s", opName, requestPath, err)
+			}
+			operationId := ToCamelCase(op.OperationID)
+			if goName != "" {
+				operationId = goName
+			}
+			if other, found := goNames[operationId]; found {
+				return nil, fmt.Errorf("operations %s and %s %s have the same Go name %s, which can be changed with %s",
+					other, opName, requestPath, operationId, extOpGoName)
+			}
+			goNames[operationId] = opName + " " + requestPath
 
 			// These are parameters defined for the specific path method that
 			// we're iterating over.
-			localParams, err := DescribeParameters(op.Parameters, []string{op.OperationID + "Params"})
+			localParams, err := DescribeParameters(op.Parameters, []string{operationId + "Params"})
 			if err != nil {
 				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
 					opName, requestPath, err)
@@ -417,21 +696,28 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 				return nil, err
 			}
 
-			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(op.OperationID, op.RequestBody)
+			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(operationId, op.RequestBody)
 			if err != nil {
 				return nil, errors.Wrap(err, "error generating body definitions")
 			}
 
+			isProxy, _, err := extBool(op.Extensions, extOpProxy)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s %s: %s", opName, requestPath, err)
+			}
+
 			opDef := OperationDefinition{
-				PathParams:   pathParams,
-				HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
-				QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
-				CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
-				OperationId:  ToCamelCase(op.OperationID),
+				PathParams:      pathParams,
+				HeaderParams:    FilterParameterDefinitionByType(allParams, "header"),
+				QueryParams:     FilterParameterDefinitionByType(allParams, "query"),
+				CookieParams:    FilterParameterDefinitionByType(allParams, "cookie"),
+				OperationId:     operationId,
+				SpecOperationId: specOperationId,
 				// Replace newlines in summary.
 				Summary:         op.Summary,
 				Method:          opName,
 				Path:            requestPath,
+				IsProxy:         isProxy,
 				Spec:            op,
 				Bodies:          bodyDefinitions,
 				TypeDefinitions: typeDefinitions,
@@ -441,12 +727,14 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 			// See: "Step 2. Applying security:" from the spec:
 			// https://swagger.io/docs/specification/authentication/
 			if op.Security != nil {
+				opDef.Security = *op.Security
 				opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
 			} else {
 				// use global securityDefinitions
 				// globalSecurityDefinitions contains the top-level securityDefinitions.
 				// They are the default securityPermissions which are injected into each
 				// path, except for the case where a path explicitly overrides them.
+				opDef.Security = swagger.Security
 				opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
 
 			}
@@ -455,12 +743,40 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 				opDef.BodyRequired = op.RequestBody.Value.Required
 			}
 
+			opDef.Impl, err = describeServiceMethod(&opDef)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s %s: %s", opName, requestPath, err)
+			}
+
+			opDef.ErrorResponses, err = describeErrorResponses(&opDef)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s %s: %s", opName, requestPath, err)
+			}
+
+			opDef.AsyncJob, err = describeAsyncJob(&opDef)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s %s: %s", opName, requestPath, err)
+			}
+
+			opDef.Filters, err = describeFilters(&opDef)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s %s: %s", opName, requestPath, err)
+			}
+
 			// Generate all the type definitions needed for this operation
 			opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
 
 			operations = append(operations, opDef)
 		}
 	}
+	reserved := componentTypeNames(swagger)
+	nameErrorTypes(operations, reserved)
+	if err := checkAsyncJobs(operations, reserved); err != nil {
+		return nil, err
+	}
+	if err := describeCompositions(operations); err != nil {
+		return nil, err
+	}
 	return operations, nil
 }
 
@@ -523,6 +839,25 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 			bodySchema.RefType = refType
 		}
 
+		// References are followed, as the body type is defined in terms of
+		// the type it refers to.
+		valueSchema := bodySchema
+		if content.Schema != nil && content.Schema.Ref != "" {
+			valueSchema, err = GenerateGoSchema(&openapi3.SchemaRef{Value: content.Schema.Value}, []string{bodyTypeName})
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error generating request body definition")
+			}
+		}
+
+		// Bodies of component schemas tracking field presence are defined as
+		// aliases, so that they keep the methods of the schema type.
+		if globalState.options.FieldPresence && bodyOrRef.Ref == "" && content.Schema != nil &&
+			strings.HasPrefix(content.Schema.Ref, "#/components/schemas/") {
+			if err := trackPresence(&valueSchema, bodyTypeName); err != nil {
+				return nil, nil, err
+			}
+		}
+
 		// If the request has a body, but it's not a user defined
 		// type under #/components, we'll define a type for it, so
 		// that we have an easy to use type for marshaling.
@@ -531,23 +866,61 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 				TypeName: bodyTypeName,
 				Schema:   bodySchema,
 			}
+			td.Schema.DefineViaAlias = valueSchema.TracksPresence
 			typeDefinitions = append(typeDefinitions, td)
 			// The body schema now is a reference to a type
 			bodySchema.RefType = bodyTypeName
 		}
 
+		// The JSON methods of the body are those of the schema it refers to,
+		// as the types defined in terms of it don't have them.
+		valueType := bodySchema.TypeDecl()
+		if content.Schema != nil && content.Schema.Ref != "" {
+			valueType, err = RefPathToGoType(content.Schema.Ref)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, fmt.Sprintf("error turning reference (%s) into a Go type", content.Schema.Ref))
+			}
+		}
+
 		bd := RequestBodyDefinition{
 			Required:    body.Required,
 			Schema:      bodySchema,
 			NameTag:     tag,
 			ContentType: contentType,
 			Default:     defaultBody,
+			IsInterface: valueSchema.GoType == "interface{}",
+			Encrypted:   len(valueSchema.EncryptedProperties()) != 0,
+			ValueType:   valueType,
+			HashMethod:  hashMethod(valueSchema),
+
+			TracksPresence: valueSchema.TracksPresence,
 		}
 		bodyDefinitions = append(bodyDefinitions, bd)
 	}
 	return bodyDefinitions, typeDefinitions, nil
 }
 
+// hashMethod returns the name of the Hash method of a body type, which has to
+// be different from the names of its fields.
+func hashMethod(schema Schema) string {
+	if schema.GoType == "interface{}" {
+		return ""
+	}
+	fields := map[string]bool{}
+	for _, p := range schema.Properties {
+		fields[p.GoFieldName()] = true
+	}
+	for _, name := range schema.EmbeddedFields {
+		fields[name] = true
+	}
+	for _, name := range []string{"Hash", "RequestHash"} {
+		if !fields[name] {
+			return name
+		}
+	}
+	return ""
+}
+
 func GenerateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
 	var typeDefs []TypeDefinition
 	// Start with the params object itself
@@ -788,3 +1161,172 @@ func GenerateClientWithResponses(t *template.Template, ops []OperationDefinition
 	}
 	return buf.String(), nil
 }
+
+// This generates a fake implementation of ClientInterface, which returns
+// programmed responses and records its calls.
+func GenerateFakeClient(t *template.Template, ops []OperationDefinition) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err := t.ExecuteTemplate(w, "client-fake.tmpl", ops)
+
+	if err != nil {
+		return "", fmt.Errorf("error generating fake client: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for fake client: %s", err)
+	}
+	return buf.String(), nil
+}
+
+// TagDefinition groups the operations which share a tag, for generating a
+// sub-client per tag.
+type TagDefinition struct {
+	Tag        string                // The tag, as in the spec
+	GoName     string                // The name of the accessor and the prefix of the sub-client type
+	Operations []OperationDefinition // The operations with this tag, in the order of ops
+}
+
+// clientFields are the exported fields and methods which client.tmpl declares
+// on Client, besides those of the operations.
+var clientFields = []string{
+	"Server", "Client", "RequestEditors", "ResponseEditors", "SecurityProviders",
+	"Clone", "Warmup",
+}
+
+// clientPackageNames are the package-level identifiers ending in Client which
+// the client templates declare, and so which the sub-clients of tags can't
+// take. They're reserved whichever clients are generated, so that turning one
+// on doesn't break the build.
+var clientPackageNames = []string{
+	"Client", "NewClient", "FakeClient", "NewFakeClient", "NewInMemoryClient",
+	"WithHTTPClient",
+}
+
+// clientMembers returns the names of the exported fields and methods of the
+// generated Client, which the accessors of tags can't take.
+func clientMembers(ops []OperationDefinition) map[string]bool {
+	members := make(map[string]bool)
+	for _, name := range clientFields {
+		members[name] = true
+	}
+	for _, op := range ops {
+		members[op.OperationId] = true
+		if op.HasBody() {
+			members[op.OperationId+"WithBody"] = true
+		}
+		for _, body := range op.Bodies {
+			members[op.OperationId+body.Suffix()] = true
+		}
+		if op.HasPartialContent() {
+			members[op.OperationId+"Range"] = true
+		}
+	}
+	return members
+}
+
+// packageNames returns the package-level identifiers which the sub-clients of
+// tags can't take: those of clientPackageNames, the given type names and the
+// types of the operations.
+func packageNames(ops []OperationDefinition, typeNames map[string]bool) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range clientPackageNames {
+		names[name] = true
+	}
+	for name := range typeNames {
+		names[name] = true
+	}
+	for _, op := range ops {
+		for _, td := range op.TypeDefinitions {
+			names[td.TypeName] = true
+		}
+	}
+	return names
+}
+
+// DescribeTags groups operations by tag, in tag order. Operations with several
+// tags appear in each group, and untagged ones in none. The typeNames are those
+// of the types generated for the components, which the sub-clients can't take.
+func DescribeTags(ops []OperationDefinition, typeNames map[string]bool) ([]TagDefinition, error) {
+	reserved := clientMembers(ops)
+	declared := packageNames(ops, typeNames)
+
+	byTag := make(map[string]*TagDefinition)
+	byGoName := make(map[string]string)
+	var tags []string
+	for _, op := range ops {
+		for _, tag := range op.Spec.Tags {
+			td, found := byTag[tag]
+			if !found {
+				goName := SchemaNameToTypeName(tag)
+				if goName == "" {
+					return nil, fmt.Errorf("tag '%s' doesn't make a valid Go name", tag)
+				}
+				if reserved[goName] {
+					return nil, fmt.Errorf("the accessor for tag '%s' would clash with Client.%s", tag, goName)
+				}
+				for _, name := range []string{goName + "Client", "New" + goName + "Client"} {
+					if declared[name] {
+						return nil, fmt.Errorf("the sub-client of tag '%s' would clash with the generated %s", tag, name)
+					}
+				}
+				if other, found := byGoName[goName]; found {
+					return nil, fmt.Errorf("tags '%s' and '%s' both make the Go name %s", other, tag, goName)
+				}
+				byGoName[goName] = tag
+				td = &TagDefinition{Tag: tag, GoName: goName}
+				byTag[tag] = td
+				tags = append(tags, tag)
+			}
+			td.Operations = append(td.Operations, op)
+		}
+	}
+	sort.Strings(tags)
+
+	tds := make([]TagDefinition, 0, len(tags))
+	for _, tag := range tags {
+		tds = append(tds, *byTag[tag])
+	}
+	return tds, nil
+}
+
+// This generates a sub-client per tag, as facades over ClientInterface.
+func GenerateTagClients(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition) (string, error) {
+	tags, err := DescribeTags(ops, componentTypeNames(swagger))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err = t.ExecuteTemplate(w, "client-tags.tmpl", tags)
+
+	if err != nil {
+		return "", fmt.Errorf("error generating tag clients: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for tag clients: %s", err)
+	}
+	return buf.String(), nil
+}
+
+// This generates a client constructor which connects the generated client to
+// the generated server without a network in between.
+func GenerateInMemoryClient(t *template.Template, opts Options) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err := t.ExecuteTemplate(w, "client-in-memory.tmpl", opts)
+
+	if err != nil {
+		return "", fmt.Errorf("error generating in-memory client: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for in-memory client: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -15,7 +15,10 @@ package codegen
 
 import (
 	"net/http"
+	"strings"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func TestGenerateDefaultOperationID(t *testing.T) {
@@ -78,4 +81,121 @@ func TestGenerateDefaultOperationID(t *testing.T) {
 			t.Fatalf("Operation ID generation error. Want [%v] Got [%v]", test.want, got)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestDescribeTags(t *testing.T) {
+	op := func(id string, tags ...string) OperationDefinition {
+		return OperationDefinition{
+			OperationId: id,
+			Spec:        &openapi3.Operation{Tags: tags},
+		}
+	}
+
+	withBody := func(op OperationDefinition) OperationDefinition {
+		op.Spec.RequestBody = &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{}}
+		op.Bodies = []RequestBodyDefinition{{NameTag: "JSON", Default: true}}
+		return op
+	}
+
+	tags, err := DescribeTags([]OperationDefinition{
+		op("ListPets", "pets"),
+		op("GetStore"),
+		op("AddPet", "pets", "pet-admin"),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("got %d tags, want 2", len(tags))
+	}
+	if tags[0].GoName != "PetAdmin" || len(tags[0].Operations) != 1 {
+		t.Errorf("unexpected first tag %+v", tags[0])
+	}
+	if tags[1].GoName != "Pets" || len(tags[1].Operations) != 2 || tags[1].Operations[1].OperationId != "AddPet" {
+		t.Errorf("unexpected second tag %+v", tags[1])
+	}
+
+	for _, ops := range [][]OperationDefinition{
+		{op("Pets"), op("ListPets", "pets")},
+		{op("ListPets", "server")},
+		{op("ListPets", "pet-admin"), op("AddPet", "pet_admin")},
+		// Tags can't take the names of the fields and methods of Client.
+		{op("ListPets", "clone")},
+		{op("ListPets", "warmup")},
+		{op("ListPets", "response-editors")},
+		{op("ListPets", "security-providers")},
+		{withBody(op("AddPet")), op("ListPets", "add-pet-with-body")},
+		{withBody(op("AddPet")), op("ListPets", "add-pet")},
+		// Nor can their sub-clients take the package-level names of the
+		// generated code.
+		{op("ListPets", "fake")},
+		{op("ListPets", "in memory")},
+	} {
+		if _, err := DescribeTags(ops, nil); err == nil {
+			t.Errorf("expected an error for %+v", ops)
+		}
+	}
+
+	_, err = DescribeTags([]OperationDefinition{op("ListPets", "pets")}, map[string]bool{"PetsClient": true})
+	if err == nil || err.Error() != "the sub-client of tag 'pets' would clash with the generated PetsClient" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestOperationGoNames(t *testing.T) {
+	const spec = `
+openapi: "3.0.1"
+info:
+  version: 1.0.0
+  title: Go names
+paths:
+  /v2/accounts/{accountId}/transactions/{txnId}:
+    get:
+      operationId: getV2AccountsAccountIdTransactionsTxnId
+      x-go-operation-name: GetTransaction
+      parameters:
+        - {name: accountId, in: path, required: true, schema: {type: string}}
+        - {name: txnId, in: path, required: true, schema: {type: string}}
+      responses:
+        200: {description: ok}
+  /v2/accounts:
+    get:
+      operationId: getV2Accounts
+      x-go-name: listAccounts
+      responses:
+        200: {description: ok}
+    post:
+      operationId: postV2Accounts
+      responses:
+        200: {description: ok}
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops, err := OperationDefinitions(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, op := range ops {
+		names = append(names, op.OperationId+"="+op.SpecOperationId)
+	}
+	want := []string{"ListAccounts=getV2Accounts", "PostV2Accounts=postV2Accounts", "GetTransaction=getV2AccountsAccountIdTransactionsTxnId"}
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Errorf("got operations %v, want %v", names, want)
+	}
+
+	for _, broken := range []string{
+		strings.Replace(spec, "x-go-name: listAccounts", "x-go-name: list-accounts", 1),
+		strings.Replace(spec, "x-go-name: listAccounts", "x-go-name: PostV2Accounts", 1),
+	} {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(broken))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OperationDefinitions(swagger); err == nil {
+			t.Errorf("expected an error for %s", broken)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// presenceMethods are the methods of the types tracking field presence, which
+// properties can't be named after.
+var presenceMethods = []string{"IsSet", "MarkSet", "SetFields"}
+
+// trackPresence adds a presence set to the struct of a component schema, with
+// a bit per property, when the schema is an object with properties, or an
+// allOf embedding the types of other schemas, so that its type can get the
+// methods of GenerateFieldPresence. The embedded types track their own
+// properties.
+func trackPresence(schema *Schema, schemaName string) error {
+	if schema.IsRef() || schema.DefineViaAlias || !strings.HasPrefix(schema.GoType, "struct {") {
+		return nil
+	}
+	if len(schema.Properties) == 0 && len(schema.EmbeddedFields) == 0 {
+		return nil
+	}
+	for _, p := range schema.Properties {
+		if StringInArray(p.GoFieldName(), presenceMethods) {
+			return fmt.Errorf("property %s of %s has the name of a field presence method, %s", p.JsonFieldName, schemaName, p.GoFieldName())
+		}
+	}
+	end := strings.LastIndex(schema.GoType, "}")
+	schema.GoType = fmt.Sprintf("%s\n// presence has a bit per property, set by UnmarshalJSON and MarkSet.\npresence [%d]uint64\n%s",
+		schema.GoType[:end], schema.PresenceWords(), schema.GoType[end:])
+	schema.TracksPresence = true
+	return nil
+}
+
+// PresenceWords returns the number of words of the presence set of a type
+// tracking field presence.
+func (s Schema) PresenceWords() int {
+	return (len(s.Properties) + 63) / 64
+}
+
+// GenerateFieldPresence generates the methods reporting which properties of
+// the types tracking field presence are set, and their UnmarshalJSON methods,
+// except for the types with additional properties, which already have theirs.
+func GenerateFieldPresence(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var types []TypeDefinition
+	for _, td := range typeDefs {
+		if td.Schema.TracksPresence {
+			types = append(types, td)
+		}
+	}
+	if len(types) == 0 {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "presence.tmpl", types)
+	if err != nil {
+		return "", fmt.Errorf("error generating field presence: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for field presence: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const modulePath = "github.com/shawnhankim/oapi-codegen"
+
+// Version is the version of oapi-codegen recorded by the provenance target.
+// When it's empty, the version of the module is read from the build info of
+// the running binary. It can be set at link time, with
+// -ldflags "-X github.com/shawnhankim/oapi-codegen/pkg/codegen.Version=v1.2.3".
+var Version string
+
+// GeneratorVersion returns the version of oapi-codegen, "(devel)" when it's
+// built from a checkout of its own module, or "unknown" when there is no
+// build info.
+func GeneratorVersion() string {
+	if Version != "" {
+		return Version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+// SpecHash returns the SHA-256 digest of the JSON encoding of the spec, as
+// "sha256:" followed by the hex encoded digest. The JSON encoding doesn't
+// depend on the formatting of the spec file, or on whether it's written in
+// YAML, so the hash only changes along with the contract.
+func SpecHash(swagger *openapi3.Swagger) (string, error) {
+	encoded, err := swagger.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("error marshaling swagger: %s", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// OptionsArgs describes the options in the form of oapi-codegen arguments,
+// leaving out those which have their default values.
+func OptionsArgs(opts Options) string {
+	var targets []string
+	for _, target := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"types", opts.GenerateTypes},
+		{"client", opts.GenerateClient},
+		{"tag-clients", opts.GenerateTagClients},
+		{"fake-client", opts.GenerateFakeClient},
+		{"in-memory-client", opts.GenerateInMemory},
+		{"example-tests", opts.GenerateExamples},
+		{"fuzz-tests", opts.GenerateFuzzTests},
+		{"server", opts.GenerateEchoServer},
+		{"chi-server", opts.GenerateChiServer},
+		{"spec", opts.EmbedSpec},
+		{"provenance", opts.GenerateProvenance},
+		{"manifest", opts.GenerateManifest},
+		{"gateway-config", opts.GenerateGateway},
+		{"schema-export", opts.GenerateSchemaInfo},
+		{"audit", opts.GenerateAudit},
+		{"slo", opts.GenerateSLO},
+		{"deprecation", opts.GenerateDeprecation},
+		{"skip-fmt", opts.SkipFmt},
+	} {
+		if target.enabled {
+			targets = append(targets, target.name)
+		}
+	}
+	args := []string{"-generate=" + strings.Join(targets, ",")}
+	if len(opts.IncludeTags) != 0 {
+		args = append(args, "-include-tags="+strings.Join(opts.IncludeTags, ","))
+	}
+	if len(opts.ExcludeTags) != 0 {
+		args = append(args, "-exclude-tags="+strings.Join(opts.ExcludeTags, ","))
+	}
+	if opts.ResponseContentTypeMatching != "" && opts.ResponseContentTypeMatching != ContentTypeMatchingLenient {
+		args = append(args, "-response-content-type-matching="+opts.ResponseContentTypeMatching)
+	}
+	if opts.UnexpectedContentTypeErrors {
+		args = append(args, "-unexpected-content-type-errors")
+	}
+	if opts.TypedErrors {
+		args = append(args, "-typed-errors")
+	}
+	if opts.ShardSpecByTag {
+		args = append(args, "-shard-spec-by-tag")
+	}
+	if opts.DateTimeUTC {
+		args = append(args, "-date-time-utc")
+	}
+	if opts.DateTimeLayout != "" {
+		args = append(args, "-date-time-layout="+opts.DateTimeLayout)
+	}
+	if opts.Swagger2Extensions {
+		args = append(args, "-swagger2-extensions")
+	}
+	if opts.FieldPresence {
+		args = append(args, "-field-presence")
+	}
+	if opts.GatewayFormat != "" && opts.GatewayFormat != GatewayFormatKong {
+		args = append(args, "-gateway-format="+opts.GatewayFormat)
+	}
+	if opts.GatewayUpstream != "" {
+		args = append(args, "-gateway-upstream="+opts.GatewayUpstream)
+	}
+	if opts.RuntimePackage != "" && opts.RuntimePackage != DefaultRuntimePackage {
+		args = append(args, "-runtime-package="+opts.RuntimePackage)
+	}
+	return strings.Join(args, " ")
+}
+
+// commandLine joins the arguments of oapi-codegen into a command, quoting
+// those which the shell would split or interpret.
+func commandLine(args []string) string {
+	quoted := []string{"oapi-codegen"}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`*?;&|<>()") {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// GenerateProvenance generates constants which record the version of
+// oapi-codegen, the options and the spec which the code was generated from.
+func GenerateProvenance(t *template.Template, swagger *openapi3.Swagger, specHash string) (string, error) {
+	context := struct {
+		GeneratorVersion string
+		GeneratorOptions string
+		SpecVersion      string
+		SpecHash         string
+	}{
+		GeneratorVersion: GeneratorVersion(),
+		GeneratorOptions: OptionsArgs(globalState.options),
+		SpecHash:         specHash,
+	}
+	if swagger.Info != nil {
+		context.SpecVersion = swagger.Info.Version
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "provenance.tmpl", context)
+	if err != nil {
+		return "", fmt.Errorf("error generating provenance: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for provenance: %s", err)
+	}
+	return buf.String(), nil
+}
+
+// GenerateManifest generates OperationsManifest, which describes the
+// operations, and a handler serving it.
+func GenerateManifest(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition, specHash string) (string, error) {
+	context := struct {
+		SpecVersion string
+		SpecHash    string
+		Operations  []OperationDefinition
+	}{
+		SpecHash:   specHash,
+		Operations: ops,
+	}
+	if swagger.Info != nil {
+		context.SpecVersion = swagger.Info.Version
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "manifest.tmpl", context)
+	if err != nil {
+		return "", fmt.Errorf("error generating manifest: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for manifest: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -17,8 +17,11 @@ type Schema struct {
 	HasAdditionalProperties  bool             // Whether we support additional properties
 	AdditionalPropertiesType *Schema          // And if we do, their type
 	AdditionalTypes          []TypeDefinition // We may need to generate auxiliary helper types, stored here
+	EmbeddedFields           []string         // For an allOf, the fields embedding the types of its referenced schemas
 
 	SkipOptionalPointer bool // Some types don't need a * in front when they're optional
+	DefineViaAlias      bool // Define a named type as an alias, so that it keeps the methods of GoType
+	TracksPresence      bool // Whether the type records which properties were unmarshaled, with Options.FieldPresence
 }
 
 func (s Schema) IsRef() bool {
@@ -43,6 +46,28 @@ func (s *Schema) MergeProperty(p Property) error {
 	return nil
 }
 
+// EncryptedProperties returns the properties marked with x-encrypted.
+func (s Schema) EncryptedProperties() []Property {
+	var encrypted []Property
+	for _, p := range s.Properties {
+		if p.Encrypted {
+			encrypted = append(encrypted, p)
+		}
+	}
+	return encrypted
+}
+
+// RawMessageField declares a json.RawMessage field standing for the property
+// in a struct shadowing its type, with omitempty when the property has it and
+// omitEmpty is set.
+func (p Property) RawMessageField(omitEmpty bool) string {
+	tag := p.JsonFieldName
+	if omitEmpty && p.HasOmitEmpty() {
+		tag += ",omitempty"
+	}
+	return fmt.Sprintf("%s json.RawMessage `json:\"%s\"`", p.GoFieldName(), tag)
+}
+
 func (s Schema) GetAdditionalTypeDefs() []TypeDefinition {
 	var result []TypeDefinition
 	for _, p := range s.Properties {
@@ -57,6 +82,16 @@ type Property struct {
 	JsonFieldName string
 	Schema        Schema
 	Required      bool
+
+	// Nullable and OmitEmpty override whether the field is a pointer, and
+	// whether its JSON tag has omitempty, which otherwise follow Required.
+	// They're set from Swagger 2 extensions, with Options.Swagger2Extensions.
+	Nullable  *bool
+	OmitEmpty *bool
+
+	// Encrypted is set from the x-encrypted extension, for values which are
+	// encrypted in payloads.
+	Encrypted bool
 }
 
 func (p Property) GoFieldName() string {
@@ -65,12 +100,32 @@ func (p Property) GoFieldName() string {
 
 func (p Property) GoTypeDef() string {
 	typeDef := p.Schema.TypeDecl()
-	if !p.Schema.SkipOptionalPointer && !p.Required {
+	if p.IsPointer() {
 		typeDef = "*" + typeDef
 	}
 	return typeDef
 }
 
+// IsPointer returns whether the field of the property is a pointer, which is
+// the case for optional and nullable properties.
+func (p Property) IsPointer() bool {
+	if p.Schema.SkipOptionalPointer {
+		return false
+	}
+	if p.Nullable != nil {
+		return *p.Nullable
+	}
+	return !p.Required
+}
+
+// HasOmitEmpty returns whether the JSON tag of the field has omitempty.
+func (p Property) HasOmitEmpty() bool {
+	if p.OmitEmpty != nil {
+		return *p.OmitEmpty
+	}
+	return !p.Required
+}
+
 type TypeDefinition struct {
 	TypeName     string
 	JsonName     string
@@ -79,7 +134,8 @@ type TypeDefinition struct {
 }
 
 func PropertiesEqual(a, b Property) bool {
-	return a.JsonFieldName == b.JsonFieldName && a.Schema.TypeDecl() == b.Schema.TypeDecl() && a.Required == b.Required
+	return a.JsonFieldName == b.JsonFieldName && a.Schema.TypeDecl() == b.Schema.TypeDecl() && a.Required == b.Required &&
+		a.GoTypeDef() == b.GoTypeDef() && a.HasOmitEmpty() == b.HasOmitEmpty() && a.Encrypted == b.Encrypted
 }
 
 func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
@@ -192,6 +248,18 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 					Required:      required,
 					Description:   description,
 				}
+				if globalState.options.Swagger2Extensions && p.Value != nil {
+					prop.Nullable, prop.OmitEmpty, err = swagger2Optionality(p.Value)
+					if err != nil {
+						return Schema{}, errors.Wrap(err, fmt.Sprintf("error reading the extensions of property '%s'", pName))
+					}
+				}
+				if p.Value != nil {
+					prop.Encrypted, _, err = extBool(p.Value.Extensions, extPropEncrypted)
+					if err != nil {
+						return Schema{}, errors.Wrap(err, fmt.Sprintf("error reading the extensions of property '%s'", pName))
+					}
+				}
 				outSchema.Properties = append(outSchema.Properties, prop)
 			}
 
@@ -255,7 +323,12 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			case "date":
 				outSchema.GoType = "openapi_types.Date"
 			case "date-time":
-				outSchema.GoType = "time.Time"
+				goType, err := dateTimeGoType(schema, path)
+				if err != nil {
+					return Schema{}, errors.Wrap(err, "error generating date-time type")
+				}
+				outSchema.GoType = goType
+				outSchema.DefineViaAlias = goType != "time.Time"
 			case "json":
 				outSchema.GoType = "json.RawMessage"
 				outSchema.SkipOptionalPointer = true
@@ -298,10 +371,10 @@ func GenFieldsFromProperties(props []Property) []string {
 			field += fmt.Sprintf("\n%s\n", StringToGoComment(p.Description))
 		}
 		field += fmt.Sprintf("    %s %s", p.GoFieldName(), p.GoTypeDef())
-		if p.Required {
-			field += fmt.Sprintf(" `json:\"%s\"`", p.JsonFieldName)
-		} else {
+		if p.HasOmitEmpty() {
 			field += fmt.Sprintf(" `json:\"%s,omitempty\"`", p.JsonFieldName)
+		} else {
+			field += fmt.Sprintf(" `json:\"%s\"`", p.JsonFieldName)
 		}
 		fields = append(fields, field)
 	}
@@ -347,6 +420,9 @@ func MergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 			return Schema{}, errors.Wrap(err, "error generating Go schema in allOf")
 		}
 		schema.RefType = refType
+		if refType != "" {
+			outSchema.EmbeddedFields = append(outSchema.EmbeddedFields, refType[strings.LastIndex(refType, ".")+1:])
+		}
 
 		for _, p := range schema.Properties {
 			err = outSchema.MergeProperty(p)
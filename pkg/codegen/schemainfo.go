@@ -0,0 +1,259 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GenerateSchemaInfo generates SchemaInfo, a schemainfo.Registry describing
+// the component schemas of the spec.
+func GenerateSchemaInfo(t *template.Template, swagger *openapi3.Swagger) (string, error) {
+	var schemas []string
+	for _, name := range SortedSchemaKeys(swagger.Components.Schemas) {
+		var buf strings.Builder
+		err := writeSchemaInfo(&buf, swagger.Components.Schemas[name], name)
+		if err != nil {
+			return "", fmt.Errorf("error describing schema %s: %s", name, err)
+		}
+		schemas = append(schemas, buf.String())
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "schema-info.tmpl", schemas)
+	if err != nil {
+		return "", fmt.Errorf("error generating schema info: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for schema info: %s", err)
+	}
+	return buf.String(), nil
+}
+
+// writeSchemaInfo writes a schemainfo.Schema literal describing the schema.
+// name is only set for component schemas.
+func writeSchemaInfo(buf *strings.Builder, ref *openapi3.SchemaRef, name string) error {
+	buf.WriteString("&schemainfo.Schema{")
+	if name != "" {
+		fmt.Fprintf(buf, "Name: %q, GoType: %q,", name, SchemaNameToTypeName(name))
+	}
+	if ref.Ref != "" && name == "" {
+		// References to other component schemas are resolved at runtime.
+		goType, err := RefPathToGoType(ref.Ref)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "Ref: %q, GoType: %q}", ref.Ref[strings.LastIndex(ref.Ref, "/")+1:], goType)
+		return nil
+	}
+	schema := ref.Value
+	if schema == nil {
+		return fmt.Errorf("unresolved reference %s", ref.Ref)
+	}
+
+	writeString := func(field, value string) {
+		if value != "" {
+			fmt.Fprintf(buf, "%s: %q,", field, value)
+		}
+	}
+	writeBool := func(field string, value bool) {
+		if value {
+			fmt.Fprintf(buf, "%s: true,", field)
+		}
+	}
+
+	schemaType := schema.Type
+	if schemaType == "" && (len(schema.Properties) != 0 || len(schema.AllOf) != 0) {
+		schemaType = "object"
+	}
+	writeString("Type", schemaType)
+	writeString("Format", schema.Format)
+	writeString("Description", schema.Description)
+	writeBool("Nullable", schema.Nullable)
+	writeBool("ReadOnly", schema.ReadOnly)
+	writeBool("WriteOnly", schema.WriteOnly)
+	writeBool("Deprecated", schema.Deprecated)
+
+	validation, err := schemaInfoValidation(schema)
+	if err != nil {
+		return err
+	}
+	if validation != "" {
+		fmt.Fprintf(buf, "Validation: schemainfo.Validation{%s},", validation)
+	}
+
+	properties, required, err := schemaInfoProperties(schema)
+	if err != nil {
+		return err
+	}
+	if len(properties) != 0 {
+		buf.WriteString("Fields: []schemainfo.Field{\n")
+		for _, propName := range SortedSchemaKeys(properties) {
+			fmt.Fprintf(buf, "{Name: %q, GoName: %q,", propName, SchemaNameToTypeName(propName))
+			writeBool("Required", required[propName])
+			buf.WriteString("Schema: ")
+			if err := writeSchemaInfo(buf, properties[propName], ""); err != nil {
+				return fmt.Errorf("error describing property %s: %s", propName, err)
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},")
+	}
+	if schema.Items != nil {
+		buf.WriteString("Items: ")
+		if err := writeSchemaInfo(buf, schema.Items, ""); err != nil {
+			return err
+		}
+		buf.WriteString(",")
+	}
+	if schema.AdditionalProperties != nil {
+		buf.WriteString("AdditionalProperties: ")
+		if err := writeSchemaInfo(buf, schema.AdditionalProperties, ""); err != nil {
+			return err
+		}
+		buf.WriteString(",")
+	} else if schema.AdditionalPropertiesAllowed != nil && *schema.AdditionalPropertiesAllowed {
+		buf.WriteString("AdditionalProperties: &schemainfo.Schema{},")
+	}
+	for _, alternatives := range []struct {
+		field   string
+		schemas openapi3.SchemaRefs
+	}{{"OneOf", schema.OneOf}, {"AnyOf", schema.AnyOf}} {
+		if len(alternatives.schemas) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "%s: []*schemainfo.Schema{\n", alternatives.field)
+		for _, alternative := range alternatives.schemas {
+			if err := writeSchemaInfo(buf, alternative, ""); err != nil {
+				return err
+			}
+			buf.WriteString(",\n")
+		}
+		buf.WriteString("},")
+	}
+	buf.WriteString("}")
+	return nil
+}
+
+// schemaInfoProperties collects the properties of an object schema, along
+// with those of its allOf schemas.
+func schemaInfoProperties(schema *openapi3.Schema) (map[string]*openapi3.SchemaRef, map[string]bool, error) {
+	properties := make(map[string]*openapi3.SchemaRef)
+	required := make(map[string]bool)
+	for _, part := range schema.AllOf {
+		if part.Value == nil {
+			return nil, nil, fmt.Errorf("unresolved reference %s", part.Ref)
+		}
+		partProperties, partRequired, err := schemaInfoProperties(part.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		for propName, prop := range partProperties {
+			properties[propName] = prop
+		}
+		for propName := range partRequired {
+			required[propName] = true
+		}
+	}
+	for propName, prop := range schema.Properties {
+		properties[propName] = prop
+	}
+	for _, propName := range schema.Required {
+		required[propName] = true
+	}
+	return properties, required, nil
+}
+
+// schemaInfoValidation returns the fields of a schemainfo.Validation literal
+// for the validation rules of the schema.
+func schemaInfoValidation(schema *openapi3.Schema) (string, error) {
+	var fields []string
+	if len(schema.Enum) != 0 {
+		var values []string
+		for _, value := range schema.Enum {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return "", fmt.Errorf("error marshaling enum value: %s", err)
+			}
+			values = append(values, strconv.Quote(string(encoded)))
+		}
+		fields = append(fields, fmt.Sprintf("Enum: []string{%s}", strings.Join(values, ", ")))
+	}
+	if schema.Default != nil {
+		encoded, err := json.Marshal(schema.Default)
+		if err != nil {
+			return "", fmt.Errorf("error marshaling default value: %s", err)
+		}
+		fields = append(fields, fmt.Sprintf("Default: %q", encoded))
+	}
+	if schema.Pattern != "" {
+		fields = append(fields, fmt.Sprintf("Pattern: %q", schema.Pattern))
+	}
+
+	uints := map[string]uint64{
+		"MinLength":     schema.MinLength,
+		"MinItems":      schema.MinItems,
+		"MinProperties": schema.MinProps,
+	}
+	uintPointers := map[string]*uint64{
+		"MaxLength":     schema.MaxLength,
+		"MaxItems":      schema.MaxItems,
+		"MaxProperties": schema.MaxProps,
+	}
+	floatPointers := map[string]*float64{
+		"Minimum":    schema.Min,
+		"Maximum":    schema.Max,
+		"MultipleOf": schema.MultipleOf,
+	}
+	bools := map[string]bool{
+		"ExclusiveMinimum": schema.ExclusiveMin,
+		"ExclusiveMaximum": schema.ExclusiveMax,
+		"UniqueItems":      schema.UniqueItems,
+	}
+	var limits []string
+	for field, value := range uints {
+		if value != 0 {
+			limits = append(limits, fmt.Sprintf("%s: %d", field, value))
+		}
+	}
+	for field, value := range uintPointers {
+		if value != nil {
+			limits = append(limits, fmt.Sprintf("%s: schemainfo.Uint64(%d)", field, *value))
+		}
+	}
+	for field, value := range floatPointers {
+		if value != nil {
+			limits = append(limits, fmt.Sprintf("%s: schemainfo.Float64(%s)", field, strconv.FormatFloat(*value, 'g', -1, 64)))
+		}
+	}
+	for field, value := range bools {
+		if value {
+			limits = append(limits, fmt.Sprintf("%s: true", field))
+		}
+	}
+	sort.Strings(limits)
+	fields = append(fields, limits...)
+	return strings.Join(fields, ", "), nil
+}
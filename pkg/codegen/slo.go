@@ -0,0 +1,163 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+// SLODefinition is the SLO of an operation, as read from its x-slo extension.
+// It's generated into a runtime.SLO.
+type SLODefinition struct {
+	OperationId   string // The operation ID as written in the spec
+	Method        string
+	Path          string
+	Availability  float64
+	Latency       time.Duration
+	LatencyTarget float64
+}
+
+// LatencyNanoseconds returns the latency objective as an integer, to be
+// generated into a time.Duration.
+func (s SLODefinition) LatencyNanoseconds() int64 {
+	return int64(s.Latency)
+}
+
+// DefaultSLOLatencyTarget is the proportion of the requests which have to be
+// handled within the latency of an x-slo without a latencyTarget.
+const DefaultSLOLatencyTarget = 0.99
+
+// sloRatio turns a percentage of an x-slo into a ratio. Values above 1 are
+// taken as percentages, so that both 99.9 and 0.999 can be written.
+func sloRatio(name string, number json.Number) (float64, error) {
+	value, err := number.Float64()
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", name, err)
+	}
+	if value > 1 {
+		// Parsing the percentage with a shifted exponent gives the closest
+		// ratio, which dividing it by 100 doesn't, eg, 99.9 / 100 is
+		// 0.9990000000000001.
+		value, err = strconv.ParseFloat(number.String()+"e-2", 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %s", name, err)
+		}
+	}
+	if value <= 0 || value >= 1 {
+		return 0, fmt.Errorf("%s must be between 0 and 100%%, exclusive", name)
+	}
+	return value, nil
+}
+
+// DescribeSLO reads the SLO of an operation from its x-slo extension, an
+// object with an availability percentage, and a latency duration such as
+// "300ms", within which latencyTarget percent of the requests have to be
+// handled. It returns nil when the operation has no SLO.
+func DescribeSLO(op OperationDefinition) (*SLODefinition, error) {
+	raw, found := op.Spec.Extensions[extOpSLO]
+	if !found {
+		return nil, nil
+	}
+	rawJSON, ok := raw.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object, got %T", extOpSLO, raw)
+	}
+	var ext struct {
+		Availability  json.Number `json:"availability"`
+		Latency       string      `json:"latency"`
+		LatencyTarget json.Number `json:"latencyTarget"`
+	}
+	if err := json.Unmarshal(rawJSON, &ext); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", extOpSLO, err)
+	}
+	if ext.Availability == "" && ext.Latency == "" {
+		return nil, fmt.Errorf("%s needs an availability or a latency", extOpSLO)
+	}
+
+	slo := &SLODefinition{
+		OperationId: op.SpecOperationId,
+		Method:      op.Method,
+		Path:        op.Path,
+	}
+	var err error
+	if ext.Availability != "" {
+		slo.Availability, err = sloRatio("availability", ext.Availability)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ext.Latency == "" {
+		if ext.LatencyTarget != "" {
+			return nil, fmt.Errorf("latencyTarget needs a latency")
+		}
+		return slo, nil
+	}
+	slo.Latency, err = time.ParseDuration(ext.Latency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latency: %s", err)
+	}
+	if slo.Latency <= 0 {
+		return nil, fmt.Errorf("latency must be positive")
+	}
+	slo.LatencyTarget = DefaultSLOLatencyTarget
+	if ext.LatencyTarget != "" {
+		slo.LatencyTarget, err = sloRatio("latencyTarget", ext.LatencyTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return slo, nil
+}
+
+// GenerateSLO generates OperationSLOs, the SLOs of the operations with an
+// x-slo extension, along with the middleware of the generated servers which
+// observes the requests to them, and the handlers exporting the SLOs to SRE
+// tooling.
+func GenerateSLO(t *template.Template, ops []OperationDefinition) (string, error) {
+	context := struct {
+		Operations []SLODefinition
+		Echo       bool
+		Chi        bool
+	}{
+		Echo: globalState.options.GenerateEchoServer,
+		Chi:  globalState.options.GenerateChiServer,
+	}
+	for _, op := range ops {
+		slo, err := DescribeSLO(op)
+		if err != nil {
+			return "", fmt.Errorf("error describing the SLO of %s: %s", op.OperationId, err)
+		}
+		if slo != nil {
+			context.Operations = append(context.Operations, *slo)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "slo.tmpl", context)
+	if err != nil {
+		return "", fmt.Errorf("error generating SLOs: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for SLOs: %s", err)
+	}
+	return buf.String(), nil
+}
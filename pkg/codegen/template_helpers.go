@@ -17,16 +17,23 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
 )
 
 const (
-	// These allow the case statements to be sorted later:
-	prefixMostSpecific, prefixLessSpecific, prefixLeastSpecific = "3", "6", "9"
-	responseTypeSuffix                                          = "Response"
+	// These allow the case statements to be sorted later. Declared status
+	// codes go before ranges of status codes, such as 2XX, which go before
+	// default, whatever their content type. Within each, the clauses which
+	// unmarshal a content type go before those which don't.
+	tierStatus, tierStatusRange, tierDefault = "1", "2", "3"
+	clauseContent, clauseStatus, clauseGuard = "1", "2", "3"
+	responseTypeSuffix                       = "Response"
 )
 
 var (
@@ -80,6 +87,48 @@ func genParamNames(params []ParameterDefinition) string {
 	return ", " + strings.Join(parts, ", ")
 }
 
+// This generates the statements which convert the string in src into the
+// simple scalar parameter, and store it in dst. Conversion errors are stored
+// in a variable named err, which must be declared by the caller:
+// "dst, err = strconv.ParseInt(src, 10, 64)"
+func genScalarConversion(param ParameterDefinition, src string, dst string) string {
+	switch param.TypeDef() {
+	case "string":
+		return fmt.Sprintf("%s = %s", dst, src)
+	case "int":
+		return fmt.Sprintf("%s, err = strconv.Atoi(%s)", dst, src)
+	case "int64":
+		return fmt.Sprintf("%s, err = strconv.ParseInt(%s, 10, 64)", dst, src)
+	case "int32":
+		return fmt.Sprintf("var value int64\nvalue, err = strconv.ParseInt(%s, 10, 32)\n%s = int32(value)", src, dst)
+	case "float64":
+		return fmt.Sprintf("%s, err = strconv.ParseFloat(%s, 64)", dst, src)
+	case "float32":
+		return fmt.Sprintf("var value float64\nvalue, err = strconv.ParseFloat(%s, 32)\n%s = float32(value)", src, dst)
+	case "bool":
+		return fmt.Sprintf("%s, err = strconv.ParseBool(%s)", dst, src)
+	}
+	panic(fmt.Sprintf("unsupported simple scalar type: %s", param.TypeDef()))
+}
+
+// This generates the message of an error about the parameter, with the given
+// message ID and the arguments following the parameter name, for the request
+// in the variable named request. When the parameter has a description, it's
+// added to the message, so that callers learn what's expected:
+// runtime.Describe(r, runtime.Message(r, runtime.MsgEmptyParam, "id"), "id", "The ID of the pet.")
+func genParamMessage(param ParameterDefinition, request string, id string, args ...string) string {
+	name := strconv.Quote(param.ParamName)
+	msg := fmt.Sprintf("runtime.Message(%s, runtime.%s, %s)", request, id, strings.Join(append([]string{name}, args...), ", "))
+	description := ""
+	if param.Spec != nil {
+		description = runtime.DescriptionSummary(param.Spec.Description)
+	}
+	if description == "" {
+		return msg
+	}
+	return fmt.Sprintf("runtime.Describe(%s, %s, %s, %s)", request, msg, name, strconv.Quote(description))
+}
+
 func genParamFmtString(path string) string {
 	return ReplacePathParamsWithStr(path)
 }
@@ -126,13 +175,8 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 		// If there is no content-type then we have no unmarshaling to do:
 		if len(responseRef.Value.Content) == 0 {
 			caseAction := "break // No content-type"
-			if typeDefinition.ResponseName == "default" {
-				caseClauseKey := "default:"
-				caseClauses[prefixLeastSpecific+caseClauseKey] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
-			} else {
-				caseClauseKey := fmt.Sprintf("case rsp.StatusCode == %s:", typeDefinition.ResponseName)
-				caseClauses[prefixLessSpecific+caseClauseKey] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
-			}
+			caseKey, caseClause := buildStatusCase(typeDefinition, caseAction)
+			caseClauses[caseKey] = caseClause
 			continue
 		}
 
@@ -157,7 +201,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 				} else {
 					caseAction = fmt.Sprintf("response.%s = &%s{} \n if err := json.Unmarshal(bodyBytes, response.%s); err != nil { \n return nil, err \n}", typeDefinition.TypeName, typeDefinition.Schema.TypeDecl(), typeDefinition.TypeName)
 				}
-				caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "json")
+				caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "json", filterContentTypes(sortedContentKeys, contentTypesJSON))
 				caseClauses[caseKey] = caseClause
 
 			// YAML:
@@ -168,7 +212,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 				} else {
 					caseAction = fmt.Sprintf("response.%s = &%s{} \n if err := yaml.Unmarshal(bodyBytes, response.%s); err != nil { \n return nil, err \n}", typeDefinition.TypeName, typeDefinition.Schema.TypeDecl(), typeDefinition.TypeName)
 				}
-				caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "yaml")
+				caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "yaml", filterContentTypes(sortedContentKeys, contentTypesYAML))
 				caseClauses[caseKey] = caseClause
 
 			// XML:
@@ -179,23 +223,38 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 				} else {
 					caseAction = fmt.Sprintf("response.%s = &%s{} \n if err := xml.Unmarshal(bodyBytes, response.%s); err != nil { \n return nil, err \n}", typeDefinition.TypeName, typeDefinition.Schema.TypeDecl(), typeDefinition.TypeName)
 				}
-				caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "xml")
+				caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "xml", filterContentTypes(sortedContentKeys, contentTypesXML))
 				caseClauses[caseKey] = caseClause
 
 			// Everything else:
 			default:
 				caseAction := fmt.Sprintf("// Content-type (%s) unsupported", contentTypeName)
-				if typeDefinition.ResponseName == "default" {
-					caseClauseKey := "default:"
-					caseClauses[prefixLeastSpecific+caseClauseKey] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
-				} else {
-					caseClauseKey := fmt.Sprintf("case rsp.StatusCode == %s:", typeDefinition.ResponseName)
-					caseClauses[prefixLessSpecific+caseClauseKey] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
-				}
+				caseKey, caseClause := buildStatusCase(typeDefinition, caseAction)
+				caseClauses[caseKey] = caseClause
 			}
 		}
 	}
 
+	// A response with a declared status code, or in a declared range, but with
+	// a content type which none of the clauses above unmarshal, mustn't end up
+	// in a less specific response.
+	for _, tier := range []string{tierStatus, tierStatusRange} {
+		if !hasContentClauseAfter(caseClauses, tier) {
+			continue
+		}
+		var conditions []string
+		for _, name := range SortedResponsesKeys(responses) {
+			_, hasStatusCase := caseClauses[tier+clauseStatus+name]
+			if responseTier(name) != tier || hasStatusCase {
+				continue
+			}
+			conditions = append(conditions, genStatusCondition(name))
+		}
+		if len(conditions) != 0 {
+			caseClauses[tier+clauseGuard] = fmt.Sprintf("case %s:\nbreak // Declared status codes aren't parsed as a less specific response\n", strings.Join(conditions, " || "))
+		}
+	}
+
 	// Now build the switch statement in order of most-to-least specific:
 	fmt.Fprintf(buffer, "switch {\n")
 	for _, caseClauseKey := range SortedStringKeys(caseClauses) {
@@ -208,16 +267,183 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 }
 
 // buildUnmarshalCase builds an unmarshalling case clause for different content-types:
-func buildUnmarshalCase(typeDefinition TypeDefinition, caseAction string, contentType string) (caseKey string, caseClause string) {
-	caseKey = fmt.Sprintf("%s.%s.%s", prefixLeastSpecific, contentType, typeDefinition.ResponseName)
-	if typeDefinition.ResponseName == "default" {
-		caseClause = fmt.Sprintf("case strings.Contains(rsp.Header.Get(\"%s\"), \"%s\"):\n%s\n", echo.HeaderContentType, contentType, caseAction)
+func buildUnmarshalCase(typeDefinition TypeDefinition, caseAction string, contentType string, declared []string) (caseKey string, caseClause string) {
+	name := typeDefinition.ResponseName
+	tier := responseTier(name)
+	condition := genContentTypeCondition(contentType, declared)
+	if tier == tierDefault {
+		caseKey = fmt.Sprintf("%s%s.%s", tier, clauseContent, contentType)
+		caseClause = fmt.Sprintf("case %s:\n%s\n", condition, caseAction)
 	} else {
-		caseClause = fmt.Sprintf("case strings.Contains(rsp.Header.Get(\"%s\"), \"%s\") && rsp.StatusCode == %s:\n%s\n", echo.HeaderContentType, contentType, typeDefinition.ResponseName, caseAction)
+		caseKey = fmt.Sprintf("%s%s.%s.%s", tier, clauseContent, name, contentType)
+		caseClause = fmt.Sprintf("case %s && %s:\n%s\n", condition, genStatusCondition(name), caseAction)
+	}
+	return caseKey, caseClause
+}
+
+// buildStatusCase builds a case clause matching a status code, a range of
+// status codes, or default, whatever the content type.
+func buildStatusCase(typeDefinition TypeDefinition, caseAction string) (caseKey string, caseClause string) {
+	name := typeDefinition.ResponseName
+	tier := responseTier(name)
+	if tier == tierDefault {
+		return tier + clauseStatus, fmt.Sprintf("default:\n%s\n", caseAction)
 	}
+	caseKey = tier + clauseStatus + name
+	caseClause = fmt.Sprintf("case %s:\n%s\n", genStatusCondition(name), caseAction)
 	return caseKey, caseClause
 }
 
+// hasContentClauseAfter returns whether any of the case clauses unmarshals a
+// response which is less specific than those of the given tier.
+func hasContentClauseAfter(caseClauses map[string]string, tier string) bool {
+	for caseKey := range caseClauses {
+		if caseKey[:1] > tier && strings.HasPrefix(caseKey[1:], clauseContent) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseTier returns whether a response is for a status code, a range of
+// status codes or the default response, as one of the tier prefixes above.
+func responseTier(name string) string {
+	switch {
+	case name == "default":
+		return tierDefault
+	case IsStatusCodeRange(name):
+		return tierStatusRange
+	default:
+		return tierStatus
+	}
+}
+
+// genStatusCondition generates the condition which checks that the status
+// code of a response is the given one, or in the given range.
+func genStatusCondition(name string) string {
+	if IsStatusCodeRange(name) {
+		return fmt.Sprintf("rsp.StatusCode/100 == %c", name[0])
+	}
+	return fmt.Sprintf("rsp.StatusCode == %s", name)
+}
+
+// genStatusCode returns a status code for a response, which is the first one
+// of a range of status codes, such as 200 for 2XX.
+func genStatusCode(name string) string {
+	if IsStatusCodeRange(name) {
+		return name[:1] + "00"
+	}
+	return name
+}
+
+// genContentTypeMatcher returns the expression for the runtime.ContentTypeMatcher
+// which implements the content type matching option.
+func genContentTypeMatcher() string {
+	switch globalState.options.ResponseContentTypeMatching {
+	case ContentTypeMatchingStrict:
+		return "runtime.MatchContentTypeStrict"
+	case ContentTypeMatchingCustom:
+		return "ResponseContentTypeMatcher"
+	default:
+		return "runtime.MatchContentTypeLenient"
+	}
+}
+
+// genResponseContentTypeCheck generates the statements which make a Parse
+// function fail with a *runtime.UnexpectedContentTypeError, when the response
+// has a content type which isn't declared for its status code. Responses
+// without a declared status code are checked against all the content types
+// of the operation. Nothing is generated unless the UnexpectedContentTypeErrors
+// option is set, or when the operation doesn't declare any content.
+func genResponseContentTypeCheck(op *OperationDefinition) string {
+	if !globalState.options.UnexpectedContentTypeErrors {
+		return ""
+	}
+
+	var allContentTypes []string
+	caseClauses := make(map[string]string)
+	for _, responseName := range SortedResponsesKeys(op.Spec.Responses) {
+		responseRef := op.Spec.Responses[responseName]
+		if responseRef.Value == nil {
+			continue
+		}
+		contentTypes := SortedContentKeys(responseRef.Value.Content)
+		for _, ct := range contentTypes {
+			if !StringInArray(ct, allContentTypes) {
+				allContentTypes = append(allContentTypes, ct)
+			}
+		}
+
+		caseClause := "break // No content-type"
+		if len(contentTypes) > 0 {
+			caseClause = genCheckResponseContentType(contentTypes)
+		}
+		if responseName == "default" {
+			caseClauses["default"] = caseClause
+		} else {
+			caseClauses[responseName] = caseClause
+		}
+	}
+	if len(allContentTypes) == 0 {
+		return ""
+	}
+	if _, found := caseClauses["default"]; !found {
+		caseClauses["default"] = genCheckResponseContentType(allContentTypes)
+	}
+
+	buffer := bytes.NewBufferString("switch {\n")
+	for _, responseName := range SortedStringKeys(caseClauses) {
+		if responseName == "default" {
+			continue
+		}
+		fmt.Fprintf(buffer, "case %s:\n%s\n", genStatusCondition(responseName), caseClauses[responseName])
+	}
+	fmt.Fprintf(buffer, "default:\n%s\n}\n", caseClauses["default"])
+	fmt.Fprintf(buffer, "if err != nil {\nreturn nil, err\n}\n")
+	return buffer.String()
+}
+
+func genCheckResponseContentType(contentTypes []string) string {
+	quoted := make([]string, len(contentTypes))
+	for i, ct := range contentTypes {
+		quoted[i] = fmt.Sprintf("%q", ct)
+	}
+	return fmt.Sprintf("err = runtime.CheckResponseContentType(rsp, bodyBytes, %s, %s)",
+		genContentTypeMatcher(), strings.Join(quoted, ", "))
+}
+
+// genContentTypeCondition generates the condition which checks the
+// Content-Type of a response, according to the content type matching option.
+// contentType is the short name of the format, eg, "json", and declared holds
+// the media types of this format which the spec declares for the response.
+func genContentTypeCondition(contentType string, declared []string) string {
+	header := fmt.Sprintf("rsp.Header.Get(\"%s\")", echo.HeaderContentType)
+	quoted := make([]string, len(declared))
+	for i, d := range declared {
+		quoted[i] = fmt.Sprintf("%q", d)
+	}
+	switch globalState.options.ResponseContentTypeMatching {
+	case ContentTypeMatchingStrict:
+		return fmt.Sprintf("runtime.MatchContentTypeStrict(%s, %s)", header, strings.Join(quoted, ", "))
+	case ContentTypeMatchingCustom:
+		return fmt.Sprintf("ResponseContentTypeMatcher(%s, %s)", header, strings.Join(quoted, ", "))
+	default:
+		return fmt.Sprintf("strings.Contains(%s, \"%s\")", header, contentType)
+	}
+}
+
+// filterContentTypes returns the content types which are in the given list of
+// known ones, keeping their order.
+func filterContentTypes(contentTypes []string, known []string) []string {
+	var result []string
+	for _, ct := range contentTypes {
+		if StringInArray(ct, known) {
+			result = append(result, ct)
+		}
+	}
+	return result
+}
+
 // genResponseTypeName creates the name of generated response types (given the operationID):
 func genResponseTypeName(operationID string) string {
 	return fmt.Sprintf("%s%s", LowercaseFirstCharacter(operationID), responseTypeSuffix)
@@ -244,21 +470,26 @@ func stripNewLines(s string) string {
 // This function map is passed to the template engine, and we can call each
 // function here by keyName from the template code.
 var TemplateFunctions = template.FuncMap{
-	"genParamArgs":               genParamArgs,
-	"genParamTypes":              genParamTypes,
-	"genParamNames":              genParamNames,
-	"genParamFmtString":          genParamFmtString,
-	"swaggerUriToEchoUri":        SwaggerUriToEchoUri,
-	"swaggerUriToChiUri":         SwaggerUriToChiUri,
-	"lcFirst":                    LowercaseFirstCharacter,
-	"ucFirst":                    UppercaseFirstCharacter,
-	"camelCase":                  ToCamelCase,
-	"genResponsePayload":         genResponsePayload,
-	"genResponseTypeName":        genResponseTypeName,
-	"genResponseUnmarshal":       genResponseUnmarshal,
-	"getResponseTypeDefinitions": getResponseTypeDefinitions,
-	"toStringArray":              toStringArray,
-	"lower":                      strings.ToLower,
-	"title":                      strings.Title,
-	"stripNewLines":              stripNewLines,
+	"genParamArgs":                genParamArgs,
+	"genParamTypes":               genParamTypes,
+	"genParamNames":               genParamNames,
+	"genParamFmtString":           genParamFmtString,
+	"genScalarConversion":         genScalarConversion,
+	"genParamMessage":             genParamMessage,
+	"swaggerUriToEchoUri":         SwaggerUriToEchoUri,
+	"swaggerUriToChiUri":          SwaggerUriToChiUri,
+	"lcFirst":                     LowercaseFirstCharacter,
+	"ucFirst":                     UppercaseFirstCharacter,
+	"camelCase":                   ToCamelCase,
+	"genResponsePayload":          genResponsePayload,
+	"genResponseTypeName":         genResponseTypeName,
+	"genResponseUnmarshal":        genResponseUnmarshal,
+	"genResponseContentTypeCheck": genResponseContentTypeCheck,
+	"getResponseTypeDefinitions":  getResponseTypeDefinitions,
+	"statusCode":                  genStatusCode,
+	"toStringArray":               toStringArray,
+	"lower":                       strings.ToLower,
+	"title":                       strings.Title,
+	"stripNewLines":               stripNewLines,
+	"opts":                        func() Options { return globalState.options },
 }
@@ -2,7 +2,7 @@ package templates
 
 import "text/template"
 
-var templates = map[string]string{"additional-properties.tmpl": `{{range .Types}}{{$addType := .Schema.AdditionalPropertiesType.TypeDecl}}
+var templates = map[string]string{"additional-properties.tmpl": `{{range .Types}}{{$addType := .Schema.AdditionalPropertiesType.TypeDecl}}{{$type := .TypeName}}{{$tracksPresence := .Schema.TracksPresence}}
 
 // Getter for additional properties for {{.TypeName}}. Returns the specified
 // element and whether it was found
@@ -28,12 +28,19 @@ func (a *{{.TypeName}}) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-{{range .Schema.Properties}}
+{{range $i, $p := .Schema.Properties}}
     if raw, found := object["{{.JsonFieldName}}"]; found {
+        {{if .Encrypted -}}
+        err = runtime.DecryptField(EncryptedFieldCipher, "{{$type}}.{{.JsonFieldName}}", raw, &a.{{.GoFieldName}})
+        {{- else -}}
         err = json.Unmarshal(raw, &a.{{.GoFieldName}})
+        {{- end}}
         if err != nil {
             return errors.Wrap(err, "error reading '{{.JsonFieldName}}'")
         }
+        {{- if $tracksPresence}}
+        runtime.MarkField(a.presence[:], {{$i}})
+        {{- end}}
         delete(object, "{{.JsonFieldName}}")
     }
 {{end}}
@@ -56,12 +63,16 @@ func (a {{.TypeName}}) MarshalJSON() ([]byte, error) {
     var err error
     object := make(map[string]json.RawMessage)
 {{range .Schema.Properties}}
-{{if not .Required}}if a.{{.GoFieldName}} != nil { {{end}}
+{{if and .HasOmitEmpty (or .IsPointer .Schema.SkipOptionalPointer)}}if a.{{.GoFieldName}} != nil { {{end}}
+    {{if .Encrypted -}}
+    object["{{.JsonFieldName}}"], err = runtime.EncryptField(EncryptedFieldCipher, "{{$type}}.{{.JsonFieldName}}", a.{{.GoFieldName}})
+    {{- else -}}
     object["{{.JsonFieldName}}"], err = json.Marshal(a.{{.GoFieldName}})
+    {{- end}}
     if err != nil {
         return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '{{.JsonFieldName}}'"))
     }
-{{if not .Required}} }{{end}}
+{{if and .HasOmitEmpty (or .IsPointer .Schema.SkipOptionalPointer)}} }{{end}}
 {{end}}
     for fieldName, field := range a.AdditionalProperties {
 		object[fieldName], err = json.Marshal(field)
@@ -72,6 +83,139 @@ func (a {{.TypeName}}) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 {{end}}
+`,
+	"async-job.tmpl": `{{range .}}{{if .AsyncJob}}{{$opid := .OperationId}}{{$hasParams := .RequiresParamObject}}{{$pathParams := .PathParams}}{{with .AsyncJob}}{{$job := .}}
+{{- if .DefineBody}}
+// {{.Name}} is a job returned by {{$opid}}.
+type {{.Name}} = {{.BodyType}}
+{{end}}
+{{- if .DeclareStatuses}}
+{{- if .DefineStatus}}
+// {{.StatusType}} is the status of the {{.Name}} jobs returned by {{$opid}}.
+type {{.StatusType}} string
+{{end}}
+// The statuses of {{.Name}} jobs.
+const (
+{{- range .Statuses}}
+    {{$job.StatusConstant .}} {{$job.StatusType}} = "{{.}}"
+{{- end}}
+)
+
+// Succeeded reports whether a job with this status is over, and succeeded.
+func (s {{.StatusType}}) Succeeded() bool {
+    switch s {
+    case {{range $i, $s := .Succeeded}}{{if $i}}, {{end}}{{$job.StatusConstant $s}}{{end}}:
+        return true
+    }
+    return false
+}
+
+// Failed reports whether a job with this status is over, and failed.
+func (s {{.StatusType}}) Failed() bool {
+    switch s {
+    case {{range $i, $s := .Failed}}{{if $i}}, {{end}}{{$job.StatusConstant $s}}{{end}}:
+        return true
+    }
+    return false
+}
+
+// IsTerminal reports whether a job with this status is over.
+func (s {{.StatusType}}) IsTerminal() bool {
+    return s.Succeeded() || s.Failed()
+}
+{{end}}
+// {{.Name}}Watcher polls {{$opid}} until the job it returns reaches a terminal
+// status.
+type {{.Name}}Watcher struct {
+    client *ClientWithResponses
+    opts   []runtime.JobOption
+}
+
+// New{{.Name}}Watcher returns a watcher calling {{$opid}} with client. The
+// options configure the time between two polls.
+func New{{.Name}}Watcher(client *ClientWithResponses, opts ...runtime.JobOption) *{{.Name}}Watcher {
+    return &{{.Name}}Watcher{client: client, opts: opts}
+}
+
+// Wait polls the job until its status is terminal, calling onStatus, when not
+// nil, with every state of the job, and returns the last one. A job which
+// failed is returned along with a *runtime.JobFailedError.
+func (w *{{.Name}}Watcher) Wait(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, onStatus func(*{{.JobType}}), reqEditors ...RequestEditorFn) (*{{.JobType}}, error) {
+    var job *{{.JobType}}
+    err := runtime.WatchJob(ctx, func(ctx context.Context) (bool, error) {
+        rsp, err := w.client.{{$opid}}WithResponse(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, reqEditors...)
+        if err != nil {
+            return false, err
+        }
+        if rsp.{{.FieldName}} == nil {
+            return false, fmt.Errorf("unexpected response to {{$opid}}: %s", rsp.Status())
+        }
+        job = rsp.{{.FieldName}}
+        if onStatus != nil {
+            onStatus(job)
+        }
+{{- if .Status.IsPointer}}
+        var status {{.StatusType}}
+        if job.{{.Status.GoFieldName}} != nil {
+            status = {{.StatusType}}(*job.{{.Status.GoFieldName}})
+        }
+{{- else}}
+        status := {{.StatusType}}(job.{{.Status.GoFieldName}})
+{{- end}}
+        if status.Failed() {
+            return true, &runtime.JobFailedError{Status: string(status)}
+        }
+        return status.IsTerminal(), nil
+    }, w.opts...)
+    return job, err
+}
+{{- if .Result}}
+
+// Result waits for the job like Wait, and returns its result, which is nil
+// when the job doesn't have one.
+func (w *{{.Name}}Watcher) Result(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, reqEditors ...RequestEditorFn) (*{{.Result.Schema.TypeDecl}}, error) {
+    job, err := w.Wait(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, nil, reqEditors...)
+    if err != nil {
+        return nil, err
+    }
+    return {{if not .Result.IsPointer}}&{{end}}job.{{.Result.GoFieldName}}, nil
+}
+{{- end}}
+{{end}}{{end}}{{end}}
+`,
+	"audit.tmpl": `// AuditDescriptors describes the operations for audit logging, by operation
+// ID.
+var AuditDescriptors = map[string]runtime.AuditDescriptor{
+{{- range .Operations}}
+    {{printf "%q" .OperationId}}: {
+        OperationID: {{printf "%q" .OperationId}},
+        Method:      {{printf "%q" .Method}},
+        Path:        {{printf "%q" .Path}},
+        Resource:    {{printf "%q" .Resource}},
+        Action:      {{printf "%q" .Action}},
+{{- if .Sensitivity}}
+        Sensitivity: {{printf "%q" .Sensitivity}},
+{{- end}}
+{{- if .Sensitive}}
+        Sensitive:   {{toStringArray .Sensitive}},
+{{- end}}
+    },
+{{- end}}
+}
+{{if .Echo}}
+// AuditMiddleware returns an echo middleware which calls emit with an audit
+// event for every request to one of the operations.
+func AuditMiddleware(emit runtime.AuditEmitter) echo.MiddlewareFunc {
+    return runtime.AuditMiddleware(AuditDescriptors, emit)
+}
+{{end}}
+{{- if .Chi}}
+// AuditHandler wraps the handler of the operations, so that emit is called
+// with an audit event for every request to one of them.
+func AuditHandler(emit runtime.AuditEmitter, next http.Handler) http.Handler {
+    return runtime.AuditHandler(AuditDescriptors, emit, next)
+}
+{{end}}
 `,
 	"chi-handler.tmpl": `// Handler creates http.Handler with routing matching OpenAPI spec.
 func Handler(si ServerInterface) http.Handler {
@@ -97,7 +241,7 @@ func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
 	"chi-middleware.tmpl": `
 {{range .}}{{$opid := .OperationId}}
 
-{{if .RequiresParamObject}}
+{{if and .RequiresParamObject (not .IsProxy)}}
 // ParamsFor{{.OperationId}} operation parameters from context
 func ParamsFor{{.OperationId}}(ctx context.Context) *{{.OperationId}}Params {
   return ctx.Value("{{.OperationId}}Params").(*{{.OperationId}}Params)
@@ -108,6 +252,22 @@ func ParamsFor{{.OperationId}}(ctx context.Context) *{{.OperationId}}Params {
 func {{$opid}}Ctx(next http.Handler) http.Handler {
   return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
     ctx := r.Context()
+{{- if (opts).GenerateAudit}}
+    {{if and .AllParams (not .IsProxy)}}auditing := {{end}}runtime.AuditOperation(ctx, {{printf "%q" .SpecOperationId}})
+{{- end}}
+{{- if (opts).GenerateSLO}}
+    runtime.SLOOperation(ctx, {{printf "%q" .SpecOperationId}})
+{{- end}}
+{{- if and (opts).GenerateDeprecation .Spec.Deprecated}}
+    runtime.DeprecatedOperationCalled(ctx, {{printf "%q" .SpecOperationId}})
+{{- end}}
+{{- with .SecurityLiteral}}
+    if err := runtime.CheckSecurity(r, {{.}}); err != nil {
+        http.Error(w, runtime.Message(r, runtime.MsgSecurityUnsatisfied), http.StatusUnauthorized)
+        return
+    }
+{{- end}}
+{{if not .IsProxy}}
     {{if or .RequiresParamObject (gt (len .PathParams) 0) }}
     var err error
     {{end}}
@@ -121,25 +281,38 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
     {{if .IsJson}}
     err = json.Unmarshal([]byte(chi.URLParam(r, "{{.ParamName}}")), &{{$varName}})
     if err != nil {
-      http.Error(w, "Error unmarshaling parameter '{{.ParamName}}' as JSON", http.StatusBadRequest)
+      http.Error(w, {{genParamMessage . "r" "MsgUnmarshalParamJSON"}}, http.StatusBadRequest)
       return
     }
     {{end}}
-    {{if .IsStyled}}
+    {{if .IsSimpleScalar}}
+    if paramValue := chi.URLParam(r, "{{.ParamName}}"); paramValue != "" {
+      {{genScalarConversion . "paramValue" $varName}}
+      if err != nil {
+        http.Error(w, {{genParamMessage . "r" "MsgInvalidParamFormat" "err"}}, http.StatusBadRequest)
+        return
+      }
+    } else {
+      http.Error(w, {{genParamMessage . "r" "MsgEmptyParam"}}, http.StatusBadRequest)
+      return
+    }
+    {{else if .IsStyled}}
     err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", chi.URLParam(r, "{{.ParamName}}"), &{{$varName}})
     if err != nil {
-      http.Error(w, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err), http.StatusBadRequest)
+      http.Error(w, {{genParamMessage . "r" "MsgInvalidParamFormat" "err"}}, http.StatusBadRequest)
       return
     }
     {{end}}
 
     ctx = context.WithValue(ctx, "{{$varName}}", {{$varName}})
     {{end}}
+{{end}}{{/* not .IsProxy */}}
 
 {{range .SecurityDefinitions}}
     ctx = context.WithValue(ctx, "{{.ProviderName}}.Scopes", {{toStringArray .Scopes}})
 {{end}}
 
+{{if not .IsProxy}}
     {{if .RequiresParamObject}}
       // Parameter object where we will unmarshal all parameters from the context
       var params {{.OperationId}}Params
@@ -155,20 +328,30 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
           var value {{.TypeDef}}
           err = json.Unmarshal([]byte(paramValue), &value)
           if err != nil {
-            http.Error(w, "Error unmarshaling parameter '{{.ParamName}}' as JSON", http.StatusBadRequest)
+            http.Error(w, {{genParamMessage . "r" "MsgUnmarshalParamJSON"}}, http.StatusBadRequest)
             return
           }
 
           params.{{.GoName}} = {{if not .Required}}&{{end}}value
         {{end}}
         }{{if .Required}} else {
-            http.Error(w, "Query argument {{.ParamName}} is required, but not found", http.StatusBadRequest)
+            http.Error(w, {{genParamMessage . "r" "MsgRequiredQueryParam"}}, http.StatusBadRequest)
             return
         }{{end}}
         {{if .IsStyled}}
         err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", r.URL.Query(), &params.{{.GoName}})
         if err != nil {
-          http.Error(w, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err), http.StatusBadRequest)
+          http.Error(w, {{genParamMessage . "r" "MsgInvalidParamFormat" "err"}}, http.StatusBadRequest)
+          return
+        }
+        {{end}}{{if .DefaultValue}}
+        if params.{{.GoName}} == nil {
+          value := {{.DefaultValue}}
+          params.{{.GoName}} = &value
+        }
+        {{end}}{{if .AllowedValues}}
+        if value, found := runtime.NotAllowedValue(params.{{.GoName}}, {{.AllowedValuesLiteral}}); found {
+          http.Error(w, {{genParamMessage . "r" "MsgParamNotAllowed" "value" .AllowedValuesList}}, http.StatusBadRequest)
           return
         }
         {{end}}
@@ -182,7 +365,7 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
             var {{.GoName}} {{.TypeDef}}
             n := len(valueList)
             if n != 1 {
-              http.Error(w, fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n), http.StatusBadRequest)
+              http.Error(w, {{genParamMessage . "r" "MsgParamValueCount" "n"}}, http.StatusBadRequest)
               return
             }
 
@@ -193,7 +376,7 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
           {{if .IsJson}}
             err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
             if err != nil {
-              http.Error(w, "Error unmarshaling parameter '{{.ParamName}}' as JSON", http.StatusBadRequest)
+              http.Error(w, {{genParamMessage . "r" "MsgUnmarshalParamJSON"}}, http.StatusBadRequest)
               return
             }
           {{end}}
@@ -201,7 +384,7 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
           {{if .IsStyled}}
             err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", valueList[0], &{{.GoName}})
             if err != nil {
-              http.Error(w, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err), http.StatusBadRequest)
+              http.Error(w, {{genParamMessage . "r" "MsgInvalidParamFormat" "err"}}, http.StatusBadRequest)
               return
             }
           {{end}}
@@ -209,7 +392,7 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
             params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
 
           } {{if .Required}}else {
-              http.Error(w, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found", err), http.StatusBadRequest)
+              http.Error(w, {{genParamMessage . "r" "MsgRequiredHeaderParam"}}, http.StatusBadRequest)
               return
           }{{end}}
 
@@ -228,13 +411,13 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
           var decoded string
           decoded, err := url.QueryUnescape(cookie.Value)
           if err != nil {
-            http.Error(w, "Error unescaping cookie parameter '{{.ParamName}}'", http.StatusBadRequest)
+            http.Error(w, {{genParamMessage . "r" "MsgUnescapeCookieParam"}}, http.StatusBadRequest)
             return
           }
 
           err = json.Unmarshal([]byte(decoded), &value)
           if err != nil {
-            http.Error(w, "Error unmarshaling parameter '{{.ParamName}}' as JSON", http.StatusBadRequest)
+            http.Error(w, {{genParamMessage . "r" "MsgUnmarshalParamJSON"}}, http.StatusBadRequest)
             return
           }
 
@@ -245,7 +428,7 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
           var value {{.TypeDef}}
           err = runtime.BindStyledParameter("simple",{{.Explode}}, "{{.ParamName}}", cookie.Value, &value)
           if err != nil {
-            http.Error(w, "Invalid format for parameter {{.ParamName}}: %s", http.StatusBadRequest)
+            http.Error(w, {{genParamMessage . "r" "MsgInvalidParamFormat" "err"}}, http.StatusBadRequest)
             return
           }
           params.{{.GoName}} = {{if not .Required}}&{{end}}value
@@ -254,7 +437,7 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
         }
 
         {{- if .Required}} else {
-          http.Error(w, "Query argument {{.ParamName}} is required, but not found", http.StatusBadRequest)
+          http.Error(w, {{genParamMessage . "r" "MsgRequiredCookieParam"}}, http.StatusBadRequest)
           return
         }
         {{- end}}
@@ -262,6 +445,19 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
 
       ctx = context.WithValue(ctx, "{{.OperationId}}Params", &params)
     {{end}}
+{{if and (opts).GenerateAudit .AllParams}}
+    if auditing {
+      runtime.AuditParams(ctx, map[string]interface{}{
+{{- range .PathParams}}
+        "{{.ParamName}}": {{.GoVariableName}},
+{{- end}}
+{{- range .Params}}
+        "{{.ParamName}}": params.{{.GoName}},
+{{- end}}
+      })
+    }
+{{end}}
+{{end}}{{/* not .IsProxy */}}
     next.ServeHTTP(w, r.WithContext(ctx))
   })
 }
@@ -269,12 +465,248 @@ func {{$opid}}Ctx(next http.Handler) http.Handler {
 
 
 
+`,
+	"client-fake.tmpl": `// FakeClient implements ClientInterface without performing any HTTP requests.
+// Responses are programmed per operation, and every call is recorded, so that
+// code built on top of the client can be tested in isolation. As no request
+// is built, the request editors passed to calls are ignored.
+type FakeClient struct {
+    mu sync.Mutex
+{{range .}}{{$opid := .OperationId}}
+    {{$opid | lcFirst}}Stub  func(call Fake{{$opid}}Call) (*http.Response, error)
+    {{$opid | lcFirst}}Calls []Fake{{$opid}}Call
+{{- end}}
+}
+
+var _ ClientInterface = (*FakeClient)(nil)
+
+// NewFakeClient creates a FakeClient with no programmed responses.
+func NewFakeClient() *FakeClient {
+    return &FakeClient{}
+}
+
+{{range .}}
+{{$hasParams := .RequiresParamObject -}}
+{{$pathParams := .PathParams -}}
+{{$opid := .OperationId -}}
+// Fake{{$opid}}Call records the arguments of a single {{$opid}} call.
+type Fake{{$opid}}Call struct {
+    Ctx context.Context
+{{- range $pathParams}}
+    {{.GoName}} {{.TypeDef}}
+{{- end}}
+{{- if $hasParams}}
+    Params *{{$opid}}Params
+{{- end}}
+{{- if .HasBody}}
+    ContentType string
+    Body        []byte
+{{- end}}
+{{- if .HasPartialContent}}
+    // Range is set for calls made through {{$opid}}Range.
+    Range *runtime.ByteRange
+{{- end}}
+}
+
+// {{$opid}}Stub sets the function which produces the response of every
+// following {{$opid}} call.
+func (f *FakeClient) {{$opid}}Stub(stub func(call Fake{{$opid}}Call) (*http.Response, error)) *FakeClient {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.{{$opid | lcFirst}}Stub = stub
+    return f
+}
+
+// {{$opid}}ReturnsResponse makes {{$opid}} return the given response and
+// error. The same response is returned on every call, so its body can only be
+// read once.
+func (f *FakeClient) {{$opid}}ReturnsResponse(rsp *http.Response, err error) *FakeClient {
+    return f.{{$opid}}Stub(func(Fake{{$opid}}Call) (*http.Response, error) {
+        return rsp, err
+    })
+}
+{{with .SuccessJSONResponse}}
+// {{$opid}}Returns makes {{$opid}} respond with a {{statusCode .ResponseName}} status and the
+// JSON encoding of body, or fail with err when it isn't nil.
+func (f *FakeClient) {{$opid}}Returns(body {{.Schema.TypeDecl}}, err error) *FakeClient {
+    return f.{{$opid}}Stub(func(Fake{{$opid}}Call) (*http.Response, error) {
+        if err != nil {
+            return nil, err
+        }
+        return runtime.NewJSONResponse({{statusCode .ResponseName}}, body)
+    })
+}
+{{end}}
+// {{$opid}}Calls returns all the {{$opid}} calls made so far.
+func (f *FakeClient) {{$opid}}Calls() []Fake{{$opid}}Call {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return append([]Fake{{$opid}}Call(nil), f.{{$opid | lcFirst}}Calls...)
+}
+
+func (f *FakeClient) record{{$opid}}(call Fake{{$opid}}Call) (*http.Response, error) {
+    f.mu.Lock()
+    f.{{$opid | lcFirst}}Calls = append(f.{{$opid | lcFirst}}Calls, call)
+    stub := f.{{$opid | lcFirst}}Stub
+    f.mu.Unlock()
+    if stub == nil {
+        return nil, fmt.Errorf("FakeClient: no response programmed for {{$opid}}")
+    }
+    return stub(call)
+}
+
+func (f *FakeClient) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors ...RequestEditorFn) (*http.Response, error) {
+{{- if .HasBody}}
+    var buf []byte
+    if body != nil {
+        var err error
+        buf, err = ioutil.ReadAll(body)
+        if err != nil {
+            return nil, err
+        }
+    }
+{{- end}}
+    return f.record{{$opid}}(Fake{{$opid}}Call{
+        Ctx: ctx,
+{{- range $pathParams}}
+        {{.GoName}}: {{.GoVariableName}},
+{{- end}}
+{{- if $hasParams}}
+        Params: params,
+{{- end}}
+{{- if .HasBody}}
+        ContentType: contentType,
+        Body:        buf,
+{{- end}}
+    })
+}
+{{range .Bodies}}
+func (f *FakeClient) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+    buf, err := json.Marshal(body)
+    if err != nil {
+        return nil, err
+    }
+    return f.record{{$opid}}(Fake{{$opid}}Call{
+        Ctx: ctx,
+{{- range $pathParams}}
+        {{.GoName}}: {{.GoVariableName}},
+{{- end}}
+{{- if $hasParams}}
+        Params: params,
+{{- end}}
+        ContentType: "{{.ContentType}}",
+        Body:        buf,
+    })
+}
+{{end}}{{/* range .Bodies */}}
+{{- if .HasPartialContent}}
+func (f *FakeClient) {{$opid}}Range(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, byteRange runtime.ByteRange, reqEditors ...RequestEditorFn) (*http.Response, error) {
+    return f.record{{$opid}}(Fake{{$opid}}Call{
+        Ctx: ctx,
+{{- range $pathParams}}
+        {{.GoName}}: {{.GoVariableName}},
+{{- end}}
+{{- if $hasParams}}
+        Params: params,
+{{- end}}
+        Range: &byteRange,
+    })
+}
+{{end}}
+{{end}}{{/* range . */}}
+`,
+	"client-in-memory.tmpl": `// NewInMemoryClient creates a new Client which passes its requests directly to
+// the handlers of si, without going through the network. Parameters and
+// bodies are still marshaled and bound by the generated client and server
+// code, which makes this useful for fast integration tests, and for composing
+// services generated from the same spec in a single process.
+func NewInMemoryClient(si ServerInterface, opts ...ClientOption) (*Client, error) {
+{{- if .GenerateEchoServer}}
+    e := echo.New()
+    RegisterHandlers(e, si)
+    handler := http.Handler(e)
+{{- else}}
+    handler := Handler(si)
+{{- end}}
+    opts = append([]ClientOption{WithHTTPClient(runtime.NewHandlerDoer(handler))}, opts...)
+    return NewClient("http://in-memory", opts...)
+}
+
+// NewInMemoryClientWithResponses creates a new ClientWithResponses on top of
+// NewInMemoryClient.
+func NewInMemoryClientWithResponses(si ServerInterface, opts ...ClientOption) (*ClientWithResponses, error) {
+    client, err := NewInMemoryClient(si, opts...)
+    if err != nil {
+        return nil, err
+    }
+    return &ClientWithResponses{client}, nil
+}
+`,
+	"client-tags.tmpl": `{{range .}}{{$tag := .}}
+// {{.GoName}}Client offers the operations tagged "{{.Tag}}". It is a facade
+// over ClientInterface, with the same methods.
+type {{.GoName}}Client struct {
+    client ClientInterface
+    err    error
+}
+
+// New{{.GoName}}Client creates a {{.GoName}}Client for any ClientInterface,
+// such as a FakeClient.
+func New{{.GoName}}Client(client ClientInterface) *{{.GoName}}Client {
+    return &{{.GoName}}Client{client: client}
+}
+
+// {{.GoName}} returns the operations tagged "{{.Tag}}". The options apply on
+// top of those of c, to the returned client only. An error applying them is
+// returned by every call.
+func (c *Client) {{.GoName}}(opts ...ClientOption) *{{.GoName}}Client {
+    client, err := c.Clone(opts...)
+    if err != nil {
+        return &{{.GoName}}Client{err: err}
+    }
+    return &{{.GoName}}Client{client: client}
+}
+{{range .Operations}}
+{{$hasParams := .RequiresParamObject -}}
+{{$pathParams := .PathParams -}}
+{{$opid := .OperationId -}}
+func (c *{{$tag.GoName}}Client) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors ...RequestEditorFn) (*http.Response, error) {
+    if c.err != nil {
+        return nil, c.err
+    }
+    return c.client.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}}, reqEditors...)
+}
+{{range .Bodies}}
+func (c *{{$tag.GoName}}Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+    if c.err != nil {
+        return nil, c.err
+    }
+    return c.client.{{$opid}}{{.Suffix}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body, reqEditors...)
+}
+{{end}}{{/* range .Bodies */}}
+{{- if .HasPartialContent}}
+func (c *{{$tag.GoName}}Client) {{$opid}}Range(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, byteRange runtime.ByteRange, reqEditors ...RequestEditorFn) (*http.Response, error) {
+    if c.err != nil {
+        return nil, c.err
+    }
+    return c.client.{{$opid}}Range(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, byteRange, reqEditors...)
+}
+{{end}}
+{{- end}}{{/* range .Operations */}}
+{{end}}{{/* range . */}}
 `,
 	"client-with-responses.tmpl": `// ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
     ClientInterface
 }
 
+{{if eq (opts).ResponseContentTypeMatching "custom" -}}
+// ResponseContentTypeMatcher decides whether the Parse functions unmarshal a
+// response, by matching its Content-Type against the media types declared for
+// it in the spec. Replace it to customize the matching.
+var ResponseContentTypeMatcher runtime.ContentTypeMatcher = runtime.MatchContentTypeStrict
+
+{{end -}}
 // NewClientWithResponses creates a new ClientWithResponses, which wraps
 // Client with return type handling
 func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
@@ -300,13 +732,16 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
-{{range .}}{{$opid := .OperationId}}{{$op := .}}
+{{range .}}{{$opid := .OperationId}}{{$op := .}}{{if not .IsProxy}}
 type {{$opid | lcFirst}}Response struct {
     Body         []byte
 	HTTPResponse *http.Response
     {{- range getResponseTypeDefinitions .}}
     {{.TypeName}} *{{.Schema.TypeDecl}}
     {{- end}}
+    {{- if .HasPartialContent}}
+    PartialContent *runtime.PartialContent
+    {{- end}}
 }
 
 // Status returns HTTPResponse.Status
@@ -324,16 +759,24 @@ func (r {{$opid | lcFirst}}Response) StatusCode() int {
     }
     return 0
 }
+{{end}}{{/* not .IsProxy */}}
 {{end}}
 
 
 {{range .}}
 {{$opid := .OperationId -}}
 {{/* Generate client methods (with responses)*/}}
-
+{{if .IsProxy}}
+// {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse request{{if .HasBody}} with arbitrary body{{end}} returning the
+// response unread, as {{$opid}} is a proxied operation. The body of the
+// response has to be closed by the caller.
+func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors ...RequestEditorFn) (*http.Response, error){
+    return c.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .HasBody}}, contentType, body{{end}}, reqEditors...)
+}
+{{else}}
 // {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse request{{if .HasBody}} with arbitrary body{{end}} returning *{{$opid}}Response
-func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*{{genResponseTypeName $opid}}, error){
-    rsp, err := c.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
+func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors ...RequestEditorFn) (*{{genResponseTypeName $opid}}, error){
+    rsp, err := c.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .HasBody}}, contentType, body{{end}}, reqEditors...)
     if err != nil {
         return nil, err
     }
@@ -344,19 +787,39 @@ func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithRespons
 {{$pathParams := .PathParams -}}
 {{$bodyRequired := .BodyRequired -}}
 {{range .Bodies}}
-func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody) (*{{genResponseTypeName $opid}}, error) {
-    rsp, err := c.{{$opid}}{{.Suffix}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
+func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors ...RequestEditorFn) (*{{genResponseTypeName $opid}}, error) {
+    rsp, err := c.{{$opid}}{{.Suffix}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body, reqEditors...)
     if err != nil {
         return nil, err
     }
     return Parse{{genResponseTypeName $opid | ucFirst}}(rsp)
 }
 {{end}}
+{{if .HasPartialContent}}
+// {{$opid}}RangeWithResponse requests a byte range of the content, returning *{{$opid}}Response
+func (c *ClientWithResponses) {{$opid}}RangeWithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, byteRange runtime.ByteRange, reqEditors ...RequestEditorFn) (*{{genResponseTypeName $opid}}, error) {
+    rsp, err := c.{{$opid}}Range(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, byteRange, reqEditors...)
+    if err != nil {
+        return nil, err
+    }
+    return Parse{{genResponseTypeName $opid | ucFirst}}(rsp)
+}
 
+// Download{{$opid}}Resumable downloads the content into w, resuming
+// interrupted transfers with {{$opid}}Range, and returns the number of bytes
+// written. See runtime.DownloadResumable for the available options.
+func (c *ClientWithResponses) Download{{$opid}}Resumable(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, w io.WriterAt, opts ...runtime.DownloadOption) (int64, error) {
+    fetch := func(ctx context.Context, byteRange runtime.ByteRange) (*http.Response, error) {
+        return c.{{$opid}}Range(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, byteRange)
+    }
+    return runtime.DownloadResumable(ctx, fetch, w, opts...)
+}
+{{end}}
+{{end}}{{/* .IsProxy */}}
 {{end}}{{/* operations */}}
 
 {{/* Generate parse functions for responses*/}}
-{{range .}}{{$opid := .OperationId}}
+{{range .}}{{$opid := .OperationId}}{{if not .IsProxy}}
 
 // Parse{{genResponseTypeName $opid | ucFirst}} parses an HTTP response from a {{$opid}}WithResponse call
 func Parse{{genResponseTypeName $opid | ucFirst}}(rsp *http.Response) (*{{genResponseTypeName $opid}}, error) {
@@ -368,15 +831,40 @@ func Parse{{genResponseTypeName $opid | ucFirst}}(rsp *http.Response) (*{{genRes
 
     response := {{genResponsePayload $opid}}
 
-    {{genResponseUnmarshal .}}
+    {{genResponseContentTypeCheck .}}
 
+    {{genResponseUnmarshal .}}
+{{if .HasPartialContent}}
+    if rsp.StatusCode == http.StatusPartialContent {
+        response.PartialContent, err = runtime.NewPartialContent(rsp, bodyBytes)
+        if err != nil {
+            return nil, err
+        }
+    }
+{{end}}
+{{- if (opts).TypedErrors}}
+{{- range .ErrorResponses}}
+    if {{.Condition}} {
+        return response, {{.TypeName}}{StatusCode: rsp.StatusCode, Body: *response.{{.FieldName}}}
+    }
+{{- end}}
+{{- end}}
     return response, nil
 }
+{{end}}{{/* not .IsProxy */}}
 {{end}}{{/* range . $opid := .OperationId */}}
 
 `,
-	"client.tmpl": `// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(req *http.Request, ctx context.Context) error
+	"client.tmpl": `// RequestEditorFn  is the function signature for the RequestEditor callback function.
+// ctx is the context passed to the client method, so that editors, such as the
+// Intercept method of security providers, can read per-request values from it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback
+// function. It's called with the response of the server before it's returned
+// or parsed, and may replace its body, eg, to decrypt it or unwrap it from an
+// envelope. ctx is the context passed to the client method.
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
 
 // Doer performs HTTP requests.
 //
@@ -386,6 +874,10 @@ type HttpRequestDoer interface {
 }
 
 // Client which conforms to the OpenAPI3 specification for this service.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its fields are
+// set once, by NewClient and its options, and must not be modified afterwards;
+// use Clone to derive a client with different settings.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
 	// https://api.deepmap.com for example.
@@ -395,9 +887,21 @@ type Client struct {
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
 
-	// A callback for modifying requests which are generated before sending over
-	// the network.
-	RequestEditor RequestEditorFn
+	// Callbacks for modifying requests which are generated before sending over
+	// the network. They're called in order, before those passed to the call,
+	// and the first error aborts the request.
+	RequestEditors []RequestEditorFn
+
+	// Callbacks for processing responses as soon as they're received. They're
+	// called in order, before those passed to the call, and the first error
+	// makes the call fail.
+	ResponseEditors []ResponseEditorFn
+
+	// Request editors attaching the credentials of security schemes, by the
+	// name of the scheme in the spec. When there are any, each call gets
+	// those of the first security requirement of its operation which they
+	// all satisfy, before the other editors.
+	SecurityProviders map[string]RequestEditorFn
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -422,6 +926,29 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
     return &client, nil
 }
 
+// Clone returns a copy of c with the given options applied on top of its
+// settings. c itself is left unchanged, so it's safe to clone a client which
+// is in use by other goroutines.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+    client := *c
+    // Editors added to the clone mustn't share the array of c.
+    client.RequestEditors = append([]RequestEditorFn(nil), c.RequestEditors...)
+    client.ResponseEditors = append([]ResponseEditorFn(nil), c.ResponseEditors...)
+    client.SecurityProviders = make(map[string]RequestEditorFn, len(c.SecurityProviders))
+    for scheme, provider := range c.SecurityProviders {
+        client.SecurityProviders[scheme] = provider
+    }
+    for _, o := range opts {
+        if err := o(&client); err != nil {
+            return nil, err
+        }
+    }
+    if client.Client == nil {
+        client.Client = http.DefaultClient
+    }
+    return &client, nil
+}
+
 // WithHTTPClient allows overriding the default Doer, which is
 // automatically created using http.Client. This is useful for tests.
 func WithHTTPClient(doer HttpRequestDoer) ClientOption {
@@ -433,13 +960,179 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
+// It's added after the editors which the client already has.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return WithRequestEditors(fn)
+}
+
+// WithRequestEditors adds callback functions, which will be called in order
+// right before sending every request, after the editors which the client
+// already has. Authentication, tracing and custom headers can each be set by
+// their own editor.
+func WithRequestEditors(editors ...RequestEditorFn) ClientOption {
 	return func(c *Client) error {
-		c.RequestEditor = fn
+		c.RequestEditors = append(c.RequestEditors, editors...)
 		return nil
 	}
 }
 
+// WithResponseEditorFn adds a callback function, which will be called with
+// every response, after the editors which the client already has.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return WithResponseEditors(fn)
+}
+
+// WithResponseEditors adds callback functions, which will be called in order
+// with every response, after the editors which the client already has.
+// Logging, decryption and signature checks can each be done by their own
+// editor.
+func WithResponseEditors(editors ...ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, editors...)
+		return nil
+	}
+}
+
+// WithSecurityProvider sets the provider of the credentials of a security
+// scheme, such as the Intercept method of a securityprovider.SecurityProvider.
+// The security requirements of each operation decide which providers apply to
+// its calls: the first requirement whose schemes all have providers is used,
+// so that operations accepting either an API key or a token, for instance,
+// get whichever the client has, and operations requiring both get both.
+func WithSecurityProvider(scheme string, provider RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		if c.SecurityProviders == nil {
+			c.SecurityProviders = map[string]RequestEditorFn{}
+		}
+		c.SecurityProviders[scheme] = provider
+		return nil
+	}
+}
+
+// responseEditorsKey is the context key of the response editors of a call.
+type responseEditorsKey struct{}
+
+// EditResponse returns a request editor which makes a call apply editors to
+// its response, after those of the client, eg:
+//
+//     client.GetPet(ctx, id, EditResponse(verifySignature))
+func EditResponse(editors ...ResponseEditorFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		previous, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+		editors := append(append([]ResponseEditorFn(nil), previous...), editors...)
+		*req = *req.WithContext(context.WithValue(req.Context(), responseEditorsKey{}, editors))
+		return nil
+	}
+}
+
+// applyEditors calls the security providers which satisfy the security
+// requirements of the operation, then the editors of the client, then those
+// passed to the call, stopping at the first error.
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) error {
+    if len(c.SecurityProviders) != 0 {
+        requirement, err := security.Select(func(scheme string) bool {
+            _, found := c.SecurityProviders[scheme]
+            return found
+        })
+        if err != nil {
+            return err
+        }
+        for _, scheme := range requirement.Schemes() {
+            if err := c.SecurityProviders[scheme](ctx, req); err != nil {
+                return err
+            }
+        }
+    }
+    for _, r := range c.RequestEditors {
+        if err := r(ctx, req); err != nil {
+            return err
+        }
+    }
+    for _, r := range additionalEditors {
+        if err := r(ctx, req); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// do sends req with the context of the call, after applying the security
+// providers and the request editors, and applies the response editors to the
+// response.
+// Nothing is sent once ctx is done, and reading the bodies of the request and
+// of the response fails as soon as it is, whatever the Doer, so that a
+// cancelled call doesn't hold a goroutine on a slow server.
+func (c *Client) do(ctx context.Context, req *http.Request, security runtime.SecurityRequirements, additionalEditors []RequestEditorFn) (*http.Response, error) {
+    req = req.WithContext(ctx)
+    if err := c.applyEditors(ctx, req, security, additionalEditors); err != nil {
+        return nil, err
+    }
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+    if req.Body != nil && req.Body != http.NoBody {
+        req.Body = runtime.NewContextReadCloser(ctx, req.Body)
+    }
+    rsp, err := c.Client.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    if rsp.Body != nil {
+        rsp.Body = runtime.NewContextReadCloser(ctx, rsp.Body)
+    }
+    additionalResponseEditors, _ := req.Context().Value(responseEditorsKey{}).([]ResponseEditorFn)
+    if err := c.applyResponseEditors(ctx, rsp, additionalResponseEditors); err != nil {
+        if rsp.Body != nil {
+            rsp.Body.Close()
+        }
+        return nil, err
+    }
+    return rsp, nil
+}
+
+// applyResponseEditors calls the response editors of the client, then those
+// of the call, stopping at the first error.
+func (c *Client) applyResponseEditors(ctx context.Context, rsp *http.Response, additionalEditors []ResponseEditorFn) error {
+    for _, r := range c.ResponseEditors {
+        if err := r(ctx, rsp); err != nil {
+            return err
+        }
+    }
+    for _, r := range additionalEditors {
+        if err := r(ctx, rsp); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// Warmup establishes n connections to the server ahead of the first calls,
+// so that these don't pay for the TCP and TLS handshakes, eg, right after a
+// deploy. It sends n concurrent HEAD requests to the server URL, or the
+// request of runtime.WithWarmupRequest, such as a cheap operation, with the
+// request editors of the client, and holds their responses until all of them
+// have arrived, so that each takes a connection of its own. The transport of
+// the Doer keeps up to its MaxIdleConnsPerHost of them, which is only 2 by
+// default for http.Transport.
+func (c *Client) Warmup(ctx context.Context, n int, opts ...runtime.WarmupOption) error {
+    send := func(ctx context.Context, method, path string) (*http.Response, error) {
+        warmupUrl, err := url.Parse(c.Server)
+        if err != nil {
+            return nil, err
+        }
+        warmupUrl, err = warmupUrl.Parse(path)
+        if err != nil {
+            return nil, err
+        }
+        req, err := http.NewRequest(method, warmupUrl.String(), nil)
+        if err != nil {
+            return nil, err
+        }
+        return c.do(ctx, req, nil, nil)
+    }
+    return runtime.Warmup(ctx, n, send, opts...)
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 {{range . -}}
@@ -447,10 +1140,14 @@ type ClientInterface interface {
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
     // {{$opid}} request {{if .HasBody}} with any body{{end}}
-    {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Response, error)
+    {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors ...RequestEditorFn) (*http.Response, error)
 {{range .Bodies}}
-    {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody) (*http.Response, error)
+    {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 {{end}}{{/* range .Bodies */}}
+{{- if .HasPartialContent}}
+    // {{$opid}}Range request for a byte range of the content
+    {{$opid}}Range(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, byteRange runtime.ByteRange, reqEditors ...RequestEditorFn) (*http.Response, error)
+{{end}}
 {{end}}{{/* range . $opid := .OperationId */}}
 }
 
@@ -460,38 +1157,35 @@ type ClientInterface interface {
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
+{{$security := or .SecurityLiteral "nil" -}}
 
-func (c *Client) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Response, error) {
+func (c *Client) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors ...RequestEditorFn) (*http.Response, error) {
     req, err := New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(c.Server{{genParamNames .PathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
     if err != nil {
         return nil, err
     }
-    req = req.WithContext(ctx)
-    if c.RequestEditor != nil {
-        err = c.RequestEditor(req, ctx)
-        if err != nil {
-            return nil, err
-        }
-    }
-    return c.Client.Do(req)
+    return c.do(ctx, req, {{$security}}, reqEditors)
 }
 
 {{range .Bodies}}
-func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody) (*http.Response, error) {
+func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
     req, err := New{{$opid}}{{.Suffix}}Request(c.Server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
     if err != nil {
         return nil, err
     }
-    req = req.WithContext(ctx)
-    if c.RequestEditor != nil {
-        err = c.RequestEditor(req, ctx)
-        if err != nil {
-            return nil, err
-        }
-    }
-    return c.Client.Do(req)
+    return c.do(ctx, req, {{$security}}, reqEditors)
 }
 {{end}}{{/* range .Bodies */}}
+{{if .HasPartialContent}}
+func (c *Client) {{$opid}}Range(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, byteRange runtime.ByteRange, reqEditors ...RequestEditorFn) (*http.Response, error) {
+    req, err := New{{$opid}}Request(c.Server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}})
+    if err != nil {
+        return nil, err
+    }
+    runtime.SetRange(req, byteRange)
+    return c.do(ctx, req, {{$security}}, reqEditors)
+}
+{{end}}
 {{end}}
 
 {{/* Generate request builders */}}
@@ -638,10 +1332,370 @@ func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamAr
 }
 
 {{end}}{{/* Range */}}
+`,
+	"compose.tmpl": `{{range .}}{{if .Composition}}{{$opid := .OperationId}}{{$hasParams := .RequiresParamObject}}{{$pathParams := .PathParams}}{{with .Composition}}
+// Compose{{$opid}} calls {{.OperationIds}} concurrently, passing on the
+// parameters of {{$opid}}, and merges their responses into its response, as
+// declared with x-compose. The first call to fail cancels the others, and its
+// error is returned as a *runtime.FanOutError, named after the property.
+func (c *ClientWithResponses) Compose{{$opid}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, reqEditors ...RequestEditorFn) (*{{.ModelType}}, error) {
+    var result {{.ModelType}}
+    err := runtime.FanOut(ctx,
+{{- range .Parts}}
+        runtime.FanOutCall{Name: "{{.Property.JsonFieldName}}", Call: func(ctx context.Context) error {
+            rsp, err := c.{{.OperationId}}WithResponse(ctx{{.Args}}, reqEditors...)
+            if err != nil {
+                return err
+            }
+            if rsp.{{.FieldName}} == nil {
+                return fmt.Errorf("unexpected response to {{.SpecOperationId}}: %s", rsp.Status())
+            }
+            result.{{.Property.GoFieldName}} = {{if not .Property.IsPointer}}*{{end}}rsp.{{.FieldName}}
+            return nil
+        }},
+{{- end}}
+    )
+    if err != nil {
+        return nil, err
+    }
+    return &result, nil
+}
+{{end}}{{end}}{{end}}
+`,
+	"deprecation.tmpl": `// DeprecatedOperations describes the operations marked deprecated in the
+// spec, by operation ID.
+var DeprecatedOperations = map[string]runtime.DeprecatedOperation{
+{{- range .Operations}}
+    {{printf "%q" .OperationId}}: {
+        OperationID: {{printf "%q" .OperationId}},
+        Method:      {{printf "%q" .Method}},
+        Path:        {{printf "%q" .Path}},
+{{- if not .Sunset.IsZero}}
+        Sunset:      time.Date({{.SunsetDate}}),
+{{- end}}
+    },
+{{- end}}
+}
+{{if .Echo}}
+// DeprecationMiddleware returns an echo middleware with which tracker counts
+// the requests to the deprecated operations, and sets the headers of their
+// responses.
+func DeprecationMiddleware(tracker *runtime.DeprecationTracker) echo.MiddlewareFunc {
+    return runtime.DeprecationMiddleware(DeprecatedOperations, tracker)
+}
+{{end}}
+{{- if .Chi}}
+// DeprecationHandler wraps the handler of the operations, so that tracker
+// counts the requests to the deprecated operations, and sets the headers of
+// their responses.
+func DeprecationHandler(tracker *runtime.DeprecationTracker, next http.Handler) http.Handler {
+    return runtime.DeprecationHandler(DeprecatedOperations, tracker, next)
+}
+{{end}}
+`,
+	"encrypted-fields.tmpl": `// EncryptedFieldCipher encrypts the properties marked with x-encrypted when
+// they're marshaled, and decrypts them when they're unmarshaled. It has to be
+// set before any of their types is.
+var EncryptedFieldCipher runtime.FieldCipher
+{{range .}}{{$type := .TypeName}}
+// MarshalJSON encrypts the {{range $i, $p := .Schema.EncryptedProperties}}{{if $i}}, {{end}}{{$p.JsonFieldName}}{{end}} properties of {{$type}} with EncryptedFieldCipher.
+func (a {{$type}}) MarshalJSON() ([]byte, error) {
+    type plain {{$type}}
+    var err error
+    object := struct {
+        plain
+{{- range .Schema.EncryptedProperties}}
+        {{.RawMessageField true}}
+{{- end}}
+    }{plain: plain(a)}
+{{range .Schema.EncryptedProperties}}
+{{- if and .HasOmitEmpty (or .IsPointer .Schema.SkipOptionalPointer)}}
+    if a.{{.GoFieldName}} != nil {
+        object.{{.GoFieldName}}, err = runtime.EncryptField(EncryptedFieldCipher, "{{$type}}.{{.JsonFieldName}}", a.{{.GoFieldName}})
+        if err != nil {
+            return nil, errors.Wrap(err, "error encrypting '{{.JsonFieldName}}'")
+        }
+    }
+{{- else}}
+    object.{{.GoFieldName}}, err = runtime.EncryptField(EncryptedFieldCipher, "{{$type}}.{{.JsonFieldName}}", a.{{.GoFieldName}})
+    if err != nil {
+        return nil, errors.Wrap(err, "error encrypting '{{.JsonFieldName}}'")
+    }
+{{- end}}
+{{- end}}
+    return json.Marshal(object)
+}
+
+{{if not .Schema.TracksPresence -}}
+// UnmarshalJSON decrypts the {{range $i, $p := .Schema.EncryptedProperties}}{{if $i}}, {{end}}{{$p.JsonFieldName}}{{end}} properties of {{$type}} with EncryptedFieldCipher.
+func (a *{{$type}}) UnmarshalJSON(b []byte) error {
+    type plain {{$type}}
+    object := struct {
+        *plain
+{{- range .Schema.EncryptedProperties}}
+        {{.RawMessageField false}}
+{{- end}}
+    }{plain: (*plain)(a)}
+    if err := json.Unmarshal(b, &object); err != nil {
+        return err
+    }
+{{- range .Schema.EncryptedProperties}}
+    if object.{{.GoFieldName}} != nil {
+        if err := runtime.DecryptField(EncryptedFieldCipher, "{{$type}}.{{.JsonFieldName}}", object.{{.GoFieldName}}, &a.{{.GoFieldName}}); err != nil {
+            return errors.Wrap(err, "error decrypting '{{.JsonFieldName}}'")
+        }
+    }
+{{- end}}
+    return nil
+}
+{{end -}}
+{{end}}
+`,
+	"error-types.tmpl": `{{range .}}{{$type := .TypeName}}
+// {{$type}} is returned by the Parse functions of the client, along with the
+// response, for {{if eq .ResponseName "default"}}4xx and 5xx responses matching the default one{{else}}{{.ResponseName}} responses{{end}} of the spec.
+type {{$type}} struct {
+    StatusCode int
+    Body       {{.BodyType}}
+}
+
+// Error returns the status of the response{{if .Message}}, followed by the message of its body{{end}}.
+func (e {{$type}}) Error() string {
+{{- if .Message}}
+    {{- if .Message.IsPointer}}
+    if e.Body.{{.Message.GoFieldName}} != nil {
+        return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), *e.Body.{{.Message.GoFieldName}})
+    }
+    {{- else}}
+    if e.Body.{{.Message.GoFieldName}} != "" {
+        return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body.{{.Message.GoFieldName}})
+    }
+    {{- end}}
+{{- end}}
+    return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether target is a {{$type}}{{if .Code}} with the same code. A target
+// without code matches any {{$type}}{{end}}.
+func (e {{$type}}) Is(target error) bool {
+{{- if .Code}}
+    t, ok := target.({{$type}})
+    if !ok {
+        return false
+    }
+    {{- if .Code.IsPointer}}
+    return t.Body.{{.Code.GoFieldName}} == nil || (e.Body.{{.Code.GoFieldName}} != nil && *t.Body.{{.Code.GoFieldName}} == *e.Body.{{.Code.GoFieldName}})
+    {{- else}}
+    return t.Body.{{.Code.GoFieldName}} == {{.CodeZero}} || t.Body.{{.Code.GoFieldName}} == e.Body.{{.Code.GoFieldName}}
+    {{- end}}
+{{- else}}
+    _, ok := target.({{$type}})
+    return ok
+{{- end}}
+}
+{{end}}
+`,
+	"example-tests.tmpl": `// TestSpecExamples checks that the examples in the spec survive a round trip
+// through the generated types. Each example is unmarshaled into its type and
+// marshaled back, and the result has to be equal to the example, which
+// catches drift between the spec and the generated types, such as a wrong
+// field name or a missing omitempty.
+func TestSpecExamples(t *testing.T) {
+    tests := []struct {
+        name    string
+        example string
+        value   interface{}
+    }{
+{{- range .}}
+        {
+            name:    {{printf "%q" .Name}},
+            example: {{.Literal}},
+            value:   new({{.TypeDecl}}),
+        },
+{{- end}}
+    }
+
+    for _, tt := range tests {
+        tt := tt
+        t.Run(tt.name, func(t *testing.T) {
+            err := json.Unmarshal([]byte(tt.example), tt.value)
+            if err != nil {
+                t.Fatalf("error unmarshaling example into %T: %s", tt.value, err)
+            }
+            marshaled, err := json.Marshal(tt.value)
+            if err != nil {
+                t.Fatalf("error marshaling %T: %s", tt.value, err)
+            }
+
+            var expected, actual interface{}
+            if err := json.Unmarshal([]byte(tt.example), &expected); err != nil {
+                t.Fatalf("error unmarshaling example: %s", err)
+            }
+            if err := json.Unmarshal(marshaled, &actual); err != nil {
+                t.Fatalf("error unmarshaling marshaled %T: %s", tt.value, err)
+            }
+            if !reflect.DeepEqual(expected, actual) {
+                t.Errorf("example doesn't survive a round trip through %T\nexample:    %s\nmarshaled:  %s",
+                    tt.value, tt.example, marshaled)
+            }
+        })
+    }
+}
+`,
+	"filters.tmpl": `{{range .}}
+// {{.Name}}Fields are the fields which the expressions of the {{.ParamName}}
+// parameter compare. Filters built from them, and combined with runtime.And
+// and runtime.Or, are set as the parameter with Set{{.FieldName}}.
+var {{.Name}}Fields = struct {
+{{- range .Fields}}
+    {{.GoName}} {{.Type}}
+{{- end}}
+}{
+{{- range .Fields}}
+    {{.GoName}}: {{.Type}}{Name: "{{.Name}}"},
+{{- end}}
+}
+
+// Set{{.FieldName}} sets the {{.ParamName}} parameter to filter, in the {{.Syntax}} grammar.
+func (p *{{.ParamsType}}) Set{{.FieldName}}(filter runtime.Filter) {
+    value := {{if eq .TypeDecl "string"}}filter.Format({{.Grammar}}){{else}}{{.TypeDecl}}(filter.Format({{.Grammar}})){{end}}
+    p.{{.FieldName}} = {{if .Pointer}}&{{end}}value
+}
+{{end}}
+`,
+	"fuzz-tests.tmpl": `// The fuzz targets send requests to the ServerInterface which newFuzzServer
+// returns, through the echo server of the package. newFuzzServer has to be
+// defined in a test file of the package:
+//
+//	func newFuzzServer(tb testing.TB) ServerInterface
+//
+// Requests are also sent through the request validator, with the spec of
+// GetSwagger, so the spec has to be embedded in the package.
+func fuzzEchos(tb testing.TB) (plain, validating *echo.Echo) {
+    swagger, err := GetSwagger()
+    if err != nil {
+        tb.Fatalf("error loading the spec: %s", err)
+    }
+    // The servers of the spec would have to match the host of the requests.
+    swagger.Servers = nil
+
+    server := newFuzzServer(tb)
+    plain = echo.New()
+    RegisterHandlers(plain, server)
+    validating = echo.New()
+    validating.Use(middleware.OapiRequestValidator(swagger))
+    RegisterHandlers(validating, server)
+    return plain, validating
+}
+{{range .}}
+// Fuzz{{.OperationId}} mutates a request which is valid according to the spec,
+// to find the inputs which make the server panic in {{.OperationId}}.
+func Fuzz{{.OperationId}}(f *testing.F) {
+    plain, validating := fuzzEchos(f)
+    for _, validate := range []bool{false, true} {
+        f.Add(validate, uint64(0){{with .Seed}}, {{.}}{{end}})
+    }
+    f.Fuzz(func(t *testing.T, validate bool, mutation uint64{{with .Params}}, {{.}}{{end}}) {
+        req := runtime.NewFuzzRequest("{{.Method}}", "{{.Path}}", map[string]string{
+{{- range .PathParams}}
+            "{{.ParamName}}": {{.GoVariableName}},
+{{- end}}
+        }, {{if .HasQuery}}rawQuery{{else}}""{{end}}, {{if .ContentType}}rawBody{{else}}nil{{end}}, mutation)
+{{- range .HeaderParams}}
+        req.Header.Set("{{.ParamName}}", {{.GoVariableName}})
+{{- end}}
+{{- if .ContentType}}
+        req.Header.Set("Content-Type", "{{.ContentType}}")
+{{- end}}
+        e := plain
+        if validate {
+            e = validating
+        }
+        e.ServeHTTP(httptest.NewRecorder(), req)
+    })
+}
+{{end}}
+`,
+	"gateway-kong.tmpl": `# Kong declarative configuration of the routes of {{.Service}}.
+#
+# Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+_format_version: "3.0"
+services:
+  - name: {{printf "%q" .Service}}
+    url: {{printf "%q" .Upstream}}
+    routes:
+{{- range .Routes}}
+      - name: {{printf "%q" (print $.Service "-" .Name)}}
+        methods: [{{printf "%q" .Method}}]
+        paths: [{{printf "%q" (print "~" .Regex)}}]
+        regex_priority: {{.Literal}}
+        strip_path: false
+{{- with .Auth}}
+{{- if eq .Type "api-key"}}
+{{- if eq .In "cookie"}}
+        # Not checked by the gateway: the {{.Scheme}} API key cookie.
+{{- else}}
+        plugins:
+          - name: key-auth
+            config:
+              key_names: [{{printf "%q" .Name}}]
+              key_in_header: {{eq .In "header"}}
+              key_in_query: {{eq .In "query"}}
+              key_in_body: false
+{{- end}}
+{{- else if eq .Type "basic"}}
+        plugins:
+          - name: basic-auth
+{{- else}}
+        plugins:
+          - name: jwt
+{{- end}}
+{{- end}}
+{{- if .Schemes}}
+        # Not checked by the gateway: any of the security requirements of {{range $i, $s := .Schemes}}{{if $i}}, {{end}}{{$s}}{{end}}.
+{{- end}}
+{{- end}}
+`,
+	"gateway-nginx.tmpl": `# NGINX locations routing the requests of {{.Service}} to {{.Upstream}}.
+# Include them in a server block.
+#
+# Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+{{range .Locations}}
+location ~ "^{{.Regex}}" {
+    if ($request_method !~ "^({{range $i, $m := .Methods}}{{if $i}}|{{end}}{{$m}}{{end}})$") {
+        return 405;
+    }
+{{- with .Auth}}
+{{- if eq .Type "api-key"}}
+    if (${{.NginxVariable}} = "") {
+        return 401;
+    }
+{{- else if eq .Type "basic"}}
+    if ($http_authorization !~* "^Basic ") {
+        return 401;
+    }
+{{- else}}
+    if ($http_authorization !~* "^Bearer ") {
+        return 401;
+    }
+{{- end}}
+{{- end}}
+{{- if .Schemes}}
+    # Not checked by the gateway: the security requirements of {{range $i, $s := .Schemes}}{{if $i}}, {{end}}{{$s}}{{end}}.
+{{- end}}
+    proxy_pass {{$.Upstream}};
+}
+{{end -}}
 `,
 	"imports.tmpl": `// Package {{.PackageName}} provides primitives to interact the openapi HTTP API.
 //
 // Code generated by github.com/shawnhankim/oapi-codegen DO NOT EDIT.
+{{- if .CommandLine}}
+//
+// To regenerate this code, run:
+//
+//	{{.CommandLine}}
+{{- end}}
 package {{.PackageName}}
 
 {{if .Imports}}
@@ -650,9 +1704,108 @@ import (
 {{end}})
 {{end}}
 `,
-	"inline.tmpl": `// Base64 encoded, gzipped, json marshaled Swagger object
+	"inline.tmpl": `{{if .Shards -}}
+// Base64 encoded, gzipped, json marshaled shards of the Swagger object. The
+// shard of the empty tag holds everything but the tagged operations, and the
+// others the paths of the operations whose first tag they are.
+var swaggerSpecShards = map[string][]string{
+{{range .Shards}}
+    {{printf "%q" .Tag}}: {
+{{- range .Parts}}
+        "{{.}}",{{end}}
+    },
+{{- end}}
+}
+
+// decodeSwaggerSpecShard decompresses a shard of the Swagger specification.
+func decodeSwaggerSpecShard(shard []string) ([]byte, error) {
+    zipped, err := base64.StdEncoding.DecodeString(strings.Join(shard, ""))
+    if err != nil {
+        return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+    }
+    zr, err := gzip.NewReader(bytes.NewReader(zipped))
+    if err != nil {
+        return nil, fmt.Errorf("error decompressing spec: %s", err)
+    }
+    var buf bytes.Buffer
+    _, err = buf.ReadFrom(zr)
+    if err != nil {
+        return nil, fmt.Errorf("error decompressing spec: %s", err)
+    }
+    return buf.Bytes(), nil
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file.
+func GetSwagger() (*openapi3.Swagger, error) {
+    var tags []string
+    for tag := range swaggerSpecShards {
+        if tag != "" {
+            tags = append(tags, tag)
+        }
+    }
+    return GetSwaggerForTags(tags...)
+}
+
+// GetSwaggerForTags returns the Swagger specification with the untagged
+// operations, and those whose first tag is one of tags. Only the shards of
+// these tags are decompressed, which bounds the memory used for loading a
+// large specification when only a part of it is needed, eg, for validating
+// the requests of a server which implements a few tags.
+func GetSwaggerForTags(tags ...string) (*openapi3.Swagger, error) {
+    data, err := decodeSwaggerSpecShard(swaggerSpecShards[""])
+    if err != nil {
+        return nil, err
+    }
+    var doc map[string]interface{}
+    if err := json.Unmarshal(data, &doc); err != nil {
+        return nil, fmt.Errorf("error unmarshaling spec: %s", err)
+    }
+    paths, _ := doc["paths"].(map[string]interface{})
+    if paths == nil {
+        paths = make(map[string]interface{})
+        doc["paths"] = paths
+    }
+
+    for _, tag := range tags {
+        shard, found := swaggerSpecShards[tag]
+        if !found || tag == "" {
+            continue
+        }
+        data, err := decodeSwaggerSpecShard(shard)
+        if err != nil {
+            return nil, err
+        }
+        var tagPaths map[string]map[string]interface{}
+        if err := json.Unmarshal(data, &tagPaths); err != nil {
+            return nil, fmt.Errorf("error unmarshaling spec for tag '%s': %s", tag, err)
+        }
+        for path, ops := range tagPaths {
+            pathItem, _ := paths[path].(map[string]interface{})
+            if pathItem == nil {
+                pathItem = make(map[string]interface{})
+                paths[path] = pathItem
+            }
+            for method, op := range ops {
+                pathItem[method] = op
+            }
+        }
+    }
+
+    data, err = json.Marshal(doc)
+    if err != nil {
+        return nil, fmt.Errorf("error marshaling spec: %s", err)
+    }
+    swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(data)
+    if err != nil {
+        return nil, fmt.Errorf("error loading Swagger: %s", err)
+    }
+    return swagger, nil
+}
+{{else -}}
+// Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
-{{range .}}
+{{range .Parts}}
     "{{.}}",{{end}}
 }
 
@@ -679,13 +1832,134 @@ func GetSwagger() (*openapi3.Swagger, error) {
     }
     return swagger, nil
 }
+{{end -}}
+`,
+	"manifest.tmpl": `// OperationsManifest describes the operations of this API, and the spec which
+// they were generated from.
+var OperationsManifest = runtime.Manifest{
+    SpecVersion: {{printf "%q" .SpecVersion}},
+    SpecHash:    {{printf "%q" .SpecHash}},
+    Operations: []runtime.ManifestOperation{
+{{- range .Operations}}
+        {OperationID: {{printf "%q" .SpecOperationId}}, Method: {{printf "%q" .Method}}, Path: {{printf "%q" .Path}}{{if .Spec.Tags}}, Tags: []string{ {{- range $i, $tag := .Spec.Tags}}{{if $i}}, {{end}}{{printf "%q" $tag}}{{end -}} }{{end}}},
+{{- end}}
+    },
+}
+
+// OperationsManifestHandler returns a handler which serves OperationsManifest
+// as JSON, for example on GET /.well-known/operations.
+func OperationsManifestHandler() http.Handler {
+    return runtime.ManifestHandler(OperationsManifest)
+}
+
 `,
 	"param-types.tmpl": `{{range .}}{{$opid := .OperationId}}
 {{range .TypeDefinitions}}
 // {{.TypeName}} defines parameters for {{$opid}}.
-type {{.TypeName}} {{.Schema.TypeDecl}}
+type {{.TypeName}} {{if .Schema.DefineViaAlias}}= {{end}}{{.Schema.TypeDecl}}
+{{end}}
+{{end}}
+`,
+	"presence.tmpl": `{{range .}}{{$type := .TypeName}}{{$properties := printf "%sProperties" (lcFirst .TypeName)}}
+// {{$properties}} are the JSON names of the properties of {{$type}}, in the
+// order of the bits of its presence set.
+var {{$properties}} = []string{ {{- range $i, $p := .Schema.Properties}}{{if $i}}, {{end}}"{{$p.JsonFieldName}}"{{end -}} }
+
+// IsSet returns whether the property of {{$type}} with the given JSON name
+// was present in the JSON it was unmarshaled from, even as null, or was marked
+// with MarkSet.
+func (a {{$type}}) IsSet(name string) bool {
+    return runtime.FieldIsSet(a.presence[:], {{$properties}}, name{{range .Schema.EmbeddedFields}}, a.{{.}}{{end}})
+}
+
+// SetFields returns the JSON names of the properties of {{$type}} which are
+// set, in the order of the spec.
+func (a {{$type}}) SetFields() []string {
+    return runtime.SetFields(a.presence[:], {{$properties}}{{range .Schema.EmbeddedFields}}, a.{{.}}{{end}})
+}
+
+// MarkSet marks the properties of {{$type}} with the given JSON names as set,
+// for values which aren't unmarshaled.
+func (a *{{$type}}) MarkSet(names ...string) error {
+    return runtime.MarkFields(a.presence[:], {{$properties}}, names{{range .Schema.EmbeddedFields}}, &a.{{.}}{{end}})
+}
+{{if not .Schema.HasAdditionalProperties}}
+// UnmarshalJSON unmarshals {{$type}}, recording which properties are present.
+func (a *{{$type}}) UnmarshalJSON(b []byte) error {
+{{- range .Schema.EmbeddedFields}}
+    if err := json.Unmarshal(b, &a.{{.}}); err != nil {
+        return err
+    }
+{{- end}}
+{{- if .Schema.Properties}}
+    object := make(map[string]json.RawMessage)
+    if err := json.Unmarshal(b, &object); err != nil {
+        return err
+    }
+{{range $i, $p := .Schema.Properties}}
+    if raw, found := object["{{.JsonFieldName}}"]; found {
+        {{if .Encrypted -}}
+        err := runtime.DecryptField(EncryptedFieldCipher, "{{$type}}.{{.JsonFieldName}}", raw, &a.{{.GoFieldName}})
+        {{- else -}}
+        err := json.Unmarshal(raw, &a.{{.GoFieldName}})
+        {{- end}}
+        if err != nil {
+            return errors.Wrap(err, "error reading '{{.JsonFieldName}}'")
+        }
+        runtime.MarkField(a.presence[:], {{$i}})
+    }
+{{end}}
+{{- end}}
+    return nil
+}
 {{end}}
 {{end}}
+`,
+	"provenance.tmpl": `// The provenance of this code, which lets builds be traced back to the version
+// of the contract they were generated from.
+const (
+    // GeneratorVersion is the version of oapi-codegen which generated this code.
+    GeneratorVersion = {{printf "%q" .GeneratorVersion}}
+
+    // GeneratorOptions are the options oapi-codegen was run with.
+    GeneratorOptions = {{printf "%q" .GeneratorOptions}}
+
+    // SpecVersion is the version of the OpenAPI spec, from its info object.
+    SpecVersion = {{printf "%q" .SpecVersion}}
+
+    // SpecHash is the SHA-256 digest of the JSON encoding of the OpenAPI spec.
+    SpecHash = {{printf "%q" .SpecHash}}
+)
+
+`,
+	"register-hosts.tmpl": `{{if .}}
+// RegisterHandlersForHosts adds each server route to e for the hosts of the
+// servers of the spec, under their base paths, so that one echo instance can
+// serve several APIs based on the Host header. The middlewares m apply to
+// these routes only. The routes are added for:
+{{- range $host := .}}{{range .BasePaths}}
+//   {{$host.Host}}{{.}}
+{{- end}}{{end}}
+func RegisterHandlersForHosts(e *echo.Echo, si ServerInterface, m ...echo.MiddlewareFunc) {
+    hosts := []struct {
+        host      string
+        basePaths []string
+    }{
+{{- range .}}
+        {{"{"}}{{printf "%q" .Host}}, []string{ {{- range $i, $path := .BasePaths}}{{if $i}}, {{end}}{{printf "%q" $path}}{{end -}} }},
+{{- end}}
+    }
+    for _, h := range hosts {
+        // Routers are per host, so each host is only created once.
+        router := e.Host(h.host)
+        for _, basePath := range h.basePaths {
+            group := router.Group(basePath)
+            group.Use(m...)
+            RegisterHandlers(group, si)
+        }
+    }
+}
+{{end}}
 `,
 	"register.tmpl": `
 
@@ -714,20 +1988,251 @@ func RegisterHandlers(router interface {
 {{range .Bodies}}
 // {{$opid}}RequestBody defines body for {{$opid}} for application/json ContentType.
 type {{$opid}}{{.NameTag}}RequestBody {{.TypeDef}}
+{{if .HashMethod}}
+// {{.HashMethod}} returns the SHA-256 digest of the JSON encoding of the body, which is
+// exactly what the client sends, for use as an idempotency or cache key.
+func (b {{$opid}}{{.NameTag}}RequestBody) {{.HashMethod}}() (string, error) {
+    return runtime.JSONHash(b)
+}
+{{end}}
+{{- if .Encrypted}}
+// MarshalJSON encrypts the body like {{.ValueType}} does.
+func (b {{$opid}}{{.NameTag}}RequestBody) MarshalJSON() ([]byte, error) {
+    return json.Marshal({{.ValueType}}(b))
+}
+{{end}}
+{{- if or .Encrypted .TracksPresence}}
+// UnmarshalJSON {{if .Encrypted}}decrypts the body{{else}}records the properties present in the body{{end}} like {{.ValueType}} does.
+func (b *{{$opid}}{{.NameTag}}RequestBody) UnmarshalJSON(data []byte) error {
+    return json.Unmarshal(data, (*{{.ValueType}})(b))
+}
 {{end}}
+{{- end}}
 {{end}}
+`,
+	"schema-info.tmpl": `// SchemaInfo describes the component schemas of the spec, with their fields,
+// types and validation rules, in a form which programs can walk.
+var SchemaInfo = schemainfo.NewRegistry(
+{{- range .}}
+    {{.}},
+{{- end}}
+)
+
 `,
 	"server-interface.tmpl": `// ServerInterface represents all server handlers.
 type ServerInterface interface {
 {{range .}}{{.SummaryAsComment }}
 // ({{.Method}} {{.Path}})
-{{.OperationId}}(ctx echo.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}) error
+{{.OperationId}}(ctx echo.Context{{if not .IsProxy}}{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}{{end}}) error
 {{end}}
 }
+`,
+	"service-adapter.tmpl": `{{if .}}
+{{range .}}
+// {{.Name}} is an existing business service, which ServiceAdapter delegates
+// operations to, per their x-go-impl extension.
+type {{.Name}} interface {
+{{- range .Operations}}
+    // {{.Impl.Method}} implements {{.OperationId}} ({{.Method}} {{.Path}})
+    {{.Impl.Method}}(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}{{if .Impl.Body}}, body {{.OperationId}}{{.Impl.Body.NameTag}}RequestBody{{end}}) {{if .Impl.ResultType}}({{.Impl.ResultType}}, error){{else}}error{{end}}
+{{- end}}
+}
+{{end}}
+
+// ServiceAdapter implements ServerInterface on top of existing business
+// services, converting the bound parameters and body of each operation with
+// x-go-impl into the arguments of its method, and its results into the
+// success response, or into an error response. Other operations are served
+// by the embedded ServerInterface.
+type ServiceAdapter struct {
+    ServerInterface
+{{range .}}
+    {{.Name}} {{.Name}}
+{{- end}}
+
+    // ErrorHandler, when set, writes the response to the errors returned by
+    // the services. By default, errors implementing runtime.StatusCoder
+    // result in their status, when the operation declares it, and others in
+    // an internal server error. See runtime.ServiceErrorResponse.
+{{- if (opts).GenerateChiServer}}
+    ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+{{- else}}
+    ErrorHandler func(ctx echo.Context, err error) error
+{{- end}}
+}
+{{range .}}{{range .Operations}}{{$opid := .OperationId}}
+{{- if (opts).GenerateChiServer}}
+// {{$opid}} calls {{.Impl.Service}}.{{.Impl.Method}}.
+func (a *ServiceAdapter) {{$opid}}(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+{{- range .PathParams}}
+    {{.GoVariableName}} := ctx.Value("{{.GoVariableName}}").({{.TypeDef}})
+{{- end}}
+{{- if .RequiresParamObject}}
+    params := *ParamsFor{{$opid}}(ctx)
+{{- end}}
+{{- if .Impl.Body}}
+    var body {{$opid}}{{.Impl.Body.NameTag}}RequestBody
+    if err := json.NewDecoder(r.Body).Decode(&body); err != nil{{if not .Impl.Body.Required}} && err != io.EOF{{end}} {
+        http.Error(w, runtime.Message(r, runtime.MsgInvalidBody, err), http.StatusBadRequest)
+        return
+    }
+{{- end}}
+    {{if .Impl.ResultType}}result, {{end}}err := a.{{.Impl.Service}}.{{.Impl.Method}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .Impl.Body}}, body{{end}})
+    if err != nil {
+        a.serviceError(w, r, err, {{.Impl.ErrorStatusesLiteral}}, {{.Impl.HasDefault}})
+        return
+    }
+{{- if .Impl.ResultType}}
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader({{.Impl.Status}})
+    _ = json.NewEncoder(w).Encode(result)
+{{- else}}
+    w.WriteHeader({{.Impl.Status}})
+{{- end}}
+}
+{{else}}
+// {{$opid}} calls {{.Impl.Service}}.{{.Impl.Method}}.
+func (a *ServiceAdapter) {{$opid}}(ctx echo.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{$opid}}Params{{end}}) error {
+{{- if .Impl.Body}}
+    var body {{$opid}}{{.Impl.Body.NameTag}}RequestBody
+    if err := json.NewDecoder(ctx.Request().Body).Decode(&body); err != nil{{if not .Impl.Body.Required}} && err != io.EOF{{end}} {
+        return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(ctx.Request(), runtime.MsgInvalidBody, err))
+    }
+{{- end}}
+    {{if .Impl.ResultType}}result, {{end}}err := a.{{.Impl.Service}}.{{.Impl.Method}}(ctx.Request().Context(){{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .Impl.Body}}, body{{end}})
+    if err != nil {
+        return a.serviceError(ctx, err, {{.Impl.ErrorStatusesLiteral}}, {{.Impl.HasDefault}})
+    }
+{{- if .Impl.ResultType}}
+    return ctx.JSON({{.Impl.Status}}, result)
+{{- else}}
+    return ctx.NoContent({{.Impl.Status}})
+{{- end}}
+}
+{{end}}
+{{- end}}{{end}}
+{{if (opts).GenerateChiServer}}
+// serviceError writes the response to an error returned by a service.
+func (a *ServiceAdapter) serviceError(w http.ResponseWriter, r *http.Request, err error, declared []int, hasDefault bool) {
+    if a.ErrorHandler != nil {
+        a.ErrorHandler(w, r, err)
+        return
+    }
+    status, message := runtime.ServiceErrorResponse(err, declared, hasDefault)
+    http.Error(w, message, status)
+}
+{{else}}
+// serviceError returns the response to an error returned by a service.
+func (a *ServiceAdapter) serviceError(ctx echo.Context, err error, declared []int, hasDefault bool) error {
+    if a.ErrorHandler != nil {
+        return a.ErrorHandler(ctx, err)
+    }
+    status, message := runtime.ServiceErrorResponse(err, declared, hasDefault)
+    return echo.NewHTTPError(status, message)
+}
+{{end}}
+{{- end}}
+`,
+	"slo.tmpl": `// OperationSLOs are the SLOs of the operations, as declared by their x-slo
+// extension, by operation ID.
+var OperationSLOs = map[string]runtime.SLO{
+{{- range .Operations}}
+    {{printf "%q" .OperationId}}: {
+        OperationID:   {{printf "%q" .OperationId}},
+        Method:        {{printf "%q" .Method}},
+        Path:          {{printf "%q" .Path}},
+{{- if .Availability}}
+        Availability:  {{.Availability}},
+{{- end}}
+{{- if .Latency}}
+        Latency:       time.Duration({{.LatencyNanoseconds}}), // {{.Latency}}
+        LatencyTarget: {{.LatencyTarget}},
+{{- end}}
+    },
+{{- end}}
+}
+{{if .Echo}}
+// SLOMiddleware returns an echo middleware which calls record for every
+// request to one of the operations with an SLO.
+func SLOMiddleware(record runtime.SLORecorder) echo.MiddlewareFunc {
+    return runtime.SLOMiddleware(OperationSLOs, record)
+}
+{{end}}
+{{- if .Chi}}
+// SLOHandler wraps the handler of the operations, so that record is called
+// for every request to one of the operations with an SLO.
+func SLOHandler(record runtime.SLORecorder, next http.Handler) http.Handler {
+    return runtime.SLOHandler(OperationSLOs, record, next)
+}
+{{end}}
+// SLOMetricsHandler serves the objectives of OperationSLOs as OpenMetrics
+// gauges.
+func SLOMetricsHandler() http.Handler {
+    return runtime.SLOMetricsHandler(OperationSLOs)
+}
+
+// NewSLOLatencyHistogram returns a histogram of the latencies of the requests
+// to the operations named name, eg, http_request_duration_seconds, whose
+// buckets include the latency thresholds of OperationSLOs. Install it with
+// runtime.LatencyMiddleware or runtime.LatencyHandler to observe the requests
+// to every operation, or pass its RecordSLO method to SLOMiddleware or
+// SLOHandler to only observe those with an SLO. It's served in the OpenMetrics
+// text format, with the trace IDs given by traceID as exemplars.
+func NewSLOLatencyHistogram(name string, traceID runtime.TraceIDFunc) *runtime.LatencyHistogram {
+    return runtime.NewLatencyHistogram(name, runtime.SLOLatencyBuckets(OperationSLOs), traceID)
+}
+
+// SLORecordingRules returns the Prometheus recording rules of the burn rates
+// of OperationSLOs, computed from the given metrics.
+func SLORecordingRules(group string, metrics runtime.SLOMetricNames) string {
+    return runtime.SLORecordingRules(group, OperationSLOs, metrics)
+}
+`,
+	"time-types.tmpl": `{{range .}}
+// {{.TypeName}} is a date-time which is marshaled with the layout {{printf "%q" .Layout}}{{if .UTC}},
+// after conversion to UTC{{end}}.
+type {{.TypeName}} struct {
+    time.Time
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t {{.TypeName}}) MarshalText() ([]byte, error) {
+    return []byte(t.Time{{if .UTC}}.UTC(){{end}}.Format({{printf "%q" .Layout}})), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *{{.TypeName}}) UnmarshalText(data []byte) error {
+    parsed, err := time.Parse({{printf "%q" .Layout}}, string(data))
+    if err != nil {
+        return err
+    }
+    t.Time = parsed{{if .UTC}}.UTC(){{end}}
+    return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t {{.TypeName}}) MarshalJSON() ([]byte, error) {
+    text, err := t.MarshalText()
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *{{.TypeName}}) UnmarshalJSON(data []byte) error {
+    var text string
+    if err := json.Unmarshal(data, &text); err != nil {
+        return err
+    }
+    return t.UnmarshalText([]byte(text))
+}
+{{end}}
 `,
 	"typedef.tmpl": `{{range .Types}}
 // {{.TypeName}} defines model for {{.JsonName}}.
-type {{.TypeName}} {{.Schema.TypeDecl}}
+type {{.TypeName}} {{if .Schema.DefineViaAlias}}= {{end}}{{.Schema.TypeDecl}}
 {{end}}
 `,
 	"wrappers.tmpl": `// ServerInterfaceWrapper converts echo contexts to parameters.
@@ -738,6 +2243,21 @@ type ServerInterfaceWrapper struct {
 {{range .}}{{$opid := .OperationId}}// {{$opid}} converts echo context to params.
 func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var err error
+{{- if (opts).GenerateAudit}}
+    {{if and .AllParams (not .IsProxy)}}auditing := {{end}}runtime.AuditOperation(ctx.Request().Context(), {{printf "%q" .SpecOperationId}})
+{{- end}}
+{{- if (opts).GenerateSLO}}
+    runtime.SLOOperation(ctx.Request().Context(), {{printf "%q" .SpecOperationId}})
+{{- end}}
+{{- if and (opts).GenerateDeprecation .Spec.Deprecated}}
+    runtime.DeprecatedOperationCalled(ctx.Request().Context(), {{printf "%q" .SpecOperationId}})
+{{- end}}
+{{- with .SecurityLiteral}}
+    if err := runtime.CheckSecurity(ctx.Request(), {{.}}); err != nil {
+        return echo.NewHTTPError(http.StatusUnauthorized, runtime.Message(ctx.Request(), runtime.MsgSecurityUnsatisfied)).SetInternal(err)
+    }
+{{- end}}
+{{if not .IsProxy -}}
 {{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
     var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
 {{if .IsPassThrough}}
@@ -746,21 +2266,36 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
 {{if .IsJson}}
     err = json.Unmarshal([]byte(ctx.Param("{{.ParamName}}")), &{{$varName}})
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgUnmarshalParamJSON"}})
     }
 {{end}}
-{{if .IsStyled}}
+{{if .IsSimpleScalar}}
+    if paramValue := ctx.Param("{{.ParamName}}"); paramValue != "" {
+        {{genScalarConversion . "paramValue" $varName}}
+        if err != nil {
+            return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgInvalidParamFormat" "err"}})
+        }
+    } else {
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgEmptyParam"}})
+    }
+{{else if .IsStyled}}
     err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", ctx.Param("{{.ParamName}}"), &{{$varName}})
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgInvalidParamFormat" "err"}})
     }
 {{end}}
 {{end}}
+{{end}}{{/* not .IsProxy */}}
 
 {{range .SecurityDefinitions}}
     ctx.Set("{{.ProviderName}}.Scopes", {{toStringArray .Scopes}})
 {{end}}
 
+{{if .IsProxy}}
+    // Proxied operations get the raw request, without binding any parameter
+    err = w.Handler.{{.OperationId}}(ctx)
+    return err
+{{else}}
 {{if .RequiresParamObject}}
     // Parameter object where we will unmarshal all parameters from the context
     var params {{.OperationId}}Params
@@ -773,17 +2308,26 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var value {{.TypeDef}}
     err = json.Unmarshal([]byte(paramValue), &value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgUnmarshalParamJSON"}})
     }
     params.{{.GoName}} = {{if not .Required}}&{{end}}value
     {{end}}
     }{{if .Required}} else {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgRequiredQueryParam"}})
     }{{end}}
     {{if .IsStyled}}
     err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", ctx.QueryParams(), &params.{{.GoName}})
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgInvalidParamFormat" "err"}})
+    }
+    {{end}}{{if .DefaultValue}}
+    if params.{{.GoName}} == nil {
+        value := {{.DefaultValue}}
+        params.{{.GoName}} = &value
+    }
+    {{end}}{{if .AllowedValues}}
+    if value, found := runtime.NotAllowedValue(params.{{.GoName}}, {{.AllowedValuesLiteral}}); found {
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgParamNotAllowed" "value" .AllowedValuesList}})
     }
     {{end}}
 {{end}}
@@ -795,7 +2339,7 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
         var {{.GoName}} {{.TypeDef}}
         n := len(valueList)
         if n != 1 {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n))
+            return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgParamValueCount" "n"}})
         }
 {{if .IsPassThrough}}
         params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
@@ -803,18 +2347,18 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
 {{if .IsJson}}
         err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
         if err != nil {
-            return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+            return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgUnmarshalParamJSON"}})
         }
 {{end}}
 {{if .IsStyled}}
         err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", valueList[0], &{{.GoName}})
         if err != nil {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+            return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgInvalidParamFormat" "err"}})
         }
 {{end}}
         params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
         } {{if .Required}}else {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found"))
+            return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgRequiredHeaderParam"}})
         }{{end}}
 {{end}}
 {{end}}
@@ -829,11 +2373,11 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var decoded string
     decoded, err := url.QueryUnescape(cookie.Value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter '{{.ParamName}}'")
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgUnescapeCookieParam"}})
     }
     err = json.Unmarshal([]byte(decoded), &value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgUnmarshalParamJSON"}})
     }
     params.{{.GoName}} = {{if not .Required}}&{{end}}value
     {{end}}
@@ -841,20 +2385,33 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var value {{.TypeDef}}
     err = runtime.BindStyledParameter("simple",{{.Explode}}, "{{.ParamName}}", cookie.Value, &value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgInvalidParamFormat" "err"}})
     }
     params.{{.GoName}} = {{if not .Required}}&{{end}}value
     {{end}}
     }{{if .Required}} else {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
+        return echo.NewHTTPError(http.StatusBadRequest, {{genParamMessage . "ctx.Request()" "MsgRequiredCookieParam"}})
     }{{end}}
 
 {{end}}{{/* .CookieParams */}}
 
 {{end}}{{/* .RequiresParamObject */}}
+{{if and (opts).GenerateAudit .AllParams}}
+    if auditing {
+        runtime.AuditParams(ctx.Request().Context(), map[string]interface{}{
+{{- range .PathParams}}
+            "{{.ParamName}}": {{.GoVariableName}},
+{{- end}}
+{{- range .Params}}
+            "{{.ParamName}}": params.{{.GoName}},
+{{- end}}
+        })
+    }
+{{end}}
     // Invoke the callback with all the unmarshalled arguments
     err = w.Handler.{{.OperationId}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
     return err
+{{- end}}{{/* .IsProxy */}}
 }
 {{end}}
 `,
@@ -0,0 +1,153 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// DateTimeTypeName is the name of the type generated for date-time schemas
+// when either of the DateTimeUTC or DateTimeLayout options is set.
+const DateTimeTypeName = "DateTime"
+
+// timeLayoutNames are the layout names accepted in place of a Go time layout.
+var timeLayoutNames = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+}
+
+// TimeTypeDefinition describes a generated date-time type, which marshals its
+// value with a fixed layout instead of the RFC3339Nano one of time.Time.
+type TimeTypeDefinition struct {
+	TypeName string // The Go type name
+	Layout   string // The Go time layout used for marshaling and parsing
+	UTC      bool   // Whether values are converted to UTC
+}
+
+// ResolveTimeLayout turns a layout name, such as "RFC3339", into the Go time
+// layout. Anything else is checked to be a layout which can parse back the
+// times it formats.
+func ResolveTimeLayout(layout string) (string, error) {
+	if named, found := timeLayoutNames[layout]; found {
+		return named, nil
+	}
+	reference := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	if _, err := time.Parse(layout, reference.Format(layout)); err != nil || !strings.ContainsAny(layout, "0123456789") {
+		return "", fmt.Errorf("invalid time layout %q", layout)
+	}
+	return layout, nil
+}
+
+// dateTimeGoType returns the Go type for a date-time schema found at path. It
+// is time.Time unless the schema has a x-go-time-format extension or the
+// date-time options are set, in which case a type with the right layout is
+// registered for generation.
+func dateTimeGoType(schema *openapi3.Schema, path []string) (string, error) {
+	opts := globalState.options
+
+	format, found, err := extString(schema.Extensions, extPropGoTimeFormat)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		if len(path) == 0 {
+			return "", fmt.Errorf("%s is only supported on named schemas", extPropGoTimeFormat)
+		}
+		layout, err := ResolveTimeLayout(format)
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("invalid %s", extPropGoTimeFormat))
+		}
+		return registerTimeType(TimeTypeDefinition{
+			TypeName: SchemaNameToTypeName(strings.Join(path, " ")) + "Time",
+			Layout:   layout,
+			UTC:      opts.DateTimeUTC,
+		})
+	}
+
+	if !opts.DateTimeUTC && opts.DateTimeLayout == "" {
+		return "time.Time", nil
+	}
+	layout := time.RFC3339Nano
+	if opts.DateTimeLayout != "" {
+		layout, err = ResolveTimeLayout(opts.DateTimeLayout)
+		if err != nil {
+			return "", err
+		}
+	}
+	return registerTimeType(TimeTypeDefinition{
+		TypeName: DateTimeTypeName,
+		Layout:   layout,
+		UTC:      opts.DateTimeUTC,
+	})
+}
+
+func registerTimeType(td TimeTypeDefinition) (string, error) {
+	if globalState.timeTypes == nil {
+		globalState.timeTypes = make(map[string]TimeTypeDefinition)
+	}
+	if existing, found := globalState.timeTypes[td.TypeName]; found && existing != td {
+		return "", fmt.Errorf("date-time type %s is defined with both layout %q and %q",
+			td.TypeName, existing.Layout, td.Layout)
+	}
+	globalState.timeTypes[td.TypeName] = td
+	return td.TypeName, nil
+}
+
+// checkTimeTypeNames fails when a date-time type registered while building
+// the Go schemas has the name of another generated type, eg, the one of a
+// component schema named DateTime.
+func checkTimeTypeNames(types []TypeDefinition) error {
+	for _, td := range types {
+		if _, found := globalState.timeTypes[td.TypeName]; found {
+			return fmt.Errorf("date-time type %s has the name of the type generated for %s", td.TypeName, td.JsonName)
+		}
+	}
+	return nil
+}
+
+// GenerateTimeTypes generates the date-time types registered while building
+// the Go schemas.
+func GenerateTimeTypes(t *template.Template) (string, error) {
+	if len(globalState.timeTypes) == 0 {
+		return "", nil
+	}
+	var timeTypes []TimeTypeDefinition
+	for _, td := range globalState.timeTypes {
+		timeTypes = append(timeTypes, td)
+	}
+	sort.Slice(timeTypes, func(i, j int) bool {
+		return timeTypes[i].TypeName < timeTypes[j].TypeName
+	})
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := t.ExecuteTemplate(w, "time-types.tmpl", timeTypes)
+	if err != nil {
+		return "", errors.Wrap(err, "error generating date-time types")
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", errors.Wrap(err, "error flushing output buffer for date-time types")
+	}
+	return buf.String(), nil
+}
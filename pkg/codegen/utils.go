@@ -135,6 +135,12 @@ func SortedResponsesKeys(dict openapi3.Responses) []string {
 	return keys
 }
 
+// IsStatusCodeRange returns whether a key of the responses of an operation is
+// a range of status codes, such as "2XX", rather than a status code.
+func IsStatusCodeRange(key string) bool {
+	return len(key) == 3 && key[0] >= '1' && key[0] <= '5' && strings.EqualFold(key[1:], "XX")
+}
+
 // This returns Content dictionary keys in sorted order
 func SortedContentKeys(dict openapi3.Content) []string {
 	keys := make([]string, len(dict))
@@ -182,6 +188,30 @@ func SortedRequestBodyKeys(dict map[string]*openapi3.RequestBodyRef) []string {
 	return keys
 }
 
+// This returns sorted keys for a HeaderRef dict
+func SortedHeaderKeys(dict map[string]*openapi3.HeaderRef) []string {
+	keys := make([]string, len(dict))
+	i := 0
+	for key := range dict {
+		keys[i] = key
+		i++
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// This returns sorted keys for an ExampleRef dict
+func SortedExampleKeys(dict map[string]*openapi3.ExampleRef) []string {
+	keys := make([]string, len(dict))
+	i := 0
+	for key := range dict {
+		keys[i] = key
+		i++
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // This function checks whether the specified string is present in an array
 // of strings
 func StringInArray(str string, array []string) bool {
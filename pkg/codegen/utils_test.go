@@ -233,3 +233,12 @@ Line
 	}
 
 }
+
+func TestIsStatusCodeRange(t *testing.T) {
+	for _, key := range []string{"1XX", "2XX", "4xx", "5XX"} {
+		assert.True(t, IsStatusCodeRange(key), key)
+	}
+	for _, key := range []string{"200", "default", "6XX", "2X", "20X", "XXX"} {
+		assert.False(t, IsStatusCodeRange(key), key)
+	}
+}
@@ -19,11 +19,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
 	"github.com/labstack/echo/v4"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/runtime"
 )
 
 const EchoContextKey = "oapi-codegen/echo-context"
@@ -45,7 +50,11 @@ func OapiValidatorFromYamlFile(path string) (echo.MiddlewareFunc, error) {
 		return nil, fmt.Errorf("error parsing %s as Swagger YAML: %s",
 			path, err)
 	}
-	return OapiRequestValidator(swagger), nil
+	validator, err := NewRequestValidator(swagger, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing the validation of %s: %s", path, err)
+	}
+	return validator.Middleware(), nil
 }
 
 // Create a validator from a swagger object.
@@ -61,12 +70,77 @@ type Options struct {
 	UserData     interface{}
 }
 
-// Create a validator from a swagger object, with validation options
+// Create a validator from a swagger object, with validation options. When the
+// spec can't be prepared, every request fails with a 500 whose internal error
+// is the reason. Use NewRequestValidator to get the error instead.
 func OapiRequestValidatorWithOptions(swagger *openapi3.Swagger, options *Options) echo.MiddlewareFunc {
-	router := openapi3filter.NewRouter().WithSwagger(swagger)
+	validator, err := NewRequestValidator(swagger, options)
+	if err != nil {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				return &echo.HTTPError{
+					Code:     http.StatusInternalServerError,
+					Message:  runtime.Message(c.Request(), runtime.MsgValidationError, err),
+					Internal: err,
+				}
+			}
+		}
+	}
+	return validator.Middleware()
+}
+
+// RequestValidator validates requests against a swagger object, as
+// ValidateRequestFromContext does, with the patterns of the schemas of every
+// operation compiled when it is created. kin-openapi otherwise compiles
+// patterns lazily, while validating the first request using them, so this
+// moves the cost to startup, reports invalid patterns as errors, and keeps
+// concurrent requests from racing to compile the same pattern.
+type RequestValidator struct {
+	router  routers.Router
+	options *Options
+}
+
+// NewRequestValidator creates a RequestValidator for swagger. It fails when
+// a pattern of the schemas of the operations can't be compiled.
+func NewRequestValidator(swagger *openapi3.Swagger, options *Options) (*RequestValidator, error) {
+	router, err := legacy.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+	compiled := make(map[*openapi3.Schema]bool)
+	for path, pathItem := range swagger.Paths {
+		for method, op := range pathItem.Operations() {
+			// Overridden parameters of the path are compiled too, which
+			// doesn't hurt.
+			parameters := append(openapi3.Parameters{}, pathItem.Parameters...)
+			for _, paramRef := range append(parameters, op.Parameters...) {
+				param := paramRef.Value
+				if err := compileSchemaPatterns(param.Schema, compiled); err != nil {
+					return nil, fmt.Errorf("parameter %s of %s %s: %s", param.Name, method, path, err)
+				}
+				for _, mt := range param.Content {
+					if err := compileSchemaPatterns(mt.Schema, compiled); err != nil {
+						return nil, fmt.Errorf("parameter %s of %s %s: %s", param.Name, method, path, err)
+					}
+				}
+			}
+			if op.RequestBody != nil {
+				for _, mt := range op.RequestBody.Value.Content {
+					if err := compileSchemaPatterns(mt.Schema, compiled); err != nil {
+						return nil, fmt.Errorf("request body of %s %s: %s", method, path, err)
+					}
+				}
+			}
+		}
+	}
+	return &RequestValidator{router: router, options: options}, nil
+}
+
+// Middleware returns an echo middleware validating requests with v.
+func (v *RequestValidator) Middleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			err := ValidateRequestFromContext(c, router, options)
+			err := v.Validate(c)
 			if err != nil {
 				return err
 			}
@@ -75,29 +149,69 @@ func OapiRequestValidatorWithOptions(swagger *openapi3.Swagger, options *Options
 	}
 }
 
+// compileSchemaPatterns makes kin-openapi compile the patterns of the schema
+// and of all the schemas it contains. It fails on the first pattern which
+// isn't a valid regular expression.
+func compileSchemaPatterns(ref *openapi3.SchemaRef, compiled map[*openapi3.Schema]bool) error {
+	if ref == nil || ref.Value == nil || compiled[ref.Value] {
+		return nil
+	}
+	schema := ref.Value
+	compiled[schema] = true
+
+	if schema.Pattern != "" {
+		// kin-openapi only reports invalid patterns while validating, as
+		// errors of the value, so they're checked here.
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %s", schema.Pattern, err)
+		}
+		// Patterns are compiled the first time a string of the right length
+		// is validated, whether it matches or not.
+		_ = schema.VisitJSONString(strings.Repeat("a", int(schema.MinLength)))
+	}
+	refs := []*openapi3.SchemaRef{schema.Items, schema.AdditionalProperties, schema.Not}
+	for _, p := range schema.Properties {
+		refs = append(refs, p)
+	}
+	for _, composed := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		refs = append(refs, composed...)
+	}
+	for _, r := range refs {
+		if err := compileSchemaPatterns(r, compiled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the request of ctx, returning an *echo.HTTPError when
+// it doesn't conform to the spec.
+func (v *RequestValidator) Validate(ctx echo.Context) error {
+	return ValidateRequestFromContext(ctx, v.router, v.options)
+}
+
 // This function is called from the middleware above and actually does the work
 // of validating a request.
-func ValidateRequestFromContext(ctx echo.Context, router *openapi3filter.Router, options *Options) error {
+func ValidateRequestFromContext(ctx echo.Context, router routers.Router, options *Options) error {
 	req := ctx.Request()
-	route, pathParams, err := router.FindRoute(req.Method, req.URL)
+	route, pathParams, err := router.FindRoute(req)
 
 	// We failed to find a matching route for the request.
 	if err != nil {
-		switch e := err.(type) {
-		case *openapi3filter.RouteError:
-			// We've got a bad request, the path requested doesn't match
-			// either server, or path, or something.
-			return echo.NewHTTPError(http.StatusBadRequest, e.Reason)
-		default:
-			// This should never happen today, but if our upstream code changes,
-			// we don't want to crash the server, so handle the unexpected error.
-			return echo.NewHTTPError(http.StatusInternalServerError,
-				fmt.Sprintf("error validating route: %s", err.Error()))
-		}
+		return routeError(req, err)
 	}
 
+	validationInput, requestContext := newValidationInput(ctx, route, pathParams, options)
+	err = openapi3filter.ValidateRequest(requestContext, validationInput)
+	if err != nil {
+		return validationError(req, err)
+	}
+	return nil
+}
+
+func newValidationInput(ctx echo.Context, route *routers.Route, pathParams map[string]string, options *Options) (*openapi3filter.RequestValidationInput, context.Context) {
 	validationInput := &openapi3filter.RequestValidationInput{
-		Request:    req,
+		Request:    ctx.Request(),
 		PathParams: pathParams,
 		Route:      route,
 	}
@@ -111,37 +225,83 @@ func ValidateRequestFromContext(ctx echo.Context, router *openapi3filter.Router,
 		validationInput.ParamDecoder = options.ParamDecoder
 		requestContext = context.WithValue(requestContext, UserDataKey, options.UserData)
 	}
+	return validationInput, requestContext
+}
 
-	err = openapi3filter.ValidateRequest(requestContext, validationInput)
-	if err != nil {
-		switch e := err.(type) {
-		case *openapi3filter.RequestError:
-			// We've got a bad request
-			// Split up the verbose error by lines and return the first one
-			// openapi errors seem to be multi-line with a decent message on the first
-			errorLines := strings.Split(e.Error(), "\n")
-			return &echo.HTTPError{
-				Code:     http.StatusBadRequest,
-				Message:  errorLines[0],
-				Internal: err,
-			}
-		case *openapi3filter.SecurityRequirementsError:
-			return &echo.HTTPError{
-				Code:     http.StatusForbidden,
-				Message:  e.Error(),
-				Internal: err,
-			}
-		default:
-			// This should never happen today, but if our upstream code changes,
-			// we don't want to crash the server, so handle the unexpected error.
-			return &echo.HTTPError{
-				Code: http.StatusInternalServerError,
-				Message: fmt.Sprintf("error validating request: %s", err),
-				Internal: err,
-			}
+// routeError turns an error finding the route of req into an *echo.HTTPError.
+func routeError(req *http.Request, err error) error {
+	switch e := err.(type) {
+	case *routers.RouteError:
+		// We've got a bad request, the path requested doesn't match
+		// either server, or path, or something.
+		return echo.NewHTTPError(http.StatusBadRequest, runtime.Message(req, runtime.MsgRouteNotFound, e.Reason))
+	default:
+		// This should never happen today, but if our upstream code changes,
+		// we don't want to crash the server, so handle the unexpected error.
+		return echo.NewHTTPError(http.StatusInternalServerError,
+			runtime.Message(req, runtime.MsgRouteError, err.Error()))
+	}
+}
+
+// validationError turns an error validating req into an *echo.HTTPError.
+func validationError(req *http.Request, err error) error {
+	switch e := err.(type) {
+	case *openapi3filter.RequestError:
+		// We've got a bad request
+		// Split up the verbose error by lines and return the first one
+		// openapi errors seem to be multi-line with a decent message on the first
+		errorLines := strings.Split(e.Error(), "\n")
+		field, description := describedField(e)
+		return &echo.HTTPError{
+			Code:     http.StatusBadRequest,
+			Message:  runtime.Describe(req, runtime.Message(req, runtime.MsgInvalidRequest, errorLines[0]), field, description),
+			Internal: err,
+		}
+	case *openapi3filter.SecurityRequirementsError:
+		return &echo.HTTPError{
+			Code:     http.StatusForbidden,
+			Message:  runtime.Message(req, runtime.MsgSecurityRequirements, e.Error()),
+			Internal: err,
+		}
+	default:
+		// This should never happen today, but if our upstream code changes,
+		// we don't want to crash the server, so handle the unexpected error.
+		return &echo.HTTPError{
+			Code:     http.StatusInternalServerError,
+			Message:  runtime.Message(req, runtime.MsgValidationError, err),
+			Internal: err,
 		}
 	}
-	return nil
+}
+
+// describedField returns the name and the description of the parameter or
+// property which a request error is about, so that they can be added to its
+// message. The description is empty when the spec has none.
+func describedField(e *openapi3filter.RequestError) (string, string) {
+	schemaErr, _ := e.Err.(*openapi3.SchemaError)
+	if e.Parameter != nil {
+		if e.Parameter.Description != "" || schemaErr == nil || schemaErr.Schema == nil {
+			return e.Parameter.Name, e.Parameter.Description
+		}
+		return e.Parameter.Name, schemaErr.Schema.Description
+	}
+	if schemaErr == nil || schemaErr.Schema == nil {
+		return "", ""
+	}
+	pointer := schemaErr.JSONPointer()
+	if len(pointer) == 0 {
+		return "body", schemaErr.Schema.Description
+	}
+	field := strings.Join(pointer, ".")
+	if schemaErr.SchemaField == "required" {
+		// The error is about the object missing the property.
+		property := schemaErr.Schema.Properties[pointer[len(pointer)-1]]
+		if property == nil || property.Value == nil {
+			return field, ""
+		}
+		return field, property.Value.Description
+	}
+	return field, schemaErr.Schema.Description
 }
 
 // Helper function to get the echo context from within requests. It returns
@@ -0,0 +1,219 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuditRedacted replaces the values of sensitive parameters in audit events.
+const AuditRedacted = "[REDACTED]"
+
+// AuditDescriptor describes what an operation does, for audit logging. The
+// audit target generates one per operation, from the x-audit extension of the
+// operation and the x-sensitive extension of its parameters.
+type AuditDescriptor struct {
+	OperationID string
+	Method      string
+	Path        string   // The path template of the spec, such as /pets/{id}
+	Resource    string   // The type of resource which the operation acts on
+	Action      string   // What the operation does to the resource, such as "read" or "delete"
+	Sensitivity string   // How sensitive the operation is, as written in the spec
+	Sensitive   []string // The names of the parameters whose values are redacted
+}
+
+// IsSensitive returns whether the value of the named parameter is redacted.
+func (d AuditDescriptor) IsSensitive(param string) bool {
+	for _, name := range d.Sensitive {
+		if name == param {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditEvent records a single call to an operation.
+type AuditEvent struct {
+	AuditDescriptor
+
+	Time       time.Time              // When the request was received
+	Duration   time.Duration          // How long the request took to handle
+	RemoteAddr string                 // The network address of the client
+	Params     map[string]interface{} // The bound parameters, by name, with sensitive values redacted
+	Status     int                    // The status code of the response
+	Err        error                  // The error returned by the handler, if any
+}
+
+// AuditEmitter is called with the event of every audited request, once the
+// request has been handled.
+type AuditEmitter func(ctx context.Context, event AuditEvent)
+
+type auditRecordKey struct{}
+
+// auditRecord collects the operation and the parameters of a request while
+// it's handled.
+type auditRecord struct {
+	mu          sync.Mutex
+	operationID string
+	params      map[string]interface{}
+}
+
+// AuditOperation records the operation which a request is routed to, when the
+// request is audited, and returns whether it is. It's called by generated
+// servers, before binding parameters, so that requests with invalid
+// parameters are audited too.
+func AuditOperation(ctx context.Context, operationID string) bool {
+	record, ok := ctx.Value(auditRecordKey{}).(*auditRecord)
+	if !ok {
+		return false
+	}
+	record.mu.Lock()
+	defer record.mu.Unlock()
+	record.operationID = operationID
+	return true
+}
+
+// AuditParams records the bound parameters of an audited request. Pointers
+// are dereferenced, and nil ones, which stand for parameters which weren't
+// passed, are left out.
+func AuditParams(ctx context.Context, params map[string]interface{}) {
+	record, ok := ctx.Value(auditRecordKey{}).(*auditRecord)
+	if !ok {
+		return
+	}
+	record.mu.Lock()
+	defer record.mu.Unlock()
+	for name, value := range params {
+		v := reflect.ValueOf(value)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				continue
+			}
+			value = v.Elem().Interface()
+		}
+		record.params[name] = value
+	}
+}
+
+// auditEvent returns the event of an audited request, or false when the
+// request wasn't routed to one of the described operations.
+func (r *auditRecord) auditEvent(descriptors map[string]AuditDescriptor) (AuditEvent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	descriptor, found := descriptors[r.operationID]
+	if !found {
+		return AuditEvent{}, false
+	}
+	event := AuditEvent{
+		AuditDescriptor: descriptor,
+		Params:          make(map[string]interface{}, len(r.params)),
+	}
+	for name, value := range r.params {
+		if descriptor.IsSensitive(name) {
+			value = AuditRedacted
+		}
+		event.Params[name] = value
+	}
+	return event, true
+}
+
+func startAudit(r *http.Request) (*http.Request, *auditRecord) {
+	record := &auditRecord{params: make(map[string]interface{})}
+	return r.WithContext(context.WithValue(r.Context(), auditRecordKey{}, record)), record
+}
+
+// auditStatusRecorder keeps the status code written to a ResponseWriter.
+type auditStatusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditStatusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditStatusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// AuditHandler wraps a handler of generated operations, such as the one of a
+// generated chi server, so that emit is called with an event for every
+// request routed to one of the described operations.
+func AuditHandler(descriptors map[string]AuditDescriptor, emit AuditEmitter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		r, record := startAudit(r)
+		recorder := &auditStatusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		event, found := record.auditEvent(descriptors)
+		if !found {
+			return
+		}
+		event.Time = start
+		event.Duration = time.Since(start)
+		event.RemoteAddr = r.RemoteAddr
+		event.Status = recorder.status
+		if event.Status == 0 {
+			event.Status = http.StatusOK
+		}
+		emit(r.Context(), event)
+	})
+}
+
+// AuditMiddleware returns an echo middleware which calls emit with an event
+// for every request routed to one of the described operations. As the error
+// returned by a handler is only turned into a response after the middleware
+// returns, the status of an *echo.HTTPError is reported, or 500 for other
+// errors.
+func AuditMiddleware(descriptors map[string]AuditDescriptor, emit AuditEmitter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			r, record := startAudit(c.Request())
+			c.SetRequest(r)
+			err := next(c)
+
+			event, found := record.auditEvent(descriptors)
+			if !found {
+				return err
+			}
+			event.Time = start
+			event.Duration = time.Since(start)
+			event.RemoteAddr = c.RealIP()
+			event.Status = c.Response().Status
+			event.Err = err
+			if err != nil && !c.Response().Committed {
+				event.Status = http.StatusInternalServerError
+				if he, ok := err.(*echo.HTTPError); ok {
+					event.Status = he.Code
+				}
+			}
+			emit(r.Context(), event)
+			return err
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditHandler(t *testing.T) {
+	descriptors := map[string]AuditDescriptor{
+		"login": {OperationID: "login", Method: "POST", Path: "/login", Resource: "session", Action: "create",
+			Sensitive: []string{"password"}},
+	}
+	var events []AuditEvent
+	emit := func(ctx context.Context, event AuditEvent) {
+		events = append(events, event)
+	}
+
+	handler := AuditHandler(descriptors, emit, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login" {
+			http.NotFound(w, r)
+			return
+		}
+		user := "alice"
+		var remember *bool
+		if AuditOperation(r.Context(), "login") {
+			AuditParams(r.Context(), map[string]interface{}{
+				"user":     &user,
+				"password": "hunter2",
+				"remember": remember,
+			})
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/login", nil))
+	require.Len(t, events, 1)
+	assert.Equal(t, "login", events[0].OperationID)
+	assert.Equal(t, "session", events[0].Resource)
+	assert.Equal(t, http.StatusCreated, events[0].Status)
+	assert.Equal(t, map[string]interface{}{"user": "alice", "password": AuditRedacted}, events[0].Params)
+	assert.False(t, events[0].Time.IsZero())
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/other", nil))
+	assert.Len(t, events, 1)
+}
+
+func TestAuditWithoutMiddleware(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, AuditOperation(ctx, "login"))
+	AuditParams(ctx, map[string]interface{}{"user": "alice"})
+}
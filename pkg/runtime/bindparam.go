@@ -35,6 +35,12 @@ func BindStyledParameter(style string, explode bool, paramName string,
 		return echo.NewHTTPError(http.StatusBadRequest, "parameter '%s' is empty, can't bind its value", paramName)
 	}
 
+	// Types which parse themselves from text are bound as a whole, even when
+	// they are structs.
+	if _, ok := textUnmarshaler(dest); ok {
+		return BindStringToObject(value, dest)
+	}
+
 	// Everything comes in by pointer, dereference it
 	v := reflect.Indirect(reflect.ValueOf(dest))
 
@@ -367,7 +373,11 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 		case reflect.Slice:
 			err = bindSplitPartsToDestinationArray(parts, output)
 		case reflect.Struct:
-			err = bindSplitPartsToDestinationStruct(paramName, parts, explode, output)
+			if _, ok := textUnmarshaler(output); ok && len(parts) == 1 {
+				err = BindStringToObject(parts[0], output)
+			} else {
+				err = bindSplitPartsToDestinationStruct(paramName, parts, explode, output)
+			}
 		default:
 			if len(parts) == 0 {
 				if required {
@@ -406,6 +416,13 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 			k = strings.TrimSuffix(split[1], "]")
 			objectMap[k] = v[0]
 		}
+		if len(objectMap) == 0 {
+			if required {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+					"query parameter '%s' is required", paramName))
+			}
+			return nil
+		}
 
 		// Marshal and unmarshal the objectMap into dest
 		data, err := json.Marshal(objectMap)
@@ -437,6 +454,9 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 	case *types.Date:
 		return BindStringToObject(values.Get(paramName), dest)
 	}
+	if _, ok := textUnmarshaler(dest); ok {
+		return BindStringToObject(values.Get(paramName), dest)
+	}
 
 	v := reflect.Indirect(reflect.ValueOf(dest))
 	if v.Type().Kind() != reflect.Struct {
@@ -258,6 +258,13 @@ func TestBindQueryParameter(t *testing.T) {
 		err := BindQueryParameter("deepObject", true, false, paramName, queryParams, &actual)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedDeepObject, actual)
+
+		var absent *ID
+		err = BindQueryParameter("deepObject", true, false, paramName, url.Values{"foo": {"bar"}}, &absent)
+		assert.NoError(t, err)
+		assert.Nil(t, absent)
+		err = BindQueryParameter("deepObject", true, true, paramName, url.Values{"foo": {"bar"}}, &absent)
+		assert.Error(t, err)
 	})
 
 	t.Run("form", func(t *testing.T) {
@@ -271,3 +278,78 @@ func TestBindQueryParameter(t *testing.T) {
 		assert.Equal(t, expected, birthday)
 	})
 }
+
+func TestBindTextUnmarshalerParameter(t *testing.T) {
+	expected := time.Date(2020, 1, 2, 2, 4, 5, 0, time.UTC)
+
+	var st secondsTime
+	assert.NoError(t, BindStyledParameter("simple", false, "at", "2020-01-02T03:04:05+01:00", &st))
+	assert.Equal(t, expected, st.Time)
+
+	queryParams := url.Values{
+		"at": {"2020-01-02T02:04:05Z"},
+	}
+	var exploded secondsTime
+	assert.NoError(t, BindQueryParameter("form", true, true, "at", queryParams, &exploded))
+	assert.Equal(t, expected, exploded.Time)
+
+	var optional *secondsTime
+	assert.NoError(t, BindQueryParameter("form", false, false, "at", queryParams, &optional))
+	if assert.NotNil(t, optional) {
+		assert.Equal(t, expected, optional.Time)
+	}
+}
+
+func BenchmarkBindStyledParameter(b *testing.B) {
+	b.Run("primitive", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dst int32
+			if err := BindStyledParameter("simple", false, "id", "5", &dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("array", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dst []int32
+			if err := BindStyledParameter("simple", false, "id", "3,4,5", &dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("object", func(b *testing.B) {
+		type Object struct {
+			FirstName string `json:"firstName"`
+			Role      string `json:"role"`
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dst Object
+			if err := BindStyledParameter("simple", true, "id", "role=admin,firstName=Alex", &dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkBindQueryParameter(b *testing.B) {
+	queryParams := url.Values{
+		"ea": {"3", "4", "5"},
+		"p":  {"5"},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var ea []int32
+		var p int32
+		if err := BindQueryParameter("form", true, true, "ea", queryParams, &ea); err != nil {
+			b.Fatal(err)
+		}
+		if err := BindQueryParameter("form", true, true, "p", queryParams, &p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -14,6 +14,7 @@
 package runtime
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
@@ -87,6 +88,12 @@ func BindStringToObject(src string, dst interface{}) error {
 			dstType.Time = parsedTime
 			return nil
 		}
+		if u, ok := textUnmarshaler(dst); ok {
+			if err := u.UnmarshalText([]byte(src)); err != nil {
+				return fmt.Errorf("error parsing '%s': %s", src, err)
+			}
+			return nil
+		}
 		fallthrough
 	default:
 		// We've got a bunch of types unimplemented, don't fail silently.
@@ -97,3 +104,14 @@ func BindStringToObject(src string, dst interface{}) error {
 	}
 	return nil
 }
+
+// textUnmarshaler returns dst as an encoding.TextUnmarshaler, unless it's one
+// of the types which BindStringToObject already knows how to parse.
+func textUnmarshaler(dst interface{}) (encoding.TextUnmarshaler, bool) {
+	switch dst.(type) {
+	case *time.Time, *types.Date:
+		return nil, false
+	}
+	u, ok := dst.(encoding.TextUnmarshaler)
+	return u, ok
+}
@@ -90,3 +90,11 @@ func TestBindStringToObject(t *testing.T) {
 	parsedTime = parsedTime.UTC()
 	assert.EqualValues(t, now, parsedTime)
 }
+
+func TestBindStringToTextUnmarshaler(t *testing.T) {
+	var st secondsTime
+	assert.NoError(t, BindStringToObject("2020-01-02T03:04:05+01:00", &st))
+	assert.Equal(t, time.Date(2020, 1, 2, 2, 4, 5, 0, time.UTC), st.Time)
+
+	assert.Error(t, BindStringToObject("2020-01-02", &st))
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ContentTypeMatcher reports whether the Content-Type header of a response,
+// actual, matches one of the media types declared for it in the spec. The
+// generated Parse functions only unmarshal the response body when it does.
+type ContentTypeMatcher func(actual string, declared ...string) bool
+
+// MatchContentTypeStrict matches the media type of actual exactly against the
+// declared ones, ignoring case and any media type parameters, such as charset.
+// Declared media types may use wildcards, such as "application/*" or "*/*".
+func MatchContentTypeStrict(actual string, declared ...string) bool {
+	mediaType, _, err := mime.ParseMediaType(actual)
+	if err != nil {
+		return false
+	}
+	for _, d := range declared {
+		d = strings.ToLower(d)
+		switch {
+		case d == mediaType, d == "*/*":
+			return true
+		case strings.HasSuffix(d, "/*"):
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(d, "*")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchContentTypeLenient matches like MatchContentTypeStrict, but it also
+// accepts any media type which mentions the same format, "json", "yaml" or
+// "xml", as one of the declared media types. This is how the generated Parse
+// functions pick the format to unmarshal by default.
+func MatchContentTypeLenient(actual string, declared ...string) bool {
+	if MatchContentTypeStrict(actual, declared...) {
+		return true
+	}
+	actual = strings.ToLower(actual)
+	for _, d := range declared {
+		d = strings.ToLower(d)
+		for _, format := range []string{"json", "yaml", "xml"} {
+			if strings.Contains(d, format) && strings.Contains(actual, format) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The maximum length of UnexpectedContentTypeError.BodySnippet.
+const bodySnippetLength = 256
+
+// UnexpectedContentTypeError is returned by the generated Parse functions,
+// when they're generated to check content types, for a response whose
+// Content-Type doesn't match any media type declared in the spec. This is
+// typically an error page from a gateway or a proxy.
+type UnexpectedContentTypeError struct {
+	StatusCode  int      // The status code of the response
+	ContentType string   // The Content-Type header of the response
+	Expected    []string // The media types declared in the spec
+	BodySnippet string   // The beginning of the response body
+}
+
+func (e *UnexpectedContentTypeError) Error() string {
+	return fmt.Sprintf("unexpected content type '%s' in response with status %d, expected one of [%s]: %s",
+		e.ContentType, e.StatusCode, strings.Join(e.Expected, ", "), e.BodySnippet)
+}
+
+// CheckResponseContentType returns an *UnexpectedContentTypeError when the
+// response has a body, and its Content-Type doesn't match any of the expected
+// media types according to match.
+func CheckResponseContentType(rsp *http.Response, body []byte, match ContentTypeMatcher, expected ...string) error {
+	if len(body) == 0 {
+		return nil
+	}
+	contentType := rsp.Header.Get(echo.HeaderContentType)
+	if match(contentType, expected...) {
+		return nil
+	}
+	snippet := body
+	if len(snippet) > bodySnippetLength {
+		snippet = snippet[:bodySnippetLength]
+	}
+	return &UnexpectedContentTypeError{
+		StatusCode:  rsp.StatusCode,
+		ContentType: contentType,
+		Expected:    expected,
+		BodySnippet: strings.ToValidUTF8(string(snippet), ""),
+	}
+}
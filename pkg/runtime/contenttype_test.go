@@ -0,0 +1,72 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchContentTypeStrict(t *testing.T) {
+	assert.True(t, MatchContentTypeStrict("application/json", "application/json"))
+	assert.True(t, MatchContentTypeStrict("Application/JSON; charset=utf-8", "application/json"))
+	assert.True(t, MatchContentTypeStrict("text/x-json", "application/json", "text/x-json"))
+	assert.True(t, MatchContentTypeStrict("application/problem+json", "application/*"))
+	assert.True(t, MatchContentTypeStrict("text/plain", "*/*"))
+
+	assert.False(t, MatchContentTypeStrict("text/html; charset=utf-8", "application/json"))
+	assert.False(t, MatchContentTypeStrict("application/problem+json", "application/json"))
+	assert.False(t, MatchContentTypeStrict("text/json", "application/*"))
+	assert.False(t, MatchContentTypeStrict("", "application/json"))
+	assert.False(t, MatchContentTypeStrict("application/json"))
+}
+
+func TestMatchContentTypeLenient(t *testing.T) {
+	assert.True(t, MatchContentTypeLenient("application/json", "application/json"))
+	assert.True(t, MatchContentTypeLenient("application/problem+json", "application/json"))
+	assert.True(t, MatchContentTypeLenient("text/plain", "text/plain"))
+
+	assert.False(t, MatchContentTypeLenient("text/html", "application/json"))
+	assert.False(t, MatchContentTypeLenient("application/json", "text/plain"))
+}
+
+func TestCheckResponseContentType(t *testing.T) {
+	rsp := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{},
+	}
+	rsp.Header.Set("Content-Type", "text/html")
+	body := []byte("<html><body>" + strings.Repeat("Bad Gateway ", 50) + "</body></html>")
+
+	err := CheckResponseContentType(rsp, body, MatchContentTypeLenient, "application/json")
+	require.Error(t, err)
+	contentTypeErr, ok := err.(*UnexpectedContentTypeError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadGateway, contentTypeErr.StatusCode)
+	assert.Equal(t, "text/html", contentTypeErr.ContentType)
+	assert.Equal(t, []string{"application/json"}, contentTypeErr.Expected)
+	assert.Len(t, contentTypeErr.BodySnippet, bodySnippetLength)
+	assert.True(t, strings.HasPrefix(contentTypeErr.BodySnippet, "<html><body>Bad Gateway"))
+	assert.Contains(t, err.Error(), "unexpected content type 'text/html' in response with status 502")
+
+	// Empty bodies don't have a content type to check
+	assert.NoError(t, CheckResponseContentType(rsp, nil, MatchContentTypeLenient, "application/json"))
+
+	rsp.Header.Set("Content-Type", "application/json; charset=utf-8")
+	assert.NoError(t, CheckResponseContentType(rsp, body, MatchContentTypeStrict, "application/json"))
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// contextReadCloser is the io.ReadCloser of NewContextReadCloser.
+type contextReadCloser struct {
+	ctx       context.Context
+	body      io.ReadCloser
+	closed    chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewContextReadCloser wraps body so that reading it fails with ctx.Err()
+// as soon as ctx is done, including reads which are blocked waiting for
+// data, such as those of the body of a stalled response. The generated
+// clients wrap the bodies of requests and responses with it, whatever their
+// HttpRequestDoer, so that cancelling the context of a call releases the
+// goroutine reading the body. To do so, body is closed when ctx is done,
+// which has to unblock its pending reads, as it does for network
+// connections and pipes. The returned body has to be closed as usual.
+func NewContextReadCloser(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+	if ctx.Done() == nil {
+		// The context can't be cancelled.
+		return body
+	}
+	r := &contextReadCloser{
+		ctx:    ctx,
+		body:   body,
+		closed: make(chan struct{}),
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = r.close()
+		case <-r.closed:
+		}
+	}()
+	return r
+}
+
+func (r *contextReadCloser) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.body.Read(p)
+	if err != nil && err != io.EOF {
+		// Reads interrupted by closing the body report the reason.
+		if ctxErr := r.ctx.Err(); ctxErr != nil {
+			return n, ctxErr
+		}
+	}
+	return n, err
+}
+
+func (r *contextReadCloser) Close() error {
+	return r.close()
+}
+
+func (r *contextReadCloser) close() error {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+		r.closeErr = r.body.Close()
+	})
+	return r.closeErr
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextReadCloser(t *testing.T) {
+	t.Run("reads until EOF", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		body := NewContextReadCloser(ctx, ioutil.NopCloser(strings.NewReader("content")))
+		data, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+		assert.NoError(t, body.Close())
+	})
+
+	t.Run("context which can't be cancelled", func(t *testing.T) {
+		body := ioutil.NopCloser(strings.NewReader("content"))
+		assert.Equal(t, body, NewContextReadCloser(context.Background(), body))
+	})
+
+	t.Run("cancelled before reading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		body := NewContextReadCloser(ctx, ioutil.NopCloser(strings.NewReader("content")))
+		_, err := ioutil.ReadAll(body)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("cancelled while blocked", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		defer pw.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		body := NewContextReadCloser(ctx, pr)
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := ioutil.ReadAll(body)
+			errs <- err
+		}()
+		_, err := pw.Write([]byte("partial"))
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case err := <-errs:
+			assert.Equal(t, context.Canceled, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the read wasn't interrupted")
+		}
+		// The body was closed, so the writer fails too.
+		_, err = pw.Write([]byte("more"))
+		assert.Equal(t, io.ErrClosedPipe, err)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		defer pw.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := ioutil.ReadAll(NewContextReadCloser(ctx, pr))
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
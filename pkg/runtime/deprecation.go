@@ -0,0 +1,224 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DeprecatedOperation is an operation marked deprecated in the spec. The
+// deprecation target generates one per deprecated operation.
+type DeprecatedOperation struct {
+	OperationID string
+	Method      string
+	Path        string
+
+	// Sunset is when the operation is going to be removed, as read from its
+	// x-sunset extension. It's zero when the spec doesn't say.
+	Sunset time.Time
+}
+
+// DeprecationOption configures a DeprecationTracker.
+type DeprecationOption func(*DeprecationTracker)
+
+// WithDeprecationHeader makes the tracker set the Deprecation header of the
+// responses of deprecated operations, as defined by RFC 9745, to the date
+// when they were deprecated.
+func WithDeprecationHeader(date time.Time) DeprecationOption {
+	return func(t *DeprecationTracker) {
+		t.date = date
+	}
+}
+
+// WithSunsetHeader makes the tracker set the Sunset header of the responses
+// of deprecated operations, as defined by RFC 8594, to when they're going to
+// be removed. The date applies to the operations without a sunset in the
+// spec, those with one are always given theirs.
+func WithSunsetHeader(date time.Time) DeprecationOption {
+	return func(t *DeprecationTracker) {
+		t.sunset = date
+		t.sunsetHeader = true
+	}
+}
+
+// WithDeprecationRecorder makes the tracker call record with every request to
+// a deprecated operation, for instance to log its caller.
+func WithDeprecationRecorder(record func(ctx context.Context, op DeprecatedOperation)) DeprecationOption {
+	return func(t *DeprecationTracker) {
+		t.record = record
+	}
+}
+
+// DeprecationTracker counts the requests to deprecated operations, so that
+// API owners know who still depends on them before removing them, and
+// optionally tells clients about the deprecation with response headers. It's
+// used by DeprecationMiddleware and DeprecationHandler.
+type DeprecationTracker struct {
+	date         time.Time
+	sunset       time.Time
+	sunsetHeader bool
+	record       func(ctx context.Context, op DeprecatedOperation)
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// NewDeprecationTracker creates a DeprecationTracker, which only counts
+// requests unless options say otherwise.
+func NewDeprecationTracker(opts ...DeprecationOption) *DeprecationTracker {
+	t := &DeprecationTracker{counts: map[string]uint64{}}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Count returns the number of requests to a deprecated operation.
+func (t *DeprecationTracker) Count(operationID string) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.counts[operationID]
+}
+
+// Counts returns the number of requests to the deprecated operations which
+// have been requested, by operation ID.
+func (t *DeprecationTracker) Counts() map[string]uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	counts := make(map[string]uint64, len(t.counts))
+	for id, count := range t.counts {
+		counts[id] = count
+	}
+	return counts
+}
+
+// WriteMetrics writes the number of requests to each of the deprecated
+// operations as a counter in the OpenMetrics text format, including the
+// operations which haven't been requested.
+func (t *DeprecationTracker) WriteMetrics(w io.Writer, ops map[string]DeprecatedOperation) error {
+	ids := make([]string, 0, len(ops))
+	for id := range ops {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	counts := t.Counts()
+
+	var buf bytes.Buffer
+	buf.WriteString("# TYPE deprecated_operation_requests counter\n")
+	buf.WriteString("# HELP deprecated_operation_requests Requests to operations deprecated in the spec.\n")
+	for _, id := range ids {
+		op := ops[id]
+		sunset := ""
+		if !op.Sunset.IsZero() {
+			sunset = op.Sunset.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(&buf, "deprecated_operation_requests_total{operation=\"%s\",method=\"%s\",path=\"%s\",sunset=\"%s\"} %d\n",
+			escapeLabelValue(op.OperationID), escapeLabelValue(op.Method), escapeLabelValue(op.Path), sunset, counts[id])
+	}
+	buf.WriteString("# EOF\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// MetricsHandler serves the counts of requests to the deprecated operations
+// in the OpenMetrics text format, as written by WriteMetrics.
+func (t *DeprecationTracker) MetricsHandler(ops map[string]DeprecatedOperation) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", OpenMetricsContentType)
+		_ = t.WriteMetrics(w, ops)
+	})
+}
+
+// track counts a request to a deprecated operation, and sets the headers of
+// its response.
+func (t *DeprecationTracker) track(ctx context.Context, header http.Header, op DeprecatedOperation) {
+	t.mu.Lock()
+	t.counts[op.OperationID]++
+	t.mu.Unlock()
+
+	if !t.date.IsZero() {
+		header.Set("Deprecation", "@"+strconv.FormatInt(t.date.Unix(), 10))
+	}
+	if t.sunsetHeader || !op.Sunset.IsZero() {
+		sunset := op.Sunset
+		if sunset.IsZero() {
+			sunset = t.sunset
+		}
+		if !sunset.IsZero() {
+			header.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+	}
+	if t.record != nil {
+		t.record(ctx, op)
+	}
+}
+
+type deprecationRecordKey struct{}
+
+// deprecationRecord tracks the requests which may be routed to a deprecated
+// operation.
+type deprecationRecord struct {
+	ops     map[string]DeprecatedOperation
+	tracker *DeprecationTracker
+	header  http.Header
+}
+
+// DeprecatedOperationCalled tracks a request routed to a deprecated
+// operation, when the request goes through DeprecationMiddleware or
+// DeprecationHandler. It's called by generated servers, before the handler of
+// the operation, so that the headers are set before the response is written.
+func DeprecatedOperationCalled(ctx context.Context, operationID string) {
+	record, ok := ctx.Value(deprecationRecordKey{}).(*deprecationRecord)
+	if !ok {
+		return
+	}
+	if op, found := record.ops[operationID]; found {
+		record.tracker.track(ctx, record.header, op)
+	}
+}
+
+func startDeprecation(r *http.Request, header http.Header, ops map[string]DeprecatedOperation, tracker *DeprecationTracker) *http.Request {
+	record := &deprecationRecord{ops: ops, tracker: tracker, header: header}
+	return r.WithContext(context.WithValue(r.Context(), deprecationRecordKey{}, record))
+}
+
+// DeprecationHandler wraps a handler of generated operations, such as the one
+// of a generated chi server, so that tracker tracks the requests routed to
+// the deprecated operations.
+func DeprecationHandler(ops map[string]DeprecatedOperation, tracker *DeprecationTracker, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, startDeprecation(r, w.Header(), ops, tracker))
+	})
+}
+
+// DeprecationMiddleware returns an echo middleware with which tracker tracks
+// the requests routed to the deprecated operations.
+func DeprecationMiddleware(ops map[string]DeprecatedOperation, tracker *DeprecationTracker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(startDeprecation(c.Request(), c.Response().Header(), ops, tracker))
+			return next(c)
+		}
+	}
+}
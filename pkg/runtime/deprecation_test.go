@@ -0,0 +1,82 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDeprecatedOperations = map[string]DeprecatedOperation{
+	"listPets": {OperationID: "listPets", Method: "GET", Path: "/pets"},
+	"getPet": {OperationID: "getPet", Method: "GET", Path: "/pets/{id}",
+		Sunset: time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)},
+}
+
+func TestDeprecationHandler(t *testing.T) {
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewDeprecationTracker(WithSunsetHeader(sunset))
+	handler := DeprecationHandler(testDeprecatedOperations, tracker, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pets":
+			DeprecatedOperationCalled(r.Context(), "listPets")
+		case "/pets/1":
+			DeprecatedOperationCalled(r.Context(), "getPet")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+	assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", rec.Header().Get("Sunset"))
+
+	// The sunset of the spec wins over the one of the tracker.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+	assert.Equal(t, "Mon, 01 Mar 2027 12:00:00 GMT", rec.Header().Get("Sunset"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/other", nil))
+	assert.Empty(t, rec.Header().Get("Sunset"))
+
+	assert.Equal(t, uint64(1), tracker.Count("listPets"))
+	assert.Equal(t, uint64(1), tracker.Count("getPet"))
+	assert.Equal(t, map[string]uint64{"listPets": 1, "getPet": 1}, tracker.Counts())
+
+	// Operations called outside of the handler aren't tracked.
+	DeprecatedOperationCalled(context.Background(), "listPets")
+	assert.Equal(t, uint64(1), tracker.Count("listPets"))
+}
+
+func TestDeprecationTrackerWriteMetrics(t *testing.T) {
+	tracker := NewDeprecationTracker()
+	tracker.track(context.Background(), http.Header{}, testDeprecatedOperations["getPet"])
+
+	var buf bytes.Buffer
+	require.NoError(t, tracker.WriteMetrics(&buf, testDeprecatedOperations))
+	assert.Equal(t, `# TYPE deprecated_operation_requests counter
+# HELP deprecated_operation_requests Requests to operations deprecated in the spec.
+deprecated_operation_requests_total{operation="getPet",method="GET",path="/pets/{id}",sunset="2027-03-01T12:00:00Z"} 1
+deprecated_operation_requests_total{operation="listPets",method="GET",path="/pets",sunset=""} 0
+# EOF
+`, buf.String())
+}
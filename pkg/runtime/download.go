@@ -0,0 +1,248 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RangeFetcher requests a byte range of some content. The generated
+// DownloadXxxResumable helpers pass the XxxRange client methods.
+type RangeFetcher func(ctx context.Context, byteRange ByteRange) (*http.Response, error)
+
+// DownloadOption configures DownloadResumable.
+type DownloadOption func(*downloadOptions)
+
+type downloadOptions struct {
+	offset     int64
+	retries    int
+	backoff    func(retry int) time.Duration
+	checkpoint func(written int64)
+	size       int64
+	hash       hash.Hash
+	digest     []byte
+}
+
+// WithDownloadOffset starts the download at offset, which is typically the
+// number of bytes written by an earlier, interrupted download, as reported to
+// its checkpoint function.
+func WithDownloadOffset(offset int64) DownloadOption {
+	return func(o *downloadOptions) {
+		o.offset = offset
+	}
+}
+
+// WithDownloadRetries resumes a transfer which fails up to retries times in a
+// row without making progress. backoff, when not nil, returns how long to
+// wait before each retry, counted from 1.
+func WithDownloadRetries(retries int, backoff func(retry int) time.Duration) DownloadOption {
+	return func(o *downloadOptions) {
+		o.retries = retries
+		o.backoff = backoff
+	}
+}
+
+// WithDownloadCheckpoint calls fn with the total number of bytes written
+// every time a transfer ends, so that it can be recorded and a later download
+// can start where this one stopped.
+func WithDownloadCheckpoint(fn func(written int64)) DownloadOption {
+	return func(o *downloadOptions) {
+		o.checkpoint = fn
+	}
+}
+
+// WithDownloadSize makes the download fail unless the content is exactly
+// size bytes long.
+func WithDownloadSize(size int64) DownloadOption {
+	return func(o *downloadOptions) {
+		o.size = size
+	}
+}
+
+// WithDownloadDigest hashes the content with h, and makes the download fail
+// unless the sum is digest. When the download starts at an offset, the bytes
+// already written are read back for hashing, so the io.WriterAt must also
+// implement io.ReaderAt, as *os.File does.
+func WithDownloadDigest(h hash.Hash, digest []byte) DownloadOption {
+	return func(o *downloadOptions) {
+		o.hash = h
+		o.digest = digest
+	}
+}
+
+// permanentError is a transfer failure which retrying won't fix.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+// DownloadResumable downloads content with fetch into w, requesting the
+// remainder of the content with a Range header whenever a transfer is
+// interrupted. Once done, it checks that the length of the content matches
+// the one announced by the server and the one given with WithDownloadSize,
+// and that its digest matches the one given with WithDownloadDigest. It
+// returns the total number of bytes written, including those written before
+// the offset given with WithDownloadOffset.
+func DownloadResumable(ctx context.Context, fetch RangeFetcher, w io.WriterAt, opts ...DownloadOption) (int64, error) {
+	o := downloadOptions{size: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	offset := o.offset
+	if o.hash != nil && offset > 0 {
+		r, ok := w.(io.ReaderAt)
+		if !ok {
+			return offset, errors.New("checking the digest of a resumed download requires an io.ReaderAt")
+		}
+		if _, err := io.Copy(o.hash, io.NewSectionReader(r, 0, offset)); err != nil {
+			return offset, fmt.Errorf("error hashing the downloaded content: %s", err)
+		}
+	}
+
+	total := int64(-1)
+	failures := 0
+	for {
+		n, size, done, err := transferRange(ctx, fetch, w, offset, o.hash)
+		offset += n
+		if size >= 0 {
+			total = size
+		}
+		if n > 0 {
+			failures = 0
+			if o.checkpoint != nil {
+				o.checkpoint(offset)
+			}
+		}
+		if done {
+			break
+		}
+		if _, ok := err.(permanentError); ok {
+			return offset, err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return offset, ctxErr
+		}
+		failures++
+		if failures > o.retries {
+			return offset, fmt.Errorf("download interrupted at offset %d: %s", offset, err)
+		}
+		if o.backoff != nil {
+			timer := time.NewTimer(o.backoff(failures))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return offset, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	if total >= 0 && offset != total {
+		return offset, fmt.Errorf("downloaded %d bytes, but the server announced %d", offset, total)
+	}
+	if o.size >= 0 && offset != o.size {
+		return offset, fmt.Errorf("downloaded %d bytes, but expected %d", offset, o.size)
+	}
+	if o.hash != nil {
+		if sum := o.hash.Sum(nil); !bytes.Equal(sum, o.digest) {
+			return offset, fmt.Errorf("digest of the downloaded content is %x, but expected %x", sum, o.digest)
+		}
+	}
+	return offset, nil
+}
+
+// transferRange requests the content from offset on, and writes it to w. It
+// returns the number of bytes written, the total size of the content or -1
+// when unknown, and whether the content is complete.
+func transferRange(ctx context.Context, fetch RangeFetcher, w io.WriterAt, offset int64, h hash.Hash) (int64, int64, bool, error) {
+	rsp, err := fetch(ctx, ByteRange{Start: offset, End: -1})
+	if err != nil {
+		return 0, -1, false, err
+	}
+	defer rsp.Body.Close()
+
+	size := int64(-1)
+	switch rsp.StatusCode {
+	case http.StatusPartialContent:
+		cr, err := ParseContentRange(rsp.Header.Get("Content-Range"))
+		if err != nil {
+			return 0, -1, false, permanentError{err}
+		}
+		if cr.Start != offset {
+			return 0, -1, false, permanentError{fmt.Errorf("requested content from offset %d, but got %s", offset, cr)}
+		}
+		size = cr.Size
+	case http.StatusOK:
+		// The server ignored the range, skip what we already have.
+		if rsp.ContentLength >= 0 {
+			size = rsp.ContentLength
+		}
+		if _, err := io.CopyN(ioutil.Discard, rsp.Body, offset); err != nil {
+			return 0, size, false, err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Asking for the range past the end of complete content.
+		complete := strings.TrimPrefix(rsp.Header.Get("Content-Range"), "bytes */")
+		if size, err := strconv.ParseInt(complete, 10, 64); err == nil && size == offset {
+			return 0, size, true, nil
+		}
+		return 0, -1, false, permanentError{fmt.Errorf("range from offset %d not satisfiable", offset)}
+	default:
+		err := fmt.Errorf("unexpected response status %s", rsp.Status)
+		if rsp.StatusCode < http.StatusInternalServerError {
+			err = permanentError{err}
+		}
+		return 0, -1, false, err
+	}
+
+	n, err := io.Copy(&offsetWriter{w: w, offset: offset, hash: h}, rsp.Body)
+	if err != nil {
+		return n, size, false, err
+	}
+	if size >= 0 && offset+n < size {
+		return n, size, false, io.ErrUnexpectedEOF
+	}
+	return n, size, true, nil
+}
+
+// offsetWriter writes sequentially to an io.WriterAt, and hashes what it
+// wrote when hash isn't nil.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+	hash   hash.Hash
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.w.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	if w.hash != nil {
+		w.hash.Write(p[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memFile is an in-memory io.WriterAt and io.ReaderAt.
+type memFile struct {
+	buf []byte
+}
+
+func (f *memFile) WriteAt(p []byte, off int64) (int, error) {
+	if end := int(off) + len(p); end > len(f.buf) {
+		f.buf = append(f.buf, make([]byte, end-len(f.buf))...)
+	}
+	return copy(f.buf[off:], p), nil
+}
+
+func (f *memFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(f.buf)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.buf[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// failingReader returns an error after limit bytes.
+type failingReader struct {
+	r     io.Reader
+	limit int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.limit <= 0 {
+		return 0, errors.New("connection reset")
+	}
+	if len(p) > r.limit {
+		p = p[:r.limit]
+	}
+	n, err := r.r.Read(p)
+	r.limit -= n
+	return n, err
+}
+
+// flakyFetcher serves content with ranges, cutting every transfer after
+// chunk bytes.
+func flakyFetcher(content []byte, chunk int, requests *[]ByteRange) RangeFetcher {
+	doer := NewHandlerDoer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeContent(w, r, "application/octet-stream", time.Time{}, bytes.NewReader(content))
+	}))
+	return func(ctx context.Context, byteRange ByteRange) (*http.Response, error) {
+		*requests = append(*requests, byteRange)
+		req, err := http.NewRequest(http.MethodGet, "http://example.com/file", nil)
+		if err != nil {
+			return nil, err
+		}
+		SetRange(req, byteRange)
+		rsp, err := doer.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		rsp.Body = ioutil.NopCloser(&failingReader{r: rsp.Body, limit: chunk})
+		return rsp, nil
+	}
+}
+
+func TestDownloadResumable(t *testing.T) {
+	content := []byte("abcdefghijklmnopqrstuvwxyz")
+	digest := sha256.Sum256(content)
+
+	var requests []ByteRange
+	var checkpoints []int64
+	var f memFile
+	n, err := DownloadResumable(context.Background(), flakyFetcher(content, 10, &requests), &f,
+		WithDownloadRetries(1, nil),
+		WithDownloadCheckpoint(func(written int64) { checkpoints = append(checkpoints, written) }),
+		WithDownloadSize(int64(len(content))),
+		WithDownloadDigest(sha256.New(), digest[:]))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, content, f.buf)
+	assert.Equal(t, []ByteRange{{Start: 0, End: -1}, {Start: 10, End: -1}, {Start: 20, End: -1}}, requests)
+	assert.Equal(t, []int64{10, 20, 26}, checkpoints)
+}
+
+func TestDownloadResumableFromOffset(t *testing.T) {
+	content := []byte("abcdefghijklmnopqrstuvwxyz")
+	digest := sha256.Sum256(content)
+
+	var requests []ByteRange
+	f := memFile{buf: append([]byte(nil), content[:15]...)}
+	n, err := DownloadResumable(context.Background(), flakyFetcher(content, 100, &requests), &f,
+		WithDownloadOffset(15),
+		WithDownloadDigest(sha256.New(), digest[:]))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, content, f.buf)
+	assert.Equal(t, []ByteRange{{Start: 15, End: -1}}, requests)
+
+	// Resuming a complete download gets a 416, which isn't an error.
+	requests = nil
+	n, err = DownloadResumable(context.Background(), flakyFetcher(content, 100, &requests), &f,
+		WithDownloadOffset(int64(len(content))))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+}
+
+func TestDownloadResumableFailures(t *testing.T) {
+	content := []byte("abcdefghijklmnopqrstuvwxyz")
+
+	// Without retries, the first interruption is fatal.
+	var requests []ByteRange
+	var f memFile
+	n, err := DownloadResumable(context.Background(), flakyFetcher(content, 10, &requests), &f)
+	assert.Error(t, err)
+	assert.Equal(t, int64(10), n)
+
+	// Retries are counted while no progress is made.
+	requests = nil
+	n, err = DownloadResumable(context.Background(), flakyFetcher(content, 0, &requests), &memFile{},
+		WithDownloadRetries(2, func(int) time.Duration { return time.Millisecond }))
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), n)
+	assert.Len(t, requests, 3)
+
+	requests = nil
+	_, err = DownloadResumable(context.Background(), flakyFetcher(content, 100, &requests), &memFile{},
+		WithDownloadDigest(sha256.New(), []byte("wrong")))
+	assert.Error(t, err)
+
+	_, err = DownloadResumable(context.Background(), flakyFetcher(content, 100, &requests), &memFile{},
+		WithDownloadSize(10))
+	assert.Error(t, err)
+
+	// A digest can't be checked when the existing content can't be read.
+	_, err = DownloadResumable(context.Background(), flakyFetcher(content, 100, &requests), writerAtOnly{},
+		WithDownloadOffset(5), WithDownloadDigest(sha256.New(), nil))
+	assert.Error(t, err)
+}
+
+type writerAtOnly struct{}
+
+func (writerAtOnly) WriteAt(p []byte, off int64) (int, error) {
+	return len(p), nil
+}
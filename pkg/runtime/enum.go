@@ -0,0 +1,70 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// NotAllowedValue checks a bound parameter against the values allowed by its
+// enum, and returns the first value which isn't allowed, formatted as in
+// allowed, along with true. The parameter may be a pointer, which is allowed
+// when nil, or a slice, whose elements are checked one by one. Generated
+// servers use it to reject parameters outside of their enum.
+func NotAllowedValue(value interface{}, allowed []string) (string, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			if s, found := NotAllowedValue(v.Index(i).Interface(), allowed); found {
+				return s, true
+			}
+		}
+		return "", false
+	}
+	s := formatEnumValue(v)
+	for _, a := range allowed {
+		if s == a {
+			return "", false
+		}
+	}
+	return s, true
+}
+
+// formatEnumValue formats a scalar the way the code generator formats enum
+// values.
+func formatEnumValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,52 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotAllowedValue(t *testing.T) {
+	type Status string
+	available := Status("available")
+	sold := Status("sold")
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		allowed []string
+		want    string
+		found   bool
+	}{
+		{"allowed string", &available, []string{"available", "pending"}, "", false},
+		{"disallowed string", &sold, []string{"available", "pending"}, "sold", true},
+		{"nil pointer", (*Status)(nil), []string{"available"}, "", false},
+		{"allowed int", 10, []string{"10", "20"}, "", false},
+		{"disallowed int", 15, []string{"10", "20"}, "15", true},
+		{"float", 0.5, []string{"0.5", "1"}, "", false},
+		{"float32", float32(0.1), []string{"0.1"}, "", false},
+		{"bool", false, []string{"true"}, "false", true},
+		{"slice", &[]Status{available, sold}, []string{"available"}, "sold", true},
+		{"empty slice", []Status{}, []string{"available"}, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, found := NotAllowedValue(test.value, test.allowed)
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.want, value)
+		})
+	}
+}
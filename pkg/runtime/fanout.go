@@ -0,0 +1,71 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FanOutCall is one of the calls which FanOut makes concurrently, named after
+// the part of the result which it fetches. The generated ComposeXxx helpers
+// make a call per part of a response composed with x-compose.
+type FanOutCall struct {
+	Name string
+	Call func(ctx context.Context) error
+}
+
+// FanOutError is returned by FanOut when a call fails.
+type FanOutError struct {
+	Name string // The name of the call which failed
+	Err  error
+}
+
+func (e *FanOutError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Err)
+}
+
+func (e *FanOutError) Unwrap() error {
+	return e.Err
+}
+
+// FanOut makes calls concurrently, and returns once all of them returned,
+// so that none outlives it. The first call to fail cancels the context of
+// the others, and its error is returned as a *FanOutError. Calls which fail
+// after that, typically because of the cancellation, are ignored.
+func FanOut(ctx context.Context, calls ...FanOutCall) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	wg.Add(len(calls))
+	for _, call := range calls {
+		go func(call FanOutCall) {
+			defer wg.Done()
+			if err := call.Call(ctx); err != nil {
+				once.Do(func() {
+					firstErr = &FanOutError{Name: call.Name, Err: err}
+					cancel()
+				})
+			}
+		}(call)
+	}
+	wg.Wait()
+	return firstErr
+}
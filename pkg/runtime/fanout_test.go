@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanOut(t *testing.T) {
+	// The calls run concurrently: each one waits for the other to start.
+	started := make(chan struct{}, 2)
+	waitOther := func(ctx context.Context) error {
+		started <- struct{}{}
+		for len(started) < 2 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond):
+			}
+		}
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, FanOut(ctx, FanOutCall{"a", waitOther}, FanOutCall{"b", waitOther}))
+
+	assert.NoError(t, FanOut(context.Background()))
+}
+
+func TestFanOutError(t *testing.T) {
+	failure := errors.New("not found")
+	var returned int32
+	err := FanOut(context.Background(),
+		FanOutCall{"owner", func(ctx context.Context) error {
+			return failure
+		}},
+		FanOutCall{"orders", func(ctx context.Context) error {
+			// The failure of owner cancels the other calls, which are
+			// waited for.
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			atomic.StoreInt32(&returned, 1)
+			return ctx.Err()
+		}})
+	var fanOutErr *FanOutError
+	require.True(t, errors.As(err, &fanOutErr))
+	assert.Equal(t, "owner", fanOutErr.Name)
+	assert.True(t, errors.Is(err, failure))
+	assert.EqualError(t, err, "owner: not found")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&returned))
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FieldCipher encrypts and decrypts the values of the properties marked with
+// x-encrypted. The generated types call it with the JSON encoding of the
+// values, and name the field as Type.property, eg, Patient.ssn, so that
+// ciphers can use a key per field, or bind the ciphertext to the field.
+type FieldCipher interface {
+	EncryptField(field string, plaintext []byte) (string, error)
+	DecryptField(field string, ciphertext string) ([]byte, error)
+}
+
+// EncryptField returns the JSON string of the encrypted JSON encoding of
+// value, for the generated MarshalJSON methods.
+func EncryptField(c FieldCipher, field string, value interface{}) (json.RawMessage, error) {
+	if c == nil {
+		return nil, fmt.Errorf("no FieldCipher to encrypt %s", field)
+	}
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := c.EncryptField(field, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ciphertext)
+}
+
+// DecryptField decrypts the JSON string raw, and unmarshals the result into
+// value, for the generated UnmarshalJSON methods. A JSON null leaves value
+// unchanged.
+func DecryptField(c FieldCipher, field string, raw json.RawMessage, value interface{}) error {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil
+	}
+	if c == nil {
+		return fmt.Errorf("no FieldCipher to decrypt %s", field)
+	}
+	var ciphertext string
+	if err := json.Unmarshal(raw, &ciphertext); err != nil {
+		return fmt.Errorf("encrypted %s isn't a string: %s", field, err)
+	}
+	plaintext, err := c.DecryptField(field, ciphertext)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, value)
+}
+
+// aesFieldCipher is the FieldCipher returned by NewAESFieldCipher.
+type aesFieldCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESFieldCipher returns a FieldCipher which seals values with AES-GCM,
+// using a key of 16, 24 or 32 bytes. The field name is authenticated along
+// with the value, so that an encrypted value can't be moved to another field.
+// Ciphertexts are the base64 encoding of the nonce followed by the sealed
+// value.
+func NewAESFieldCipher(key []byte) (FieldCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesFieldCipher{aead: aead}, nil
+}
+
+func (c *aesFieldCipher) EncryptField(field string, plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plaintext)+c.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, []byte(field))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *aesFieldCipher) DecryptField(field string, ciphertext string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding encrypted %s: %s", field, err)
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return nil, errors.New("encrypted " + field + " is too short")
+	}
+	nonce, sealed := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, sealed, []byte(field))
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting %s: %s", field, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESFieldCipher(t *testing.T) {
+	c, err := NewAESFieldCipher([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+
+	raw, err := EncryptField(c, "Patient.ssn", "123-45-6789")
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "123-45-6789")
+
+	var ssn string
+	require.NoError(t, DecryptField(c, "Patient.ssn", raw, &ssn))
+	assert.Equal(t, "123-45-6789", ssn)
+
+	// Values are bound to their field.
+	assert.Error(t, DecryptField(c, "Patient.name", raw, &ssn))
+
+	// Encrypting twice gives different ciphertexts.
+	other, err := EncryptField(c, "Patient.ssn", "123-45-6789")
+	require.NoError(t, err)
+	assert.NotEqual(t, string(raw), string(other))
+
+	_, err = NewAESFieldCipher([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestDecryptField(t *testing.T) {
+	c, err := NewAESFieldCipher(make([]byte, 32))
+	require.NoError(t, err)
+
+	// Any JSON value can be encrypted.
+	raw, err := EncryptField(c, "Visit.codes", []int{1, 2})
+	require.NoError(t, err)
+	var codes []int
+	require.NoError(t, DecryptField(c, "Visit.codes", raw, &codes))
+	assert.Equal(t, []int{1, 2}, codes)
+
+	ssn := "unchanged"
+	assert.NoError(t, DecryptField(c, "Patient.ssn", json.RawMessage("null"), &ssn))
+	assert.Equal(t, "unchanged", ssn)
+	assert.EqualError(t, DecryptField(c, "Patient.ssn", json.RawMessage("12"), &ssn),
+		"encrypted Patient.ssn isn't a string: json: cannot unmarshal number into Go value of type string")
+	assert.Error(t, DecryptField(c, "Patient.ssn", json.RawMessage(`"not base64!"`), &ssn))
+
+	_, err = EncryptField(nil, "Patient.ssn", "123")
+	assert.EqualError(t, err, "no FieldCipher to encrypt Patient.ssn")
+}
@@ -0,0 +1,389 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOperator is the operator of a comparison in a Filter.
+type FilterOperator string
+
+// These are the operators of comparisons, all of which take a value, except
+// FilterIn, which takes a list.
+const (
+	FilterEq FilterOperator = "eq"
+	FilterNe FilterOperator = "ne"
+	FilterLt FilterOperator = "lt"
+	FilterLe FilterOperator = "le"
+	FilterGt FilterOperator = "gt"
+	FilterGe FilterOperator = "ge"
+	FilterIn FilterOperator = "in"
+)
+
+// Filter is an expression of the filter parameters of search endpoints, made
+// of comparisons of fields, combined with And and Or. The fields are those of
+// the generated XxxFields variables of parameters with x-filter-grammar, and
+// expressions are formatted in the grammar of their parameter. The zero
+// Filter matches everything, and is formatted as an empty string.
+type Filter struct {
+	field    string
+	operator FilterOperator
+	values   []interface{}
+
+	combinator string // "and" or "or", for combinations
+	operands   []Filter
+}
+
+func compare(field string, operator FilterOperator, values ...interface{}) Filter {
+	return Filter{field: field, operator: operator, values: values}
+}
+
+// And returns a filter matching when all the given filters match.
+func And(filters ...Filter) Filter {
+	return combine("and", filters)
+}
+
+// Or returns a filter matching when any of the given filters matches.
+func Or(filters ...Filter) Filter {
+	return combine("or", filters)
+}
+
+func combine(combinator string, filters []Filter) Filter {
+	var operands []Filter
+	for _, f := range filters {
+		if !f.IsZero() {
+			operands = append(operands, f)
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	return Filter{combinator: combinator, operands: operands}
+}
+
+// IsZero returns whether f is the zero Filter.
+func (f Filter) IsZero() bool {
+	return f.field == "" && len(f.operands) == 0
+}
+
+// Format returns f in the given grammar.
+func (f Filter) Format(grammar FilterGrammar) string {
+	if f.IsZero() {
+		return ""
+	}
+	if f.combinator == "" {
+		return grammar.Comparison(f.field, f.operator, f.values)
+	}
+	operands := make([]string, len(f.operands))
+	for i, operand := range f.operands {
+		operands[i] = operand.Format(grammar)
+		// And binds tighter than Or in the supported grammars.
+		if f.combinator == "and" && operand.combinator == "or" {
+			operands[i] = "(" + operands[i] + ")"
+		}
+	}
+	if f.combinator == "and" {
+		return grammar.And(operands)
+	}
+	return grammar.Or(operands)
+}
+
+// FilterGrammar formats filters in the syntax expected by an API. The values
+// of comparisons are strings, int64s, float64s, bools or time.Times.
+type FilterGrammar interface {
+	Comparison(field string, operator FilterOperator, values []interface{}) string
+	And(operands []string) string
+	Or(operands []string) string
+}
+
+// ODataFilterGrammar formats filters as OData $filter expressions, eg,
+// name eq 'Rex' and (age gt 3 or tag in ('cat','dog')).
+var ODataFilterGrammar FilterGrammar = odataGrammar{}
+
+type odataGrammar struct{}
+
+func (odataGrammar) Comparison(field string, operator FilterOperator, values []interface{}) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			formatted[i] = "'" + strings.Replace(v, "'", "''", -1) + "'"
+		default:
+			formatted[i] = formatFilterValue(v)
+		}
+	}
+	if operator == FilterIn {
+		return field + " in (" + strings.Join(formatted, ",") + ")"
+	}
+	return field + " " + string(operator) + " " + formatted[0]
+}
+
+func (odataGrammar) And(operands []string) string {
+	return strings.Join(operands, " and ")
+}
+
+func (odataGrammar) Or(operands []string) string {
+	return strings.Join(operands, " or ")
+}
+
+// RSQLFilterGrammar formats filters as RSQL expressions, eg,
+// name==Rex;(age=gt=3,tag=in=(cat,dog)).
+var RSQLFilterGrammar FilterGrammar = rsqlGrammar{}
+
+type rsqlGrammar struct{}
+
+var rsqlOperators = map[FilterOperator]string{
+	FilterEq: "==",
+	FilterNe: "!=",
+	FilterLt: "=lt=",
+	FilterLe: "=le=",
+	FilterGt: "=gt=",
+	FilterGe: "=ge=",
+	FilterIn: "=in=",
+}
+
+func (rsqlGrammar) Comparison(field string, operator FilterOperator, values []interface{}) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = formatFilterValue(value)
+		if s, ok := value.(string); ok && (s == "" || strings.ContainsAny(s, "\"'();,=!~<> ")) {
+			formatted[i] = strconv.Quote(s)
+		}
+	}
+	if operator == FilterIn {
+		return field + rsqlOperators[operator] + "(" + strings.Join(formatted, ",") + ")"
+	}
+	return field + rsqlOperators[operator] + formatted[0]
+}
+
+func (rsqlGrammar) And(operands []string) string {
+	return strings.Join(operands, ";")
+}
+
+func (rsqlGrammar) Or(operands []string) string {
+	return strings.Join(operands, ",")
+}
+
+// formatFilterValue formats the values which are written the same way in all
+// the supported grammars.
+func formatFilterValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		panic("unsupported filter value")
+	}
+}
+
+// StringFilterField is a string field of a Filter.
+type StringFilterField struct {
+	Name string // The name of the field in the grammar
+}
+
+// Eq matches when the field is equal to value.
+func (f StringFilterField) Eq(value string) Filter {
+	return compare(f.Name, FilterEq, value)
+}
+
+// Ne matches when the field is not equal to value.
+func (f StringFilterField) Ne(value string) Filter {
+	return compare(f.Name, FilterNe, value)
+}
+
+// Lt matches when the field is less than value.
+func (f StringFilterField) Lt(value string) Filter {
+	return compare(f.Name, FilterLt, value)
+}
+
+// Le matches when the field is less than or equal to value.
+func (f StringFilterField) Le(value string) Filter {
+	return compare(f.Name, FilterLe, value)
+}
+
+// Gt matches when the field is greater than value.
+func (f StringFilterField) Gt(value string) Filter {
+	return compare(f.Name, FilterGt, value)
+}
+
+// Ge matches when the field is greater than or equal to value.
+func (f StringFilterField) Ge(value string) Filter {
+	return compare(f.Name, FilterGe, value)
+}
+
+// In matches when the field is equal to one of values.
+func (f StringFilterField) In(values ...string) Filter {
+	operands := make([]interface{}, len(values))
+	for i, value := range values {
+		operands[i] = value
+	}
+	return compare(f.Name, FilterIn, operands...)
+}
+
+// IntFilterField is an integer field of a Filter.
+type IntFilterField struct {
+	Name string // The name of the field in the grammar
+}
+
+// Eq matches when the field is equal to value.
+func (f IntFilterField) Eq(value int64) Filter {
+	return compare(f.Name, FilterEq, value)
+}
+
+// Ne matches when the field is not equal to value.
+func (f IntFilterField) Ne(value int64) Filter {
+	return compare(f.Name, FilterNe, value)
+}
+
+// Lt matches when the field is less than value.
+func (f IntFilterField) Lt(value int64) Filter {
+	return compare(f.Name, FilterLt, value)
+}
+
+// Le matches when the field is less than or equal to value.
+func (f IntFilterField) Le(value int64) Filter {
+	return compare(f.Name, FilterLe, value)
+}
+
+// Gt matches when the field is greater than value.
+func (f IntFilterField) Gt(value int64) Filter {
+	return compare(f.Name, FilterGt, value)
+}
+
+// Ge matches when the field is greater than or equal to value.
+func (f IntFilterField) Ge(value int64) Filter {
+	return compare(f.Name, FilterGe, value)
+}
+
+// In matches when the field is equal to one of values.
+func (f IntFilterField) In(values ...int64) Filter {
+	operands := make([]interface{}, len(values))
+	for i, value := range values {
+		operands[i] = value
+	}
+	return compare(f.Name, FilterIn, operands...)
+}
+
+// NumberFilterField is a number field of a Filter.
+type NumberFilterField struct {
+	Name string // The name of the field in the grammar
+}
+
+// Eq matches when the field is equal to value.
+func (f NumberFilterField) Eq(value float64) Filter {
+	return compare(f.Name, FilterEq, value)
+}
+
+// Ne matches when the field is not equal to value.
+func (f NumberFilterField) Ne(value float64) Filter {
+	return compare(f.Name, FilterNe, value)
+}
+
+// Lt matches when the field is less than value.
+func (f NumberFilterField) Lt(value float64) Filter {
+	return compare(f.Name, FilterLt, value)
+}
+
+// Le matches when the field is less than or equal to value.
+func (f NumberFilterField) Le(value float64) Filter {
+	return compare(f.Name, FilterLe, value)
+}
+
+// Gt matches when the field is greater than value.
+func (f NumberFilterField) Gt(value float64) Filter {
+	return compare(f.Name, FilterGt, value)
+}
+
+// Ge matches when the field is greater than or equal to value.
+func (f NumberFilterField) Ge(value float64) Filter {
+	return compare(f.Name, FilterGe, value)
+}
+
+// In matches when the field is equal to one of values.
+func (f NumberFilterField) In(values ...float64) Filter {
+	operands := make([]interface{}, len(values))
+	for i, value := range values {
+		operands[i] = value
+	}
+	return compare(f.Name, FilterIn, operands...)
+}
+
+// TimeFilterField is a date-time field of a Filter.
+type TimeFilterField struct {
+	Name string // The name of the field in the grammar
+}
+
+// Eq matches when the field is equal to value.
+func (f TimeFilterField) Eq(value time.Time) Filter {
+	return compare(f.Name, FilterEq, value)
+}
+
+// Ne matches when the field is not equal to value.
+func (f TimeFilterField) Ne(value time.Time) Filter {
+	return compare(f.Name, FilterNe, value)
+}
+
+// Lt matches when the field is less than value.
+func (f TimeFilterField) Lt(value time.Time) Filter {
+	return compare(f.Name, FilterLt, value)
+}
+
+// Le matches when the field is less than or equal to value.
+func (f TimeFilterField) Le(value time.Time) Filter {
+	return compare(f.Name, FilterLe, value)
+}
+
+// Gt matches when the field is greater than value.
+func (f TimeFilterField) Gt(value time.Time) Filter {
+	return compare(f.Name, FilterGt, value)
+}
+
+// Ge matches when the field is greater than or equal to value.
+func (f TimeFilterField) Ge(value time.Time) Filter {
+	return compare(f.Name, FilterGe, value)
+}
+
+// In matches when the field is equal to one of values.
+func (f TimeFilterField) In(values ...time.Time) Filter {
+	operands := make([]interface{}, len(values))
+	for i, value := range values {
+		operands[i] = value
+	}
+	return compare(f.Name, FilterIn, operands...)
+}
+
+// BoolFilterField is a boolean field of a Filter.
+type BoolFilterField struct {
+	Name string // The name of the field in the grammar
+}
+
+// Eq matches when the field is equal to value.
+func (f BoolFilterField) Eq(value bool) Filter {
+	return compare(f.Name, FilterEq, value)
+}
+
+// Ne matches when the field is not equal to value.
+func (f BoolFilterField) Ne(value bool) Filter {
+	return compare(f.Name, FilterNe, value)
+}
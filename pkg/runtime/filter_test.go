@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterFormat(t *testing.T) {
+	name := StringFilterField{Name: "name"}
+	age := IntFilterField{Name: "age"}
+	weight := NumberFilterField{Name: "weight"}
+	vaccinated := BoolFilterField{Name: "vaccinated"}
+	born := TimeFilterField{Name: "born"}
+
+	filter := And(
+		name.Ne("it's"),
+		Or(age.Lt(2), age.Ge(10), weight.In(1.5, 2)),
+		vaccinated.Ne(false),
+		born.Le(time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600))),
+	)
+	assert.Equal(t, "name ne 'it''s' and (age lt 2 or age ge 10 or weight in (1.5,2)) and vaccinated ne false and born le 2020-01-02T03:04:05+01:00",
+		filter.Format(ODataFilterGrammar))
+	assert.Equal(t, `name!="it's";(age=lt=2,age=ge=10,weight=in=(1.5,2));vaccinated!=false;born=le=2020-01-02T03:04:05+01:00`,
+		filter.Format(RSQLFilterGrammar))
+
+	// And within Or needs no parentheses.
+	filter = Or(And(name.Eq("Rex"), age.Gt(3)), name.In("", "Tom"))
+	assert.Equal(t, "name eq 'Rex' and age gt 3 or name in ('','Tom')", filter.Format(ODataFilterGrammar))
+	assert.Equal(t, `name==Rex;age=gt=3,name=in=("",Tom)`, filter.Format(RSQLFilterGrammar))
+}
+
+func TestFilterZero(t *testing.T) {
+	name := StringFilterField{Name: "name"}
+
+	assert.True(t, Filter{}.IsZero())
+	assert.Equal(t, "", Filter{}.Format(ODataFilterGrammar))
+	assert.Equal(t, "", And().Format(RSQLFilterGrammar))
+	// Zero filters are left out of combinations.
+	assert.Equal(t, "name eq 'Rex'", And(Filter{}, name.Eq("Rex"), Or()).Format(ODataFilterGrammar))
+}
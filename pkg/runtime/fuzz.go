@@ -0,0 +1,127 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// NewFuzzRequest builds a request to an operation from the values of a fuzz
+// target: the values of the path parameters, which replace the placeholders
+// of the path template, the raw query and the body, whose JSON is mutated as
+// MutateJSON does. It's used by the generated fuzz tests.
+func NewFuzzRequest(method, pathTemplate string, pathParams map[string]string, rawQuery string, body []byte,
+	mutation uint64) *http.Request {
+	path := pathTemplate
+	for name, value := range pathParams {
+		path = strings.Replace(path, "{"+name+"}", url.PathEscape(value), -1)
+	}
+	body = MutateJSON(body, mutation)
+	req := httptest.NewRequest(method, "/", bytes.NewReader(body))
+	req.URL = &url.URL{RawPath: path, RawQuery: rawQuery}
+	req.URL.Path, _ = url.PathUnescape(path)
+	req.RequestURI = req.URL.RequestURI()
+	return req
+}
+
+// MutateJSON applies structural mutations to a JSON document, chosen by
+// mutation: values are replaced by values of another kind, or by extreme
+// ones, fields are removed, and array elements duplicated. Starting from a
+// valid payload, the result is still JSON, but breaks the schema in the ways
+// which binders and handlers tend to overlook, which byte level mutations
+// rarely reach. Documents which aren't JSON, or a zero mutation, are returned
+// unchanged.
+func MutateJSON(data []byte, mutation uint64) []byte {
+	if mutation == 0 {
+		return data
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return data
+	}
+	rnd := rand.New(rand.NewSource(int64(mutation)))
+	for i := 0; i < 1+int(mutation%3); i++ {
+		doc = mutateJSONValue(rnd, doc)
+	}
+	mutated, err := json.Marshal(doc)
+	if err != nil {
+		return data
+	}
+	return mutated
+}
+
+// jsonReplacements are the values which mutated values are replaced by.
+var jsonReplacements = []interface{}{
+	nil,
+	true,
+	json.Number("0"),
+	json.Number("-1"),
+	json.Number("1e308"),
+	json.Number("9223372036854775808"),
+	json.Number("0.5"),
+	"",
+	strings.Repeat("a", 4096),
+	"\x00‮\U0001F600",
+	"2006-01-02T15:04:05Z",
+	[]interface{}{},
+	map[string]interface{}{},
+}
+
+// mutateJSONValue applies a mutation to a value, or to one of the values
+// nested in it, and returns the result.
+func mutateJSONValue(rnd *rand.Rand, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 || rnd.Intn(4) == 0 {
+			break
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		key := keys[rnd.Intn(len(keys))]
+		if rnd.Intn(4) == 0 {
+			delete(v, key)
+		} else {
+			v[key] = mutateJSONValue(rnd, v[key])
+		}
+		return v
+	case []interface{}:
+		if len(v) == 0 || rnd.Intn(4) == 0 {
+			break
+		}
+		i := rnd.Intn(len(v))
+		if rnd.Intn(4) == 0 {
+			return append(v, v[i])
+		}
+		v[i] = mutateJSONValue(rnd, v[i])
+		return v
+	}
+	if rnd.Intn(8) == 0 {
+		// Nest the value, which turns scalars into arrays, and arrays into
+		// arrays of arrays.
+		return []interface{}{value}
+	}
+	return jsonReplacements[rnd.Intn(len(jsonReplacements))]
+}
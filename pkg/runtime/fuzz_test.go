@@ -0,0 +1,56 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFuzzRequest(t *testing.T) {
+	req := NewFuzzRequest("POST", "/shelves/{shelfId}/books/{bookId}",
+		map[string]string{"shelfId": "a/b c", "bookId": "7"}, "tags=x&tags=y", []byte(`{"title":"Dune"}`), 0)
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "/shelves/a/b c/books/7", req.URL.Path)
+	assert.Equal(t, "/shelves/a%2Fb%20c/books/7?tags=x&tags=y", req.RequestURI)
+	body, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"title":"Dune"}`, string(body))
+
+	req = NewFuzzRequest("POST", "/books", nil, "", []byte(`{"title":"Dune","year":1965}`), 42)
+	body, err = ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.NotEqual(t, `{"title":"Dune","year":1965}`, string(body))
+}
+
+func TestMutateJSON(t *testing.T) {
+	doc := []byte(`{"title":"Dune","authors":[{"name":"Frank Herbert"}],"year":1965}`)
+	mutations := map[string]bool{}
+	for mutation := uint64(1); mutation <= 100; mutation++ {
+		mutated := MutateJSON(doc, mutation)
+		assert.True(t, json.Valid(mutated), string(mutated))
+		assert.Equal(t, mutated, MutateJSON(doc, mutation), "mutations have to be deterministic")
+		mutations[string(mutated)] = true
+	}
+	assert.True(t, len(mutations) > 50, "%d distinct mutations", len(mutations))
+
+	assert.Equal(t, doc, MutateJSON(doc, 0))
+
+	// Documents which aren't JSON are left alone.
+	assert.Equal(t, "not json", string(MutateJSON([]byte("not json"), 7)))
+}
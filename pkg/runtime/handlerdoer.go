@@ -0,0 +1,62 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// HandlerDoer performs HTTP requests by calling an http.Handler directly,
+// without a network round trip. It satisfies the HttpRequestDoer interface of
+// the generated clients, which lets a client talk to a server generated from
+// the same spec within a single process.
+type HandlerDoer struct {
+	Handler http.Handler
+}
+
+// NewHandlerDoer returns a HandlerDoer which serves requests with handler.
+func NewHandlerDoer(handler http.Handler) *HandlerDoer {
+	return &HandlerDoer{Handler: handler}
+}
+
+// Do serves req with the wrapped handler and returns the recorded response.
+// The request is given the fields which an http.Server would have filled in
+// for an incoming request.
+func (d *HandlerDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	serverReq := req.WithContext(ctx)
+	if serverReq.Body == nil {
+		serverReq.Body = http.NoBody
+	}
+	if serverReq.RequestURI == "" {
+		serverReq.RequestURI = req.URL.RequestURI()
+	}
+	if serverReq.Host == "" {
+		serverReq.Host = req.URL.Host
+	}
+	if serverReq.RemoteAddr == "" {
+		serverReq.RemoteAddr = "192.0.2.1:1234"
+	}
+
+	rec := httptest.NewRecorder()
+	d.Handler.ServeHTTP(rec, serverReq)
+	rsp := rec.Result()
+	rsp.Request = req
+	return rsp, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerDoer(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(r.Method + " " + r.RequestURI + " " + string(body)))
+	})
+	doer := NewHandlerDoer(handler)
+
+	req, err := http.NewRequest("POST", "http://example.com/pets?limit=1", strings.NewReader("fido"))
+	require.NoError(t, err)
+	rsp, err := doer.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rsp.StatusCode)
+	assert.Equal(t, "text/plain", rsp.Header.Get("Content-Type"))
+	assert.Equal(t, req, rsp.Request)
+	body, err := ioutil.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "POST /pets?limit=1 fido", string(body))
+
+	// Requests without a body must still be readable by the handler.
+	req, err = http.NewRequest("GET", "http://example.com/pets", nil)
+	require.NoError(t, err)
+	rsp, err = doer.Do(req)
+	require.NoError(t, err)
+	body, err = ioutil.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "GET /pets ", string(body))
+
+	// A canceled context fails the request without calling the handler.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = doer.Do(req.WithContext(ctx))
+	assert.Equal(t, context.Canceled, err)
+}
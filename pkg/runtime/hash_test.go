@@ -0,0 +1,40 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONHash(t *testing.T) {
+	// The SHA-256 digest of {"a":1,"b":2}.
+	const want = "43258cff783fe7036d8a43033f830adfc60ec037382473548ac742b888292777"
+
+	hash, err := JSONHash(map[string]int{"b": 2, "a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, want, hash)
+
+	hash, err = JSONHash(struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, want, hash)
+
+	_, err = JSONHash(func() {})
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// JobPoller fetches the state of an asynchronous job, and reports whether it
+// reached a terminal status. The generated XxxWatcher helpers pass a poller
+// which calls the status operation of the job.
+type JobPoller func(ctx context.Context) (done bool, err error)
+
+// JobOption configures WatchJob.
+type JobOption func(*jobOptions)
+
+type jobOptions struct {
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+// DefaultJobInterval is the time WatchJob waits between two polls, unless
+// WithJobInterval is given.
+const DefaultJobInterval = time.Second
+
+// WithJobInterval sets the time to wait between two polls.
+func WithJobInterval(interval time.Duration) JobOption {
+	return func(o *jobOptions) {
+		o.interval = interval
+	}
+}
+
+// WithJobBackoff doubles the time to wait after every poll, up to max, for
+// jobs which may take long.
+func WithJobBackoff(max time.Duration) JobOption {
+	return func(o *jobOptions) {
+		o.maxInterval = max
+	}
+}
+
+// JobFailedError is returned by the generated watchers when a job reaches
+// one of the statuses which the spec declares as failures.
+type JobFailedError struct {
+	Status string // The status of the job
+}
+
+func (e *JobFailedError) Error() string {
+	return fmt.Sprintf("job failed with status '%s'", e.Status)
+}
+
+// WatchJob calls poll until it reports that the job is done, or fails,
+// waiting between calls as configured by the options. It returns the error
+// of the last call to poll, or the error of ctx when it's done first.
+func WatchJob(ctx context.Context, poll JobPoller, opts ...JobOption) error {
+	o := jobOptions{interval: DefaultJobInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	interval := o.interval
+	for {
+		done, err := poll(ctx)
+		if done || err != nil {
+			return err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if o.maxInterval > interval {
+			interval *= 2
+			if interval > o.maxInterval {
+				interval = o.maxInterval
+			}
+		}
+	}
+}
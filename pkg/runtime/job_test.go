@@ -0,0 +1,69 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchJob(t *testing.T) {
+	polls := 0
+	err := WatchJob(context.Background(), func(ctx context.Context) (bool, error) {
+		polls++
+		return polls == 3, nil
+	}, WithJobInterval(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, polls)
+
+	failure := &JobFailedError{Status: "failed"}
+	err = WatchJob(context.Background(), func(ctx context.Context) (bool, error) {
+		return true, failure
+	})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, "job failed with status 'failed'", err.Error())
+
+	pollErr := errors.New("unavailable")
+	err = WatchJob(context.Background(), func(ctx context.Context) (bool, error) {
+		return false, pollErr
+	})
+	assert.Equal(t, pollErr, err)
+}
+
+func TestWatchJobBackoff(t *testing.T) {
+	var times []time.Time
+	err := WatchJob(context.Background(), func(ctx context.Context) (bool, error) {
+		times = append(times, time.Now())
+		return len(times) == 4, nil
+	}, WithJobInterval(5*time.Millisecond), WithJobBackoff(10*time.Millisecond))
+	assert.NoError(t, err)
+	// The intervals are 5ms, 10ms, and 10ms again.
+	assert.True(t, times[3].Sub(times[0]) >= 25*time.Millisecond)
+}
+
+func TestWatchJobContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	polls := 0
+	err := WatchJob(ctx, func(ctx context.Context) (bool, error) {
+		polls++
+		return false, nil
+	}, WithJobInterval(time.Hour))
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, polls)
+}
@@ -0,0 +1,254 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TraceIDFunc returns the ID of the trace which a request belongs to, from
+// its context, or "" when it isn't traced. With OpenTelemetry, for example:
+//
+//	func(ctx context.Context) string {
+//		sc := trace.SpanContextFromContext(ctx)
+//		if !sc.IsSampled() {
+//			return ""
+//		}
+//		return sc.TraceID().String()
+//	}
+type TraceIDFunc func(ctx context.Context) string
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the buckets of
+// latency histograms, unless others are given.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// LatencyHistogram is a histogram of the time taken to handle the requests to
+// each operation, labeled with the operation ID and the status code, as
+// returned by SLOObservation.Labels. When it's given a TraceIDFunc, each
+// bucket keeps the trace ID of its latest traced request as an exemplar,
+// so that dashboards can pivot from a latency to a trace of it. It is safe for
+// concurrent use.
+type LatencyHistogram struct {
+	name    string
+	buckets []float64
+	traceID TraceIDFunc
+	now     func() time.Time
+
+	mu     sync.Mutex
+	series map[latencyLabels]*latencySeries
+}
+
+type latencyLabels struct {
+	operationID string
+	status      int
+}
+
+type latencySeries struct {
+	counts    []uint64 // By bucket, not cumulative, the last one being +Inf
+	exemplars []*latencyExemplar
+	sum       float64
+	count     uint64
+}
+
+type latencyExemplar struct {
+	traceID string
+	value   float64
+	time    time.Time
+}
+
+// NewLatencyHistogram returns a histogram named name, eg,
+// http_request_duration_seconds, with buckets of the given upper bounds in
+// seconds, or DefaultLatencyBuckets when there are none. traceID may be nil,
+// in which case there are no exemplars.
+func NewLatencyHistogram(name string, buckets []float64, traceID TraceIDFunc) *LatencyHistogram {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &LatencyHistogram{
+		name:    name,
+		buckets: sorted,
+		traceID: traceID,
+		now:     time.Now,
+		series:  make(map[latencyLabels]*latencySeries),
+	}
+}
+
+// Observe records that a request to an operation took d to handle, and got a
+// response with the given status code.
+func (h *LatencyHistogram) Observe(ctx context.Context, operationID string, status int, d time.Duration) {
+	value := d.Seconds()
+	bucket := sort.SearchFloat64s(h.buckets, value)
+	var exemplar *latencyExemplar
+	if h.traceID != nil {
+		if traceID := h.traceID(ctx); traceID != "" {
+			exemplar = &latencyExemplar{traceID: traceID, value: value, time: h.now()}
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	labels := latencyLabels{operationID: operationID, status: status}
+	series, found := h.series[labels]
+	if !found {
+		series = &latencySeries{
+			counts:    make([]uint64, len(h.buckets)+1),
+			exemplars: make([]*latencyExemplar, len(h.buckets)+1),
+		}
+		h.series[labels] = series
+	}
+	series.counts[bucket]++
+	series.sum += value
+	series.count++
+	if exemplar != nil {
+		series.exemplars[bucket] = exemplar
+	}
+}
+
+// RecordSLO observes the request of o. It's an SLORecorder, to pass to the
+// generated SLOMiddleware or SLOHandler, which only observe the operations
+// with an SLO. LatencyMiddleware and LatencyHandler observe all of them.
+func (h *LatencyHistogram) RecordSLO(ctx context.Context, o SLOObservation) {
+	h.Observe(ctx, o.OperationID, o.Status, o.Duration)
+}
+
+// WriteOpenMetrics writes the histogram in the OpenMetrics text format, with
+// its exemplars, which the Prometheus text format doesn't support.
+func (h *LatencyHistogram) WriteOpenMetrics(w io.Writer) error {
+	h.mu.Lock()
+	labels := make([]latencyLabels, 0, len(h.series))
+	for l := range h.series {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].operationID != labels[j].operationID {
+			return labels[i].operationID < labels[j].operationID
+		}
+		return labels[i].status < labels[j].status
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# TYPE %s histogram\n", h.name)
+	fmt.Fprintf(&buf, "# HELP %s Time taken to handle the requests to the operation.\n", h.name)
+	for _, l := range labels {
+		series := h.series[l]
+		selector := fmt.Sprintf(`operation="%s",code="%d"`, escapeLabelValue(l.operationID), l.status)
+		var cumulative uint64
+		for i, count := range series.counts {
+			cumulative += count
+			le := "+Inf"
+			if i < len(h.buckets) {
+				le = formatFloat(h.buckets[i])
+			}
+			fmt.Fprintf(&buf, `%s_bucket{%s,le="%s"} %d`, h.name, selector, le, cumulative)
+			if e := series.exemplars[i]; e != nil {
+				fmt.Fprintf(&buf, ` # {trace_id="%s"} %s %s`, escapeLabelValue(e.traceID), formatFloat(e.value),
+					strconv.FormatFloat(float64(e.time.UnixNano())/1e9, 'f', 3, 64))
+			}
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%s_count{%s} %d\n", h.name, selector, series.count)
+		fmt.Fprintf(&buf, "%s_sum{%s} %s\n", h.name, selector, formatFloat(series.sum))
+	}
+	h.mu.Unlock()
+
+	buf.WriteString("# EOF\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// ServeHTTP serves the histogram in the OpenMetrics text format, as written by
+// WriteOpenMetrics.
+func (h *LatencyHistogram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", OpenMetricsContentType)
+	_ = h.WriteOpenMetrics(w)
+}
+
+// LatencyHandler wraps a handler of generated operations, such as the one of a
+// generated chi server, so that h observes every request routed to one of the
+// operations, whether it has an SLO or not. The server has to be generated
+// with the slo target, which reports the operation of each request.
+func LatencyHandler(h *LatencyHistogram, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		r, rec := startSLO(r, nil)
+		recorder := &auditStatusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		if rec.operationID == "" {
+			return
+		}
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		h.Observe(r.Context(), rec.operationID, status, time.Since(start))
+	})
+}
+
+// LatencyMiddleware returns an echo middleware with which h observes every
+// request routed to one of the operations, whether it has an SLO or not. The
+// server has to be generated with the slo target, which reports the
+// operation of each request.
+func LatencyMiddleware(h *LatencyHistogram) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			r, rec := startSLO(c.Request(), nil)
+			c.SetRequest(r)
+			err := next(c)
+
+			if rec.operationID == "" {
+				return err
+			}
+			h.Observe(r.Context(), rec.operationID, echoResponseStatus(c, err), time.Since(start))
+			return err
+		}
+	}
+}
+
+// SLOLatencyBuckets returns DefaultLatencyBuckets along with the latency
+// thresholds of the SLOs, which SLORecordingRules needs to be buckets of the
+// duration histogram.
+func SLOLatencyBuckets(slos map[string]SLO) []float64 {
+	seen := make(map[float64]bool)
+	var buckets []float64
+	add := func(bound float64) {
+		if !seen[bound] {
+			seen[bound] = true
+			buckets = append(buckets, bound)
+		}
+	}
+	for _, bound := range DefaultLatencyBuckets {
+		add(bound)
+	}
+	for _, slo := range sortedSLOs(slos) {
+		if slo.Latency != 0 {
+			add(slo.Latency.Seconds())
+		}
+	}
+	sort.Float64s(buckets)
+	return buckets
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceIDKey struct{}
+
+func testTraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	h := NewLatencyHistogram("http_request_duration_seconds", []float64{0.5, 0.1}, testTraceID)
+	h.now = func() time.Time { return time.Unix(1600000000, 250000000) }
+
+	traced := context.WithValue(context.Background(), traceIDKey{}, "4bf92f3577b34da6a3ce929d0e0e4736")
+	h.Observe(context.Background(), "getPet", 200, 50*time.Millisecond)
+	h.Observe(traced, "getPet", 200, 300*time.Millisecond)
+	h.Observe(context.Background(), "getPet", 200, 2*time.Second)
+	h.RecordSLO(traced, SLOObservation{SLO: testSLOs["listPets"], Status: 503, Duration: 100 * time.Millisecond})
+
+	var buf bytes.Buffer
+	require.NoError(t, h.WriteOpenMetrics(&buf))
+	assert.Equal(t, `# TYPE http_request_duration_seconds histogram
+# HELP http_request_duration_seconds Time taken to handle the requests to the operation.
+http_request_duration_seconds_bucket{operation="getPet",code="200",le="0.1"} 1
+http_request_duration_seconds_bucket{operation="getPet",code="200",le="0.5"} 2 # {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"} 0.3 1600000000.250
+http_request_duration_seconds_bucket{operation="getPet",code="200",le="+Inf"} 3
+http_request_duration_seconds_count{operation="getPet",code="200"} 3
+http_request_duration_seconds_sum{operation="getPet",code="200"} 2.35
+http_request_duration_seconds_bucket{operation="listPets",code="503",le="0.1"} 1 # {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"} 0.1 1600000000.250
+http_request_duration_seconds_bucket{operation="listPets",code="503",le="0.5"} 1
+http_request_duration_seconds_bucket{operation="listPets",code="503",le="+Inf"} 1
+http_request_duration_seconds_count{operation="listPets",code="503"} 1
+http_request_duration_seconds_sum{operation="listPets",code="503"} 0.1
+# EOF
+`, buf.String())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, OpenMetricsContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, buf.String(), rec.Body.String())
+}
+
+func TestLatencyHistogramWithoutTraces(t *testing.T) {
+	h := NewLatencyHistogram("latency_seconds", nil, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Observe(context.Background(), "listPets", 200, time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	var buf bytes.Buffer
+	require.NoError(t, h.WriteOpenMetrics(&buf))
+	assert.Contains(t, buf.String(), "latency_seconds_bucket{operation=\"listPets\",code=\"200\",le=\"0.005\"} 10\n")
+	assert.NotContains(t, buf.String(), "trace_id")
+}
+
+func TestLatencyHandler(t *testing.T) {
+	h := NewLatencyHistogram("http_request_duration_seconds", nil, nil)
+	var observations []SLOObservation
+	record := func(ctx context.Context, o SLOObservation) {
+		observations = append(observations, o)
+	}
+	handler := LatencyHandler(h, SLOHandler(testSLOs, record, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pets":
+			SLOOperation(r.Context(), "listPets")
+			w.WriteHeader(http.StatusBadGateway)
+		case "/health":
+			SLOOperation(r.Context(), "health")
+			_, _ = w.Write([]byte("ok"))
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pets", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	// Operations without an SLO are observed by the histogram, but only those
+	// with one are recorded by the SLOHandler within.
+	var buf bytes.Buffer
+	require.NoError(t, h.WriteOpenMetrics(&buf))
+	assert.Contains(t, buf.String(), `http_request_duration_seconds_count{operation="listPets",code="502"} 1`)
+	assert.Contains(t, buf.String(), `http_request_duration_seconds_count{operation="health",code="200"} 1`)
+	assert.NotContains(t, buf.String(), "404")
+	require.Len(t, observations, 1)
+	assert.Equal(t, "listPets", observations[0].OperationID)
+}
+
+func TestSLOLatencyBuckets(t *testing.T) {
+	buckets := SLOLatencyBuckets(testSLOs)
+	assert.Contains(t, buckets, 0.25)
+	assert.Len(t, buckets, len(DefaultLatencyBuckets))
+
+	slos := map[string]SLO{"getPet": {OperationID: "getPet", Latency: 300 * time.Millisecond, LatencyTarget: 0.99}}
+	buckets = SLOLatencyBuckets(slos)
+	assert.Len(t, buckets, len(DefaultLatencyBuckets)+1)
+	assert.Equal(t, 0.3, buckets[6])
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Manifest describes the operations compiled into a binary, and the spec they
+// were generated from, in a machine readable form. The manifest target
+// generates one for the operations of a spec, which platforms can fetch to
+// synchronize the routes of an API gateway, for example.
+type Manifest struct {
+	SpecVersion string              `json:"specVersion,omitempty"`
+	SpecHash    string              `json:"specHash"`
+	Operations  []ManifestOperation `json:"operations"`
+}
+
+// ManifestOperation describes a single operation of a Manifest. Path is the
+// path template of the spec, such as /pets/{id}.
+type ManifestOperation struct {
+	OperationID string   `json:"operationId"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// ManifestHandler returns a handler which responds to GET and HEAD requests
+// with the JSON encoding of m. The hash of the encoding is sent as the ETag of
+// the response, so that clients polling the manifest can make conditional
+// requests with If-None-Match. The spec hash wouldn't do, as binaries built
+// from the same spec with different tag filters serve different manifests.
+func ManifestHandler(m Manifest) http.Handler {
+	buf, err := json.Marshal(m)
+	if err != nil {
+		// A Manifest only holds strings, so this can't happen.
+		panic(fmt.Sprintf("error marshaling manifest: %s", err))
+	}
+	sum := sha256.Sum256(buf)
+	etag := fmt.Sprintf(`"sha256:%s"`, hex.EncodeToString(sum[:]))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodHead}, ", "))
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(buf))
+	})
+}
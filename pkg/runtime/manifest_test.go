@@ -0,0 +1,71 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestHandler(t *testing.T) {
+	manifest := Manifest{
+		SpecVersion: "1.0.0",
+		SpecHash:    "sha256:abc",
+		Operations: []ManifestOperation{
+			{OperationID: "findPets", Method: "GET", Path: "/pets", Tags: []string{"pets"}},
+			{OperationID: "deletePet", Method: "DELETE", Path: "/pets/{id}"},
+		},
+	}
+	handler := ManifestHandler(manifest)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/manifest", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	sum := sha256.Sum256(rec.Body.Bytes())
+	etag := `"sha256:` + hex.EncodeToString(sum[:]) + `"`
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+	assert.JSONEq(t, `{
+		"specVersion": "1.0.0",
+		"specHash": "sha256:abc",
+		"operations": [
+			{"operationId": "findPets", "method": "GET", "path": "/pets", "tags": ["pets"]},
+			{"operationId": "deletePet", "method": "DELETE", "path": "/pets/{id}"}
+		]
+	}`, rec.Body.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/manifest", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	// A manifest of the same spec with other operations, as generated with
+	// other tag filters, has another ETag.
+	manifest.Operations = manifest.Operations[:1]
+	rec = httptest.NewRecorder()
+	ManifestHandler(manifest).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/manifest", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+}
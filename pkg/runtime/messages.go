@@ -0,0 +1,163 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// MessageID identifies a human readable message, which the generated server
+// wrappers and the request validator send back to the caller.
+type MessageID string
+
+// These are all the messages, along with the arguments which they're given.
+const (
+	// The parameter has an invalid format. Args: parameter name, error.
+	MsgInvalidParamFormat MessageID = "InvalidParamFormat"
+	// The parameter is empty. Args: parameter name.
+	MsgEmptyParam MessageID = "EmptyParam"
+	// The parameter isn't valid JSON. Args: parameter name.
+	MsgUnmarshalParamJSON MessageID = "UnmarshalParamJSON"
+	// The cookie parameter can't be unescaped. Args: parameter name.
+	MsgUnescapeCookieParam MessageID = "UnescapeCookieParam"
+	// The header parameter has more than one value. Args: parameter name,
+	// number of values.
+	MsgParamValueCount MessageID = "ParamValueCount"
+	// The request body isn't valid. Args: error.
+	MsgInvalidBody MessageID = "InvalidBody"
+	// A required query parameter is missing. Args: parameter name.
+	MsgRequiredQueryParam MessageID = "RequiredQueryParam"
+	// A required header parameter is missing. Args: parameter name.
+	MsgRequiredHeaderParam MessageID = "RequiredHeaderParam"
+	// A required cookie parameter is missing. Args: parameter name.
+	MsgRequiredCookieParam MessageID = "RequiredCookieParam"
+	// The parameter has a value outside of its enum. Args: parameter name,
+	// value, allowed values.
+	MsgParamNotAllowed MessageID = "ParamNotAllowed"
+	// The request doesn't match any route of the spec. Args: reason.
+	MsgRouteNotFound MessageID = "RouteNotFound"
+	// Finding the route of the request failed. Args: error.
+	MsgRouteError MessageID = "RouteError"
+	// The request doesn't conform to the spec. Args: the first line of the
+	// validation error.
+	MsgInvalidRequest MessageID = "InvalidRequest"
+	// The security requirements of the operation aren't met. Args: error.
+	MsgSecurityRequirements MessageID = "SecurityRequirements"
+	// The request satisfies none of the security requirements of its
+	// operation, as checked by a generated server. The reasons aren't
+	// given, not to reveal them to the caller. Args: none.
+	MsgSecurityUnsatisfied MessageID = "SecurityUnsatisfied"
+	// Validating the request failed. Args: error.
+	MsgValidationError MessageID = "ValidationError"
+	// Another message, about a parameter or property with a description in
+	// the spec. Args: message, parameter or property name, description.
+	MsgFieldDescription MessageID = "FieldDescription"
+)
+
+// defaultMessages holds the English format strings of all messages. It's
+// never modified, as handlers read it concurrently.
+var defaultMessages = map[MessageID]string{
+	MsgInvalidParamFormat:   "Invalid format for parameter %s: %s",
+	MsgEmptyParam:           "Invalid format for parameter %s: value is empty",
+	MsgUnmarshalParamJSON:   "Error unmarshaling parameter '%s' as JSON",
+	MsgUnescapeCookieParam:  "Error unescaping cookie parameter '%s'",
+	MsgParamValueCount:      "Expected one value for %s, got %d",
+	MsgInvalidBody:          "Invalid request body: %s",
+	MsgRequiredQueryParam:   "Query argument %s is required, but not found",
+	MsgRequiredHeaderParam:  "Header parameter %s is required, but not found",
+	MsgRequiredCookieParam:  "Cookie parameter %s is required, but not found",
+	MsgParamNotAllowed:      "Parameter %s can't be '%s', allowed values are: %s",
+	MsgRouteNotFound:        "%s",
+	MsgRouteError:           "error validating route: %s",
+	MsgInvalidRequest:       "%s",
+	MsgSecurityRequirements: "%s",
+	MsgSecurityUnsatisfied:  "The security requirements of the operation aren't satisfied",
+	MsgValidationError:      "error validating request: %s",
+	MsgFieldDescription:     "%s (%s: %s)",
+}
+
+// DefaultMessage returns the English format string of the message with the
+// given ID, and whether there's one, eg, for a catalog to fall back on.
+func DefaultMessage(id MessageID) (string, bool) {
+	format, found := defaultMessages[id]
+	return format, found
+}
+
+// MessageCatalog returns the message with the given ID, formatted with args,
+// for the given request. The request allows picking the language of the
+// caller, eg, from the Accept-Language header. When a catalog returns an
+// empty string, the default message is used.
+type MessageCatalog func(r *http.Request, id MessageID, args ...interface{}) string
+
+var (
+	messageCatalogMu sync.RWMutex
+	messageCatalog   MessageCatalog
+)
+
+// SetMessageCatalog replaces the messages of the generated server wrappers
+// and the request validator with those of catalog. Passing nil restores the
+// default messages.
+func SetMessageCatalog(catalog MessageCatalog) {
+	messageCatalogMu.Lock()
+	defer messageCatalogMu.Unlock()
+	messageCatalog = catalog
+}
+
+// Message returns the message with the given ID for the request, from the
+// catalog set by SetMessageCatalog, or from the default messages.
+func Message(r *http.Request, id MessageID, args ...interface{}) string {
+	messageCatalogMu.RLock()
+	catalog := messageCatalog
+	messageCatalogMu.RUnlock()
+
+	if catalog != nil {
+		if msg := catalog(r, id, args...); msg != "" {
+			return msg
+		}
+	}
+	format, found := DefaultMessage(id)
+	if !found {
+		return fmt.Sprintf("%s: %v", id, args)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Describe adds the description of a parameter or property from the spec to
+// a message about it, with the MsgFieldDescription message, so that callers
+// learn what the field means and what's expected. Only the first paragraph of
+// the description is used. The message is returned as is when there's no
+// description.
+func Describe(r *http.Request, message string, field string, description string) string {
+	description = DescriptionSummary(description)
+	if description == "" {
+		return message
+	}
+	return Message(r, MsgFieldDescription, message, field, description)
+}
+
+// DescriptionSummary returns the first paragraph of a description, on a
+// single line.
+func DescriptionSummary(description string) string {
+	lines := strings.Split(strings.TrimSpace(description), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines = lines[:i]
+			break
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
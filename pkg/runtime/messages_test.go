@@ -0,0 +1,74 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage(t *testing.T) {
+	defer SetMessageCatalog(nil)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	assert.Equal(t, "Expected one value for X-Id, got 2", Message(req, MsgParamValueCount, "X-Id", 2))
+
+	german := map[MessageID]string{
+		MsgRequiredQueryParam: "Der Abfrageparameter %s ist erforderlich",
+	}
+	SetMessageCatalog(func(r *http.Request, id MessageID, args ...interface{}) string {
+		if !strings.HasPrefix(r.Header.Get("Accept-Language"), "de") {
+			return ""
+		}
+		if format, found := german[id]; found {
+			return fmt.Sprintf(format, args...)
+		}
+		return ""
+	})
+
+	// Requests in other languages get the default messages
+	assert.Equal(t, "Query argument limit is required, but not found", Message(req, MsgRequiredQueryParam, "limit"))
+
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+	assert.Equal(t, "Der Abfrageparameter limit ist erforderlich", Message(req, MsgRequiredQueryParam, "limit"))
+	// Messages missing from the catalog fall back to the defaults
+	assert.Equal(t, "Header parameter X-Id is required, but not found", Message(req, MsgRequiredHeaderParam, "X-Id"))
+
+	SetMessageCatalog(nil)
+	assert.Equal(t, "Query argument limit is required, but not found", Message(req, MsgRequiredQueryParam, "limit"))
+
+	format, found := DefaultMessage(MsgRequiredQueryParam)
+	assert.True(t, found)
+	assert.Equal(t, "Query argument %s is required, but not found", format)
+	_, found = DefaultMessage("Unknown")
+	assert.False(t, found)
+	assert.Equal(t, "Unknown: [limit]", Message(req, "Unknown", "limit"))
+}
+
+func TestDescribe(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	msg := Message(req, MsgRequiredQueryParam, "limit")
+
+	assert.Equal(t, msg, Describe(req, msg, "limit", ""))
+	assert.Equal(t, "Query argument limit is required, but not found (limit: The maximum number of results to return.)",
+		Describe(req, msg, "limit", "The maximum number of\nresults to return.\n\nDefaults to 20."))
+
+	assert.Equal(t, "", DescriptionSummary(" \n "))
+	assert.Equal(t, "A pet's name.", DescriptionSummary("\n  A pet's\n  name.  \n \nMore."))
+}
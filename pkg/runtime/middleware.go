@@ -0,0 +1,83 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WrapHTTPMiddleware adapts a net/http middleware, such as those used with
+// the generated chi server, into an echo middleware, for use with the
+// generated echo server.
+//
+// Unlike echo.WrapMiddleware, errors returned by the following handlers are
+// turned into a response by the error handler of echo before the net/http
+// middleware returns, so that it sees the status and body of the response,
+// as it would in a net/http server. Changes which the middleware makes to the
+// request, such as context values, are passed on to the handlers, and echo
+// middlewares running before this one see the response as it was written.
+func WrapHTTPMiddleware(m func(http.Handler) http.Handler) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			outer := c.Response()
+			var reached bool
+			m(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+				c.SetRequest(r)
+				c.SetResponse(echo.NewResponse(w, c.Echo()))
+				if err := next(c); err != nil {
+					c.Error(err)
+				}
+			})).ServeHTTP(outer, c.Request())
+
+			if reached {
+				// The middleware may have wrapped the ResponseWriter, in which
+				// case outer wasn't written through, and doesn't know about the
+				// response yet.
+				inner := c.Response()
+				c.SetResponse(outer)
+				if inner != outer && !outer.Committed {
+					outer.Status = inner.Status
+					outer.Size = inner.Size
+					outer.Committed = inner.Committed
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// WrapEchoMiddleware adapts an echo middleware into a net/http middleware, so
+// that it can be used with the generated chi server, or any other net/http
+// handler. Errors returned by the middleware are turned into a response by the
+// error handler of e, which can be nil to use the default one of echo.
+func WrapEchoMiddleware(e *echo.Echo, m echo.MiddlewareFunc) func(http.Handler) http.Handler {
+	if e == nil {
+		e = echo.New()
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := e.NewContext(r, w)
+			h := m(func(c echo.Context) error {
+				next.ServeHTTP(c.Response(), c.Request())
+				return nil
+			})
+			if err := h(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+		})
+	}
+}
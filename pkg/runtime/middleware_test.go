@@ -0,0 +1,123 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type middlewareKey struct{}
+
+// statusRecorder is a net/http middleware which wraps the ResponseWriter, as
+// logging and metrics middlewares do.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func TestWrapHTTPMiddleware(t *testing.T) {
+	var recorded int
+	recordStatus := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &statusRecorder{ResponseWriter: w}
+			ctx := context.WithValue(r.Context(), middlewareKey{}, "value")
+			next.ServeHTTP(rec, r.WithContext(ctx))
+			recorded = rec.status
+		})
+	}
+	reject := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "denied", http.StatusForbidden)
+		})
+	}
+
+	var echoStatus int
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			echoStatus = c.Response().Status
+			return err
+		}
+	})
+	e.Use(WrapHTTPMiddleware(recordStatus))
+	e.GET("/ok", func(c echo.Context) error {
+		return c.String(http.StatusAccepted, c.Request().Context().Value(middlewareKey{}).(string))
+	})
+	e.GET("/error", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusTeapot, "short and stout")
+	})
+	e.GET("/rejected", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, WrapHTTPMiddleware(reject))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "value", rec.Body.String())
+	assert.Equal(t, http.StatusAccepted, recorded)
+	assert.Equal(t, http.StatusAccepted, echoStatus)
+
+	// The net/http middleware sees the response of errors.
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/error", nil))
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Contains(t, rec.Body.String(), "short and stout")
+	assert.Equal(t, http.StatusTeapot, recorded)
+	assert.Equal(t, http.StatusTeapot, echoStatus)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/rejected", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, http.StatusForbidden, echoStatus)
+}
+
+func TestWrapEchoMiddleware(t *testing.T) {
+	requireHeader := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("X-Token") == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing token")
+			}
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), middlewareKey{}, "token")))
+			return next(c)
+		}
+	}
+	handler := WrapEchoMiddleware(nil, requireHeader)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(r.Context().Value(middlewareKey{}).(string)))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "missing token")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Token", "t")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "token", rec.Body.String())
+}
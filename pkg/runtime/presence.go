@@ -0,0 +1,108 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import "fmt"
+
+// The types generated with field presence tracking hold a presence set, a
+// bitset with a bit per property, in the order of the list of the JSON names
+// of their properties. The bits are set by UnmarshalJSON, for the properties
+// found in the JSON, even when they're null, and by MarkSet. The types of
+// allOf schemas embed the types of the schemas they refer to, which track
+// their own properties, and are passed as embedded to the functions below.
+
+// PresenceTracker is implemented by the types generated with field presence
+// tracking.
+type PresenceTracker interface {
+	IsSet(name string) bool
+	SetFields() []string
+}
+
+// PresenceMarker is implemented by pointers to the types generated with field
+// presence tracking.
+type PresenceMarker interface {
+	MarkSet(names ...string) error
+}
+
+// MarkField sets the bit of the i-th property in a presence set.
+func MarkField(set []uint64, i int) {
+	set[i/64] |= 1 << uint(i%64)
+}
+
+// MarkFields sets the bits of the named properties in a presence set, or
+// marks them in the first of the embedded PresenceMarkers which has them. It
+// returns an error for names which none has.
+func MarkFields(set []uint64, properties []string, names []string, embedded ...interface{}) error {
+	for _, name := range names {
+		if i := indexOf(properties, name); i >= 0 {
+			MarkField(set, i)
+			continue
+		}
+		if !markEmbedded(name, embedded) {
+			return fmt.Errorf("unknown property %q", name)
+		}
+	}
+	return nil
+}
+
+func markEmbedded(name string, embedded []interface{}) bool {
+	for _, e := range embedded {
+		if marker, ok := e.(PresenceMarker); ok && marker.MarkSet(name) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldIsSet returns whether the bit of the named property is set in a
+// presence set, or whether it's set in one of the embedded PresenceTrackers.
+// It's false for unknown names.
+func FieldIsSet(set []uint64, properties []string, name string, embedded ...interface{}) bool {
+	if i := indexOf(properties, name); i >= 0 && set[i/64]&(1<<uint(i%64)) != 0 {
+		return true
+	}
+	for _, e := range embedded {
+		if tracker, ok := e.(PresenceTracker); ok && tracker.IsSet(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFields returns the names of the properties which are set in the embedded
+// PresenceTrackers, followed by those whose bits are set in a presence set, in
+// the order of properties.
+func SetFields(set []uint64, properties []string, embedded ...interface{}) []string {
+	var names []string
+	for _, e := range embedded {
+		if tracker, ok := e.(PresenceTracker); ok {
+			names = append(names, tracker.SetFields()...)
+		}
+	}
+	for i, name := range properties {
+		if set[i/64]&(1<<uint(i%64)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func indexOf(properties []string, name string) int {
+	for i, property := range properties {
+		if property == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type presenceTestType struct {
+	presence   [1]uint64
+	properties []string
+}
+
+func (p presenceTestType) IsSet(name string) bool {
+	return FieldIsSet(p.presence[:], p.properties, name)
+}
+
+func (p presenceTestType) SetFields() []string {
+	return SetFields(p.presence[:], p.properties)
+}
+
+func (p *presenceTestType) MarkSet(names ...string) error {
+	return MarkFields(p.presence[:], p.properties, names)
+}
+
+func TestFieldPresence(t *testing.T) {
+	properties := make([]string, 70)
+	for i := range properties {
+		properties[i] = string(rune('a'+i/26)) + string(rune('a'+i%26))
+	}
+	set := make([]uint64, 2)
+	MarkField(set, 0)
+	MarkField(set, 69)
+	assert.True(t, FieldIsSet(set, properties, "aa"))
+	assert.True(t, FieldIsSet(set, properties, "cr"))
+	assert.False(t, FieldIsSet(set, properties, "ab"))
+	assert.False(t, FieldIsSet(set, properties, "zz"))
+	assert.Equal(t, []string{"aa", "cr"}, SetFields(set, properties))
+
+	assert.NoError(t, MarkFields(set, properties, []string{"ab"}))
+	assert.True(t, FieldIsSet(set, properties, "ab"))
+	assert.EqualError(t, MarkFields(set, properties, []string{"zz"}), `unknown property "zz"`)
+}
+
+func TestFieldPresenceEmbedded(t *testing.T) {
+	embedded := &presenceTestType{properties: []string{"name"}}
+	set := make([]uint64, 1)
+	properties := []string{"id"}
+
+	assert.NoError(t, MarkFields(set, properties, []string{"id", "name"}, embedded))
+	assert.True(t, embedded.IsSet("name"))
+	assert.True(t, FieldIsSet(set, properties, "name", *embedded))
+	assert.Equal(t, []string{"name", "id"}, SetFields(set, properties, *embedded))
+	assert.EqualError(t, MarkFields(set, properties, []string{"tag"}, embedded), `unknown property "tag"`)
+
+	// Embedded values which don't track presence are skipped.
+	assert.False(t, FieldIsSet(set, properties, "name", struct{}{}))
+}
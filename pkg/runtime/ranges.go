@@ -0,0 +1,235 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsatisfiableRange is returned by ParseRange when none of the requested
+// ranges overlaps the content. Servers should reply with a 416 status.
+var ErrUnsatisfiableRange = errors.New("requested range not satisfiable")
+
+// ByteRange is a single byte range, as requested with a Range header. End is
+// inclusive, and a negative End means that the range extends to the end of
+// the content. A negative Start requests the last -Start bytes instead, like
+// "bytes=-500".
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// String returns the value of the Range header requesting r.
+func (r ByteRange) String() string {
+	switch {
+	case r.Start < 0:
+		return fmt.Sprintf("bytes=%d", r.Start)
+	case r.End < 0:
+		return fmt.Sprintf("bytes=%d-", r.Start)
+	default:
+		return fmt.Sprintf("bytes=%d-%d", r.Start, r.End)
+	}
+}
+
+// SetRange sets the Range header of req to request r.
+func SetRange(req *http.Request, r ByteRange) {
+	req.Header.Set("Range", r.String())
+}
+
+// ContentRange is the range of the content carried by a partial response, as
+// described by its Content-Range header. End is inclusive, and Size is the
+// length of the complete content, or -1 when it isn't known.
+type ContentRange struct {
+	Start int64
+	End   int64
+	Size  int64
+}
+
+// Length returns the number of bytes in the range.
+func (r ContentRange) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// String returns the value of the Content-Range header describing r.
+func (r ContentRange) String() string {
+	if r.Size < 0 {
+		return fmt.Sprintf("bytes %d-%d/*", r.Start, r.End)
+	}
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.End, r.Size)
+}
+
+// ParseContentRange parses the value of a Content-Range header.
+func ParseContentRange(header string) (ContentRange, error) {
+	spec := strings.TrimSpace(header)
+	if !strings.HasPrefix(spec, "bytes ") {
+		return ContentRange{}, fmt.Errorf("invalid content range '%s': unit isn't bytes", header)
+	}
+	spec = strings.TrimSpace(strings.TrimPrefix(spec, "bytes "))
+
+	slash := strings.IndexByte(spec, '/')
+	if slash < 0 {
+		return ContentRange{}, fmt.Errorf("invalid content range '%s': missing size", header)
+	}
+	rangeSpec, sizeSpec := spec[:slash], spec[slash+1:]
+
+	cr := ContentRange{Size: -1}
+	if sizeSpec != "*" {
+		size, err := strconv.ParseInt(sizeSpec, 10, 64)
+		if err != nil || size < 0 {
+			return ContentRange{}, fmt.Errorf("invalid content range '%s': bad size", header)
+		}
+		cr.Size = size
+	}
+	if rangeSpec == "*" {
+		return ContentRange{}, fmt.Errorf("invalid content range '%s': range not satisfied", header)
+	}
+
+	dash := strings.IndexByte(rangeSpec, '-')
+	if dash < 0 {
+		return ContentRange{}, fmt.Errorf("invalid content range '%s': missing range end", header)
+	}
+	start, err := strconv.ParseInt(rangeSpec[:dash], 10, 64)
+	if err != nil || start < 0 {
+		return ContentRange{}, fmt.Errorf("invalid content range '%s': bad range start", header)
+	}
+	end, err := strconv.ParseInt(rangeSpec[dash+1:], 10, 64)
+	if err != nil || end < start || (cr.Size >= 0 && end >= cr.Size) {
+		return ContentRange{}, fmt.Errorf("invalid content range '%s': bad range end", header)
+	}
+	cr.Start = start
+	cr.End = end
+	return cr, nil
+}
+
+// PartialContent is a 206 Partial Content response carrying a single range.
+type PartialContent struct {
+	ContentRange ContentRange
+	ContentType  string
+	Body         []byte
+}
+
+// NewPartialContent builds a PartialContent from a 206 response and its body,
+// which has already been read. Multipart responses, which carry several
+// ranges, aren't supported.
+func NewPartialContent(rsp *http.Response, body []byte) (*PartialContent, error) {
+	contentType := rsp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == "multipart/byteranges" {
+		return nil, errors.New("multipart byte range responses are not supported")
+	}
+	cr, err := ParseContentRange(rsp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) != cr.Length() {
+		return nil, fmt.Errorf("partial content has %d bytes, but its range %s has %d",
+			len(body), cr, cr.Length())
+	}
+	return &PartialContent{
+		ContentRange: cr,
+		ContentType:  contentType,
+		Body:         body,
+	}, nil
+}
+
+// ParseRange parses the value of a Range header into the ranges of content of
+// the given size to send back, in the order they were requested. Ranges are
+// clipped to the content, and ranges which start past its end are dropped. If
+// nothing is left, ErrUnsatisfiableRange is returned.
+func ParseRange(header string, size int64) ([]ContentRange, error) {
+	spec := strings.TrimSpace(header)
+	if !strings.HasPrefix(spec, "bytes=") {
+		return nil, fmt.Errorf("invalid range '%s': unit isn't bytes", header)
+	}
+	var ranges []ContentRange
+	for _, part := range strings.Split(strings.TrimPrefix(spec, "bytes="), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		dash := strings.IndexByte(part, '-')
+		if dash < 0 {
+			return nil, fmt.Errorf("invalid range '%s'", header)
+		}
+		startSpec, endSpec := strings.TrimSpace(part[:dash]), strings.TrimSpace(part[dash+1:])
+
+		var start, end int64
+		if startSpec == "" {
+			// A suffix range, the last n bytes of the content.
+			n, err := strconv.ParseInt(endSpec, 10, 64)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid range '%s'", header)
+			}
+			if n > size {
+				n = size
+			}
+			start, end = size-n, size-1
+		} else {
+			var err error
+			start, err = strconv.ParseInt(startSpec, 10, 64)
+			if err != nil || start < 0 {
+				return nil, fmt.Errorf("invalid range '%s'", header)
+			}
+			end = size - 1
+			if endSpec != "" {
+				end, err = strconv.ParseInt(endSpec, 10, 64)
+				if err != nil || end < start {
+					return nil, fmt.Errorf("invalid range '%s'", header)
+				}
+				if end >= size {
+					end = size - 1
+				}
+			}
+		}
+		if start >= size || end < start {
+			continue
+		}
+		ranges = append(ranges, ContentRange{Start: start, End: end, Size: size})
+	}
+	if len(ranges) == 0 {
+		return nil, ErrUnsatisfiableRange
+	}
+	return ranges, nil
+}
+
+// WritePartialContent writes a 206 response carrying the range r, whose
+// bytes are read from content.
+func WritePartialContent(w http.ResponseWriter, contentType string, r ContentRange, content io.Reader) error {
+	header := w.Header()
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Content-Range", r.String())
+	header.Set("Content-Length", strconv.FormatInt(r.Length(), 10))
+	w.WriteHeader(http.StatusPartialContent)
+	_, err := io.CopyN(w, content, r.Length())
+	return err
+}
+
+// ServeContent replies to req with content, honouring the Range and
+// conditional headers of the request, as http.ServeContent does. Requests for
+// one range get a 206 response, requests for several a multipart one.
+func ServeContent(w http.ResponseWriter, req *http.Request, contentType string, modtime time.Time, content io.ReadSeeker) {
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	http.ServeContent(w, req, "", modtime, content)
+}
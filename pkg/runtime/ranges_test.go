@@ -0,0 +1,136 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteRangeString(t *testing.T) {
+	assert.Equal(t, "bytes=0-99", ByteRange{Start: 0, End: 99}.String())
+	assert.Equal(t, "bytes=100-", ByteRange{Start: 100, End: -1}.String())
+	assert.Equal(t, "bytes=-500", ByteRange{Start: -500}.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	SetRange(req, ByteRange{Start: 10, End: 19})
+	assert.Equal(t, "bytes=10-19", req.Header.Get("Range"))
+}
+
+func TestParseContentRange(t *testing.T) {
+	cr, err := ParseContentRange("bytes 0-99/1000")
+	require.NoError(t, err)
+	assert.Equal(t, ContentRange{Start: 0, End: 99, Size: 1000}, cr)
+	assert.Equal(t, int64(100), cr.Length())
+	assert.Equal(t, "bytes 0-99/1000", cr.String())
+
+	cr, err = ParseContentRange("bytes 10-19/*")
+	require.NoError(t, err)
+	assert.Equal(t, ContentRange{Start: 10, End: 19, Size: -1}, cr)
+	assert.Equal(t, "bytes 10-19/*", cr.String())
+
+	for _, header := range []string{"", "items 0-9/10", "bytes 0-9", "bytes */10", "bytes 9-0/10", "bytes 0-10/10", "bytes a-9/10"} {
+		_, err = ParseContentRange(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestNewPartialContent(t *testing.T) {
+	rsp := &http.Response{
+		StatusCode: http.StatusPartialContent,
+		Header: http.Header{
+			"Content-Type":  {"application/octet-stream"},
+			"Content-Range": {"bytes 2-4/10"},
+		},
+	}
+	pc, err := NewPartialContent(rsp, []byte("cde"))
+	require.NoError(t, err)
+	assert.Equal(t, ContentRange{Start: 2, End: 4, Size: 10}, pc.ContentRange)
+	assert.Equal(t, "application/octet-stream", pc.ContentType)
+	assert.Equal(t, []byte("cde"), pc.Body)
+
+	_, err = NewPartialContent(rsp, []byte("cd"))
+	assert.Error(t, err)
+
+	rsp.Header.Set("Content-Type", "multipart/byteranges; boundary=x")
+	_, err = NewPartialContent(rsp, []byte("cde"))
+	assert.Error(t, err)
+}
+
+func TestParseRange(t *testing.T) {
+	ranges, err := ParseRange("bytes=0-4, 8-, -3", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []ContentRange{
+		{Start: 0, End: 4, Size: 10},
+		{Start: 8, End: 9, Size: 10},
+		{Start: 7, End: 9, Size: 10},
+	}, ranges)
+
+	// Ranges are clipped to the content, or dropped when past its end
+	ranges, err = ParseRange("bytes=5-100,20-30,-50", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []ContentRange{
+		{Start: 5, End: 9, Size: 10},
+		{Start: 0, End: 9, Size: 10},
+	}, ranges)
+
+	_, err = ParseRange("bytes=10-", 10)
+	assert.Equal(t, ErrUnsatisfiableRange, err)
+
+	for _, header := range []string{"items=0-1", "bytes=1", "bytes=4-2", "bytes=-0", "bytes=a-"} {
+		_, err = ParseRange(header, 10)
+		assert.Error(t, err, header)
+		assert.NotEqual(t, ErrUnsatisfiableRange, err, header)
+	}
+}
+
+func TestWritePartialContent(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := WritePartialContent(w, "text/plain", ContentRange{Start: 2, End: 4, Size: 10}, strings.NewReader("cdefg"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "bytes 2-4/10", w.Header().Get("Content-Range"))
+	assert.Equal(t, "3", w.Header().Get("Content-Length"))
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, "cde", w.Body.String())
+}
+
+func TestServeContent(t *testing.T) {
+	content := []byte("abcdefghij")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeContent(w, r, "application/octet-stream", time.Time{}, bytes.NewReader(content))
+	})
+	doer := NewHandlerDoer(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	SetRange(req, ByteRange{Start: -3})
+	rsp, err := doer.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, rsp.StatusCode)
+	body, err := ioutil.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	pc, err := NewPartialContent(rsp, body)
+	require.NoError(t, err)
+	assert.Equal(t, ContentRange{Start: 7, End: 9, Size: 10}, pc.ContentRange)
+	assert.Equal(t, []byte("hij"), pc.Body)
+	assert.Equal(t, "application/octet-stream", pc.ContentType)
+}
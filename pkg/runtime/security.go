@@ -0,0 +1,202 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SecurityRequirement is a security requirement of an operation: the
+// security schemes which all have to be satisfied, with the scopes required
+// of each. An empty requirement makes the operation accessible anonymously.
+type SecurityRequirement map[string][]string
+
+// Schemes returns the names of the schemes of r, sorted.
+func (r SecurityRequirement) Schemes() []string {
+	schemes := make([]string, 0, len(r))
+	for scheme := range r {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// String returns the schemes of r, eg, "ApiKey and Bearer".
+func (r SecurityRequirement) String() string {
+	if len(r) == 0 {
+		return "anonymous"
+	}
+	return strings.Join(r.Schemes(), " and ")
+}
+
+// SecurityRequirements are the alternative security requirements of an
+// operation, any of which grants access. No requirements at all means that
+// the operation isn't secured.
+type SecurityRequirements []SecurityRequirement
+
+// String returns the alternatives of r, eg, "ApiKey and Bearer, or OAuth".
+func (r SecurityRequirements) String() string {
+	alternatives := make([]string, len(r))
+	for i, requirement := range r {
+		alternatives[i] = requirement.String()
+	}
+	return strings.Join(alternatives, ", or ")
+}
+
+// SecurityUnsatisfiedError is returned by SecurityRequirements.Select when no
+// requirement can be satisfied.
+type SecurityUnsatisfiedError struct {
+	Requirements SecurityRequirements
+}
+
+func (e *SecurityUnsatisfiedError) Error() string {
+	return fmt.Sprintf("no security provider satisfies any of the security requirements: %s", e.Requirements)
+}
+
+// Select returns the first requirement of r whose schemes are all available,
+// so that the credentials of those schemes can be sent. An empty requirement,
+// which makes credentials optional, is only selected when no other one can
+// be satisfied. It returns nil without requirements.
+func (r SecurityRequirements) Select(available func(scheme string) bool) (SecurityRequirement, error) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	anonymous := false
+	for _, requirement := range r {
+		if len(requirement) == 0 {
+			anonymous = true
+			continue
+		}
+		satisfied := true
+		for scheme := range requirement {
+			if !available(scheme) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return requirement, nil
+		}
+	}
+	if anonymous {
+		return SecurityRequirement{}, nil
+	}
+	return nil, &SecurityUnsatisfiedError{Requirements: r}
+}
+
+// SecurityAuthenticator checks that a request satisfies a security scheme,
+// with the given scopes, eg, by verifying its API key or token. The context
+// is the one of the request.
+type SecurityAuthenticator func(ctx context.Context, r *http.Request, scheme string, scopes []string) error
+
+// SecurityError is returned by CheckSecurity when a request satisfies none of
+// the security requirements of its operation.
+type SecurityError struct {
+	Requirements SecurityRequirements
+	Errors       []error // Why each requirement isn't satisfied
+}
+
+func (e *SecurityError) Error() string {
+	failures := make([]string, len(e.Requirements))
+	for i, requirement := range e.Requirements {
+		failures[i] = fmt.Sprintf("%s: %s", requirement, e.Errors[i])
+	}
+	return "none of the security requirements is satisfied: " + strings.Join(failures, ", or ")
+}
+
+// SecurityErrorHandler is called with the reasons a request is rejected by
+// CheckSecurity, eg, to log them, as generated servers don't send them to the
+// caller.
+type SecurityErrorHandler func(r *http.Request, err *SecurityError)
+
+type securityCheck struct {
+	authenticate SecurityAuthenticator
+	onError      SecurityErrorHandler
+}
+
+type securityCheckKey struct{}
+
+// CheckSecurity checks that a request satisfies one of the security
+// requirements of its operation, calling the authenticator installed by
+// SecurityMiddleware or SecurityHandler for every scheme of a requirement,
+// until all of them pass. Requirements are tried in order, and the first one
+// satisfied grants access. Without an authenticator, or requirements, every
+// request is accepted. It's called by generated servers, which respond with a
+// generic message when it fails.
+func CheckSecurity(r *http.Request, requirements SecurityRequirements) error {
+	check, ok := r.Context().Value(securityCheckKey{}).(securityCheck)
+	if !ok || len(requirements) == 0 {
+		return nil
+	}
+	errs := make([]error, len(requirements))
+	for i, requirement := range requirements {
+		var err error
+		for _, scheme := range requirement.Schemes() {
+			if err = check.authenticate(r.Context(), r, scheme, requirement[scheme]); err != nil {
+				err = fmt.Errorf("%s: %s", scheme, err)
+				break
+			}
+		}
+		if err == nil {
+			return nil
+		}
+		errs[i] = err
+	}
+	err := &SecurityError{Requirements: requirements, Errors: errs}
+	if check.onError != nil {
+		check.onError(r, err)
+	}
+	return err
+}
+
+func withSecurityCheck(r *http.Request, check securityCheck) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), securityCheckKey{}, check))
+}
+
+// SecurityHandler wraps a handler of generated operations, such as the one of
+// a generated chi server, so that requests are checked against the security
+// requirements of their operation with authenticate.
+func SecurityHandler(authenticate SecurityAuthenticator, next http.Handler) http.Handler {
+	return SecurityHandlerWithErrorHandler(authenticate, nil, next)
+}
+
+// SecurityHandlerWithErrorHandler is like SecurityHandler, but also calls
+// onError with the reasons a request is rejected.
+func SecurityHandlerWithErrorHandler(authenticate SecurityAuthenticator, onError SecurityErrorHandler, next http.Handler) http.Handler {
+	check := securityCheck{authenticate: authenticate, onError: onError}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, withSecurityCheck(r, check))
+	})
+}
+
+// SecurityMiddleware returns an echo middleware with which requests are
+// checked against the security requirements of their operation with
+// authenticate. The reasons a request is rejected are the internal error of
+// the *echo.HTTPError returned by the generated server, which is passed to
+// the HTTPErrorHandler of echo.
+func SecurityMiddleware(authenticate SecurityAuthenticator) echo.MiddlewareFunc {
+	check := securityCheck{authenticate: authenticate}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(withSecurityCheck(c.Request(), check))
+			return next(c)
+		}
+	}
+}
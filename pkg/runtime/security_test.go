@@ -0,0 +1,115 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityRequirementsSelect(t *testing.T) {
+	requirements := SecurityRequirements{
+		{"Bearer": {"read"}, "ApiKey": nil},
+		{"OAuth": {"read"}},
+	}
+	assert.Equal(t, "ApiKey and Bearer, or OAuth", requirements.String())
+
+	available := func(schemes ...string) func(string) bool {
+		return func(scheme string) bool {
+			for _, s := range schemes {
+				if s == scheme {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	requirement, err := requirements.Select(available("ApiKey", "Bearer", "OAuth"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ApiKey", "Bearer"}, requirement.Schemes())
+
+	requirement, err = requirements.Select(available("ApiKey", "OAuth"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OAuth"}, requirement.Schemes())
+
+	_, err = requirements.Select(available("ApiKey"))
+	var unsatisfied *SecurityUnsatisfiedError
+	require.True(t, errors.As(err, &unsatisfied))
+	assert.EqualError(t, err, "no security provider satisfies any of the security requirements: ApiKey and Bearer, or OAuth")
+
+	// An empty requirement makes credentials optional, but the other ones
+	// are preferred.
+	requirements = SecurityRequirements{{}, {"OAuth": nil}}
+	assert.Equal(t, "anonymous, or OAuth", requirements.String())
+	requirement, err = requirements.Select(available("OAuth"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OAuth"}, requirement.Schemes())
+	requirement, err = requirements.Select(available())
+	require.NoError(t, err)
+	assert.Empty(t, requirement)
+
+	requirement, err = SecurityRequirements(nil).Select(available())
+	require.NoError(t, err)
+	assert.Nil(t, requirement)
+}
+
+func TestCheckSecurity(t *testing.T) {
+	requirements := SecurityRequirements{
+		{"ApiKey": nil, "Bearer": {"write"}},
+		{"OAuth": {"write"}},
+	}
+	var calls []string
+	authenticate := func(ctx context.Context, r *http.Request, scheme string, scopes []string) error {
+		calls = append(calls, scheme)
+		if r.Header.Get(scheme) == "" {
+			return errors.New("missing credentials")
+		}
+		return nil
+	}
+	var checkErr error
+	handler := SecurityHandler(authenticate, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkErr = CheckSecurity(r, requirements)
+	}))
+
+	check := func(headers ...string) error {
+		calls = nil
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, header := range headers {
+			r.Header.Set(header, "x")
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		return checkErr
+	}
+
+	assert.NoError(t, check("ApiKey", "Bearer"))
+	assert.Equal(t, []string{"ApiKey", "Bearer"}, calls)
+
+	assert.NoError(t, check("Bearer", "OAuth"))
+	assert.Equal(t, []string{"ApiKey", "OAuth"}, calls)
+
+	err := check("ApiKey")
+	var securityErr *SecurityError
+	require.True(t, errors.As(err, &securityErr))
+	assert.EqualError(t, err, "none of the security requirements is satisfied: "+
+		"ApiKey and Bearer: Bearer: missing credentials, or OAuth: OAuth: missing credentials")
+
+	// The reasons are passed to the error handler.
+	var handled *SecurityError
+	handler = SecurityHandlerWithErrorHandler(authenticate, func(r *http.Request, err *SecurityError) {
+		handled = err
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkErr = CheckSecurity(r, requirements)
+	}))
+	err = check("ApiKey")
+	require.True(t, errors.As(err, &securityErr))
+	assert.Same(t, securityErr, handled)
+	assert.NoError(t, check("OAuth"))
+
+	// Without an authenticator, requests aren't checked.
+	assert.NoError(t, CheckSecurity(httptest.NewRequest(http.MethodGet, "/", nil), requirements))
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"net/http"
+)
+
+// StatusCoder is implemented by errors which know the status code of the
+// response they should result in, such as the domain errors of a business
+// service, eg, a not found error answering 404.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// ServiceErrorResponse returns the status code and message of the response
+// to an error returned by a business service, for an operation declaring the
+// error statuses declared, and a default response when hasDefault is true.
+// The generated service adapters use it. When an error in the chain of err
+// implements StatusCoder with a status which the operation declares, that
+// status is used, with the message of err. Any other error is an internal
+// server error, whose message isn't disclosed.
+func ServiceErrorResponse(err error, declared []int, hasDefault bool) (int, string) {
+	if coder := statusCoder(err); coder != nil {
+		status := coder.StatusCode()
+		allowed := hasDefault
+		for _, d := range declared {
+			if d == status {
+				allowed = true
+			}
+		}
+		if allowed && status >= 400 && status < 600 {
+			return status, err.Error()
+		}
+	}
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
+
+// statusCoder returns the first error implementing StatusCoder in the chain
+// of err, following both Unwrap, and Cause, as github.com/pkg/errors wraps
+// errors.
+func statusCoder(err error) StatusCoder {
+	for err != nil {
+		var coder StatusCoder
+		if errors.As(err, &coder) {
+			return coder
+		}
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil
+		}
+		err = causer.Cause()
+	}
+	return nil
+}
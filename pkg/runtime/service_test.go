@@ -0,0 +1,60 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type notFoundError struct {
+	id string
+}
+
+func (e notFoundError) Error() string {
+	return fmt.Sprintf("order %s not found", e.id)
+}
+
+func (e notFoundError) StatusCode() int {
+	return http.StatusNotFound
+}
+
+func TestServiceErrorResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		declared   []int
+		hasDefault bool
+		status     int
+		message    string
+	}{
+		{"declared", notFoundError{"1"}, []int{400, 404}, false, 404, "order 1 not found"},
+		{"wrapped", errors.Wrap(notFoundError{"1"}, "loading order"), []int{404}, false, 404, "loading order: order 1 not found"},
+		{"wrapped with %w", fmt.Errorf("loading order: %w", notFoundError{"1"}), []int{404}, false, 404, "loading order: order 1 not found"},
+		{"default response", notFoundError{"1"}, nil, true, 404, "order 1 not found"},
+		{"undeclared", notFoundError{"1"}, []int{400}, false, 500, "Internal Server Error"},
+		{"without status", errors.New("connection refused"), []int{400}, true, 500, "Internal Server Error"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, message := ServiceErrorResponse(test.err, test.declared, test.hasDefault)
+			assert.Equal(t, test.status, status)
+			assert.Equal(t, test.message, message)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SLO is the service level objective of an operation. The slo target
+// generates one per operation with the x-slo extension.
+type SLO struct {
+	OperationID string
+	Method      string
+	Path        string // The path template of the spec, such as /pets/{id}
+
+	// Availability is the proportion of requests which have to succeed, that
+	// is, get a response with a status below 500, eg, 0.999. It's zero when
+	// there's no availability objective.
+	Availability float64
+
+	// Latency is the time within which the proportion LatencyTarget of the
+	// requests have to be handled, eg, 99% in 300ms. It's zero when there's no
+	// latency objective.
+	Latency       time.Duration
+	LatencyTarget float64
+}
+
+// SLOObservation is a request to an operation with an SLO.
+type SLOObservation struct {
+	SLO
+
+	Duration time.Duration // How long the request took to handle
+	Status   int           // The status code of the response
+}
+
+// Available returns whether the request counts as a success for the
+// availability objective.
+func (o SLOObservation) Available() bool {
+	return o.Status < 500
+}
+
+// Fast returns whether the request was handled within the latency objective,
+// which is always the case without one.
+func (o SLOObservation) Fast() bool {
+	return o.Latency == 0 || o.Duration <= o.Latency
+}
+
+// Labels returns the labels to tag the metrics and spans of the request with:
+// the operation ID and the status code, which the recording rules of
+// SLORecordingRules select requests by, along with the objectives.
+func (o SLOObservation) Labels() map[string]string {
+	labels := sloLabels(o.SLO)
+	labels["code"] = strconv.Itoa(o.Status)
+	return labels
+}
+
+// sloLabels returns the labels describing an SLO.
+func sloLabels(slo SLO) map[string]string {
+	labels := map[string]string{"operation": slo.OperationID}
+	if slo.Availability != 0 {
+		labels["slo_availability"] = formatFloat(slo.Availability)
+	}
+	if slo.Latency != 0 {
+		labels["slo_latency_seconds"] = formatFloat(slo.Latency.Seconds())
+		labels["slo_latency_target"] = formatFloat(slo.LatencyTarget)
+	}
+	return labels
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// SLORecorder is called with every request to an operation with an SLO, once
+// the request has been handled, typically to update metrics.
+type SLORecorder func(ctx context.Context, o SLOObservation)
+
+type sloRecordKey struct{}
+
+// sloRecord holds the SLO of the operation which a request is routed to.
+type sloRecord struct {
+	slos        map[string]SLO
+	operationID string
+	parent      *sloRecord // The record of an enclosing middleware, if any
+}
+
+// SLOOperation records the operation which a request is routed to, when the
+// request is observed by SLOMiddleware, SLOHandler, LatencyMiddleware or
+// LatencyHandler. It's called by generated servers.
+func SLOOperation(ctx context.Context, operationID string) {
+	record, _ := ctx.Value(sloRecordKey{}).(*sloRecord)
+	for ; record != nil; record = record.parent {
+		record.operationID = operationID
+	}
+}
+
+// SLOFromContext returns the SLO of the operation which a request is routed
+// to, so that handlers can tag their spans with it. It's only found when
+// the request is observed by SLOMiddleware or SLOHandler.
+func SLOFromContext(ctx context.Context) (SLO, bool) {
+	record, _ := ctx.Value(sloRecordKey{}).(*sloRecord)
+	for ; record != nil; record = record.parent {
+		if slo, found := record.slos[record.operationID]; found {
+			return slo, true
+		}
+	}
+	return SLO{}, false
+}
+
+func startSLO(r *http.Request, slos map[string]SLO) (*http.Request, *sloRecord) {
+	parent, _ := r.Context().Value(sloRecordKey{}).(*sloRecord)
+	record := &sloRecord{slos: slos, parent: parent}
+	return r.WithContext(context.WithValue(r.Context(), sloRecordKey{}, record)), record
+}
+
+// echoResponseStatus returns the status of the response to a request handled
+// by echo, whose handler returned err. As the error is only turned into a
+// response after the middleware returns, the status of an *echo.HTTPError is
+// returned, or 500 for other errors.
+func echoResponseStatus(c echo.Context, err error) int {
+	status := c.Response().Status
+	if err != nil && !c.Response().Committed {
+		status = http.StatusInternalServerError
+		if he, ok := err.(*echo.HTTPError); ok {
+			status = he.Code
+		}
+	}
+	return status
+}
+
+// SLOHandler wraps a handler of generated operations, such as the one of a
+// generated chi server, so that record is called for every request routed to
+// an operation with an SLO.
+func SLOHandler(slos map[string]SLO, record SLORecorder, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		r, rec := startSLO(r, slos)
+		recorder := &auditStatusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		slo, found := slos[rec.operationID]
+		if !found {
+			return
+		}
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		record(r.Context(), SLOObservation{SLO: slo, Duration: time.Since(start), Status: status})
+	})
+}
+
+// SLOMiddleware returns an echo middleware which calls record for every
+// request routed to an operation with an SLO. As the error returned by a
+// handler is only turned into a response after the middleware returns, the
+// status of an *echo.HTTPError is reported, or 500 for other errors.
+func SLOMiddleware(slos map[string]SLO, record SLORecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			r, rec := startSLO(c.Request(), slos)
+			c.SetRequest(r)
+			err := next(c)
+
+			slo, found := slos[rec.operationID]
+			if !found {
+				return err
+			}
+			status := echoResponseStatus(c, err)
+			record(r.Context(), SLOObservation{SLO: slo, Duration: time.Since(start), Status: status})
+			return err
+		}
+	}
+}
+
+// sortedSLOs returns the SLOs by operation ID.
+func sortedSLOs(slos map[string]SLO) []SLO {
+	sorted := make([]SLO, 0, len(slos))
+	for _, slo := range slos {
+		sorted = append(sorted, slo)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OperationID < sorted[j].OperationID
+	})
+	return sorted
+}
+
+// OpenMetricsContentType is the media type of the OpenMetrics text format.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// WriteSLOMetrics writes the objectives of the SLOs as gauges in the
+// OpenMetrics text format, labeled with the operation, method and path, so
+// that SRE tooling can scrape them along with the metrics they apply to.
+func WriteSLOMetrics(w io.Writer, slos map[string]SLO) error {
+	sorted := sortedSLOs(slos)
+	var buf bytes.Buffer
+	gauge := func(name, unit, help string, value func(SLO) (float64, bool)) {
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", name)
+		if unit != "" {
+			fmt.Fprintf(&buf, "# UNIT %s %s\n", name, unit)
+		}
+		fmt.Fprintf(&buf, "# HELP %s %s\n", name, help)
+		for _, slo := range sorted {
+			if v, ok := value(slo); ok {
+				fmt.Fprintf(&buf, "%s{operation=\"%s\",method=\"%s\",path=\"%s\"} %s\n", name,
+					escapeLabelValue(slo.OperationID), escapeLabelValue(slo.Method), escapeLabelValue(slo.Path), formatFloat(v))
+			}
+		}
+	}
+	gauge("slo_availability_objective", "", "Proportion of the requests to the operation which have to succeed.",
+		func(slo SLO) (float64, bool) { return slo.Availability, slo.Availability != 0 })
+	gauge("slo_latency_threshold_seconds", "seconds", "Time within which the requests to the operation have to be handled.",
+		func(slo SLO) (float64, bool) { return slo.Latency.Seconds(), slo.Latency != 0 })
+	gauge("slo_latency_objective", "", "Proportion of the requests to the operation which have to be handled within the threshold.",
+		func(slo SLO) (float64, bool) { return slo.LatencyTarget, slo.Latency != 0 })
+	buf.WriteString("# EOF\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// escapeLabelValue escapes a label value of the OpenMetrics text format.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// SLOMetricsHandler serves the objectives of the SLOs in the OpenMetrics text
+// format, as written by WriteSLOMetrics.
+func SLOMetricsHandler(slos map[string]SLO) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", OpenMetricsContentType)
+		_ = WriteSLOMetrics(w, slos)
+	})
+}
+
+// errorBudget formats the proportion of requests which an objective allows to
+// fail, rounded so that 1 - 0.999 is written 0.001.
+func errorBudget(objective float64) string {
+	return strconv.FormatFloat(1-objective, 'g', 12, 64)
+}
+
+// SLOMetricNames names the metrics which SLORecordingRules computes burn
+// rates from. Both are labeled as returned by SLOObservation.Labels.
+type SLOMetricNames struct {
+	Requests string // A counter of the requests, eg, http_requests_total
+	Duration string // A histogram of the durations of the requests in seconds, eg, http_request_duration_seconds
+}
+
+// SLOBurnRateWindows are the windows of the burn rates recorded by
+// SLORecordingRules, as used by multiwindow, multi-burn-rate alerts.
+var SLOBurnRateWindows = []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"}
+
+// SLORecordingRules returns a Prometheus rule group, in YAML, which records
+// the burn rates of the SLOs over SLOBurnRateWindows, as the ratio of failed
+// requests over the ratio allowed by the objective. The availability burn
+// rates are named slo:availability_burn_rate:rate<window>, and the latency
+// ones slo:latency_burn_rate:rate<window>, labeled with the operation. The
+// latency threshold of every SLO has to be a bucket of the duration
+// histogram.
+func SLORecordingRules(group string, slos map[string]SLO, metrics SLOMetricNames) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "groups:\n- name: %q\n  rules:\n", group)
+	rule := func(record, expr string, labels map[string]string) {
+		fmt.Fprintf(&buf, "  - record: %s\n    expr: %q\n    labels:\n", record, expr)
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&buf, "      %s: %q\n", key, labels[key])
+		}
+	}
+	for _, slo := range sortedSLOs(slos) {
+		selector := fmt.Sprintf(`operation="%s"`, escapeLabelValue(slo.OperationID))
+		for _, window := range SLOBurnRateWindows {
+			if slo.Availability != 0 {
+				rule("slo:availability_burn_rate:rate"+window,
+					fmt.Sprintf(`(sum(rate(%s{%s,code=~"5.."}[%s])) / sum(rate(%s{%s}[%s]))) / %s`,
+						metrics.Requests, selector, window, metrics.Requests, selector, window, errorBudget(slo.Availability)),
+					sloLabels(slo))
+			}
+			if slo.Latency != 0 {
+				rule("slo:latency_burn_rate:rate"+window,
+					fmt.Sprintf(`(1 - sum(rate(%s_bucket{%s,le="%s"}[%s])) / sum(rate(%s_count{%s}[%s]))) / %s`,
+						metrics.Duration, selector, formatFloat(slo.Latency.Seconds()), window,
+						metrics.Duration, selector, window, errorBudget(slo.LatencyTarget)),
+					sloLabels(slo))
+			}
+		}
+	}
+	return buf.String()
+}
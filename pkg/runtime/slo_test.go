@@ -0,0 +1,125 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSLOs = map[string]SLO{
+	"listPets": {OperationID: "listPets", Method: "GET", Path: "/pets", Availability: 0.999},
+	"getPet": {OperationID: "getPet", Method: "GET", Path: "/pets/{id}", Latency: 250 * time.Millisecond,
+		LatencyTarget: 0.99},
+}
+
+func TestSLOHandler(t *testing.T) {
+	var observations []SLOObservation
+	record := func(ctx context.Context, o SLOObservation) {
+		observations = append(observations, o)
+	}
+	handler := SLOHandler(testSLOs, record, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pets":
+			SLOOperation(r.Context(), "listPets")
+			slo, found := SLOFromContext(r.Context())
+			assert.True(t, found)
+			assert.Equal(t, "listPets", slo.OperationID)
+			w.WriteHeader(http.StatusBadGateway)
+		case "/pets/1":
+			SLOOperation(r.Context(), "getPet")
+			_, _ = w.Write([]byte("{}"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pets", nil))
+	require.Len(t, observations, 1)
+	assert.Equal(t, "listPets", observations[0].OperationID)
+	assert.Equal(t, http.StatusBadGateway, observations[0].Status)
+	assert.False(t, observations[0].Available())
+	assert.True(t, observations[0].Fast())
+	assert.Equal(t, map[string]string{"operation": "listPets", "code": "502", "slo_availability": "0.999"},
+		observations[0].Labels())
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+	require.Len(t, observations, 2)
+	assert.Equal(t, http.StatusOK, observations[1].Status)
+	assert.True(t, observations[1].Available())
+
+	// Requests which aren't routed to an operation with an SLO aren't
+	// recorded.
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	assert.Len(t, observations, 2)
+}
+
+func TestSLOObservationFast(t *testing.T) {
+	o := SLOObservation{SLO: testSLOs["getPet"], Duration: 250 * time.Millisecond}
+	assert.True(t, o.Fast())
+	o.Duration = 251 * time.Millisecond
+	assert.False(t, o.Fast())
+}
+
+func TestWriteSLOMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSLOMetrics(&buf, testSLOs))
+	assert.Equal(t, `# TYPE slo_availability_objective gauge
+# HELP slo_availability_objective Proportion of the requests to the operation which have to succeed.
+slo_availability_objective{operation="listPets",method="GET",path="/pets"} 0.999
+# TYPE slo_latency_threshold_seconds gauge
+# UNIT slo_latency_threshold_seconds seconds
+# HELP slo_latency_threshold_seconds Time within which the requests to the operation have to be handled.
+slo_latency_threshold_seconds{operation="getPet",method="GET",path="/pets/{id}"} 0.25
+# TYPE slo_latency_objective gauge
+# HELP slo_latency_objective Proportion of the requests to the operation which have to be handled within the threshold.
+slo_latency_objective{operation="getPet",method="GET",path="/pets/{id}"} 0.99
+# EOF
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteSLOMetrics(&buf, map[string]SLO{
+		"q": {OperationID: `say "hi"\`, Method: "GET", Path: "/", Availability: 0.9},
+	}))
+	assert.Contains(t, buf.String(), `{operation="say \"hi\"\\",method="GET",path="/"} 0.9`)
+}
+
+func TestSLORecordingRules(t *testing.T) {
+	rules := SLORecordingRules("pets", testSLOs, SLOMetricNames{
+		Requests: "http_requests_total",
+		Duration: "http_request_duration_seconds",
+	})
+	assert.True(t, strings.HasPrefix(rules, `groups:
+- name: "pets"
+  rules:
+  - record: slo:latency_burn_rate:rate5m
+    expr: "(1 - sum(rate(http_request_duration_seconds_bucket{operation=\"getPet\",le=\"0.25\"}[5m])) / sum(rate(http_request_duration_seconds_count{operation=\"getPet\"}[5m]))) / 0.01"
+    labels:
+      operation: "getPet"
+      slo_latency_seconds: "0.25"
+      slo_latency_target: "0.99"
+`), rules)
+	assert.Contains(t, rules, `  - record: slo:availability_burn_rate:rate3d
+    expr: "(sum(rate(http_requests_total{operation=\"listPets\",code=~\"5..\"}[3d])) / sum(rate(http_requests_total{operation=\"listPets\"}[3d]))) / 0.001"
+`)
+	assert.Equal(t, len(SLOBurnRateWindows)*2, strings.Count(rules, "- record:"))
+}
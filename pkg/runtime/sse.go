@@ -0,0 +1,451 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSELastEventIDHeader is the header in which clients send the ID of the last
+// event they received when they reconnect to an event stream.
+const SSELastEventIDHeader = "Last-Event-ID"
+
+var (
+	// ErrSSEClosed is returned by SSEStream.Send once the stream is closed.
+	ErrSSEClosed = errors.New("event stream closed")
+	// ErrSSESlowConsumer is returned by SSEStream.Send when the client doesn't
+	// keep up with the events, in which case the stream is closed.
+	ErrSSESlowConsumer = errors.New("event stream consumer too slow")
+)
+
+// SSEEvent is a single server-sent event. Only Data is required.
+type SSEEvent struct {
+	ID    string        // The ID of the event, which clients send back when they reconnect
+	Event string        // The type of the event, "message" when empty
+	Data  string        // The data of the event, which may span several lines
+	Retry time.Duration // When set, how long clients wait before reconnecting
+}
+
+func (e SSEEvent) validate() error {
+	if strings.ContainsAny(e.ID, "\r\n\x00") {
+		return fmt.Errorf("invalid event ID %q", e.ID)
+	}
+	if strings.ContainsAny(e.Event, "\r\n") {
+		return fmt.Errorf("invalid event type %q", e.Event)
+	}
+	return nil
+}
+
+// writeTo writes the event in the text/event-stream format.
+func (e SSEEvent) writeTo(w io.Writer) error {
+	var b strings.Builder
+	if e.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", e.ID)
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", e.Event)
+	}
+	if e.Retry > 0 {
+		fmt.Fprintf(&b, "retry: %d\n", e.Retry/time.Millisecond)
+	}
+	data := strings.Replace(strings.Replace(e.Data, "\r\n", "\n", -1), "\r", "\n", -1)
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SSEOption configures NewSSEStream, on the server side, and SubscribeSSE, on
+// the client side. Each option says which side it applies to.
+type SSEOption func(*sseOptions)
+
+type sseOptions struct {
+	heartbeat   time.Duration
+	buffer      int
+	sendTimeout time.Duration
+	retry       time.Duration
+	maxRetries  int
+	lastEventID string
+}
+
+// WithSSEHeartbeat makes the server send a comment line whenever no event was
+// sent for interval, so that proxies and load balancers don't close idle
+// streams, and dead connections are noticed.
+func WithSSEHeartbeat(interval time.Duration) SSEOption {
+	return func(o *sseOptions) {
+		o.heartbeat = interval
+	}
+}
+
+// WithSSEBuffer sets how many events the server queues for a client which
+// doesn't read them as fast as they're sent. It's 16 by default.
+func WithSSEBuffer(size int) SSEOption {
+	return func(o *sseOptions) {
+		o.buffer = size
+	}
+}
+
+// WithSSESendTimeout sets how long SSEStream.Send waits for room in the
+// buffer of a slow client. When it times out, the stream is closed and Send
+// returns ErrSSESlowConsumer, so that producers feeding many clients aren't
+// held up by one of them. By default, Send waits until its context is done.
+func WithSSESendTimeout(timeout time.Duration) SSEOption {
+	return func(o *sseOptions) {
+		o.sendTimeout = timeout
+	}
+}
+
+// WithSSERetry sets the reconnection delay. The server sends it to clients as
+// the stream starts; SubscribeSSE uses it until the server sends one. It's 3
+// seconds by default on the client side.
+func WithSSERetry(delay time.Duration) SSEOption {
+	return func(o *sseOptions) {
+		o.retry = delay
+	}
+}
+
+// WithSSEMaxRetries makes SubscribeSSE give up after failing to reconnect
+// retries times in a row. By default, it reconnects until its context is
+// done.
+func WithSSEMaxRetries(retries int) SSEOption {
+	return func(o *sseOptions) {
+		o.maxRetries = retries
+	}
+}
+
+// WithSSELastEventID makes SubscribeSSE resume a stream after the event with
+// the given ID, typically the last one received by an earlier subscription.
+func WithSSELastEventID(id string) SSEOption {
+	return func(o *sseOptions) {
+		o.lastEventID = id
+	}
+}
+
+// SSEStream sends server-sent events to a client. Events are queued by Send,
+// which can be called from any goroutine, and written by Serve, which has to
+// be called by the handler of the request.
+type SSEStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	ctx     context.Context
+	opts    sseOptions
+	lastID  string
+
+	queue     chan SSEEvent
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSSEStream starts an event stream in response to r. It fails when w can't
+// be flushed, as events couldn't be delivered as they're sent.
+func NewSSEStream(w http.ResponseWriter, r *http.Request, opts ...SSEOption) (*SSEStream, error) {
+	o := sseOptions{buffer: 16}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("the response writer doesn't support flushing")
+	}
+	return &SSEStream{
+		w:       w,
+		flusher: flusher,
+		ctx:     r.Context(),
+		opts:    o,
+		lastID:  r.Header.Get(SSELastEventIDHeader),
+		queue:   make(chan SSEEvent, o.buffer),
+		closed:  make(chan struct{}),
+	}, nil
+}
+
+// LastEventID returns the ID of the last event which the client received,
+// when it's reconnecting, so that the events it missed can be sent again.
+func (s *SSEStream) LastEventID() string {
+	return s.lastID
+}
+
+// Send queues an event for the client. It waits for room in the buffer until
+// ctx is done, or for the timeout set with WithSSESendTimeout.
+func (s *SSEStream) Send(ctx context.Context, event SSEEvent) error {
+	if err := event.validate(); err != nil {
+		return err
+	}
+	select {
+	case <-s.closed:
+		return ErrSSEClosed
+	case s.queue <- event:
+		return nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if s.opts.sendTimeout > 0 {
+		timer := time.NewTimer(s.opts.sendTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case s.queue <- event:
+		return nil
+	case <-s.closed:
+		return ErrSSEClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		s.Close()
+		return ErrSSESlowConsumer
+	}
+}
+
+// Close ends the stream. Serve writes the events which are still queued, and
+// returns.
+func (s *SSEStream) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// Done returns a channel which is closed when the stream is closed, by Close
+// or because the client went away.
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.closed
+}
+
+// Serve writes the queued events, and heartbeats, until the stream is closed
+// or the client goes away. It must be called from the handler of the request,
+// as the response can't be written once the handler returns.
+func (s *SSEStream) Serve() error {
+	defer s.Close()
+	header := s.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Keep NGINX from buffering the stream.
+	header.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+	if s.opts.retry > 0 {
+		if _, err := fmt.Fprintf(s.w, "retry: %d\n\n", s.opts.retry/time.Millisecond); err != nil {
+			return err
+		}
+	}
+	s.flusher.Flush()
+
+	var heartbeat <-chan time.Time
+	if s.opts.heartbeat > 0 {
+		ticker := time.NewTicker(s.opts.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	idle := true
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-s.closed:
+			for {
+				select {
+				case event := <-s.queue:
+					if err := event.writeTo(s.w); err != nil {
+						return err
+					}
+				default:
+					s.flusher.Flush()
+					return nil
+				}
+			}
+		case event := <-s.queue:
+			if err := event.writeTo(s.w); err != nil {
+				return err
+			}
+			s.flusher.Flush()
+			idle = false
+		case <-heartbeat:
+			if idle {
+				if _, err := io.WriteString(s.w, ":\n\n"); err != nil {
+					return err
+				}
+				s.flusher.Flush()
+			}
+			idle = true
+		}
+	}
+}
+
+// SSEReader reads server-sent events from a text/event-stream.
+type SSEReader struct {
+	r *bufio.Reader
+
+	// LastEventID is the ID of the last event read, or of the last one
+	// received before, for a resumed stream.
+	LastEventID string
+	// Retry is the last reconnection delay sent by the server, if any.
+	Retry time.Duration
+}
+
+// NewSSEReader creates an SSEReader reading from r. lastEventID is the ID of
+// the last event received before, when the stream is resumed.
+func NewSSEReader(r io.Reader, lastEventID string) *SSEReader {
+	return &SSEReader{r: bufio.NewReader(r), LastEventID: lastEventID}
+}
+
+// Next returns the next event of the stream. It returns io.EOF at the end of
+// the stream. Comments, such as heartbeats, are skipped.
+func (r *SSEReader) Next() (SSEEvent, error) {
+	var event SSEEvent
+	var data []string
+	for {
+		line, err := r.r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line != "" {
+				// An event which isn't terminated by an empty line is
+				// incomplete, and discarded.
+				err = io.ErrUnexpectedEOF
+			}
+			return SSEEvent{}, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if data == nil {
+				event = SSEEvent{}
+				continue
+			}
+			event.ID = r.LastEventID
+			event.Data = strings.Join(data, "\n")
+			return event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if colon := strings.Index(line, ":"); colon >= 0 {
+			field, value = line[:colon], strings.TrimPrefix(line[colon+1:], " ")
+		}
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				r.LastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				r.Retry = time.Duration(ms) * time.Millisecond
+				event.Retry = r.Retry
+			}
+		}
+	}
+}
+
+// SSEConnector opens an event stream, typically by calling a generated client
+// method. The context holds the ID of the last event received, which the
+// client sends with SSELastEventIDEditor.
+type SSEConnector func(ctx context.Context) (*http.Response, error)
+
+type sseLastEventIDKey struct{}
+
+// SSELastEventID returns the ID of the last event received, which SubscribeSSE
+// passes to its SSEConnector in the context.
+func SSELastEventID(ctx context.Context) string {
+	id, _ := ctx.Value(sseLastEventIDKey{}).(string)
+	return id
+}
+
+// SSELastEventIDEditor is a request editor, for WithRequestEditorFn, which
+// sends the ID of the last event received when SubscribeSSE reconnects.
+func SSELastEventIDEditor(ctx context.Context, req *http.Request) error {
+	if id := SSELastEventID(ctx); id != "" {
+		req.Header.Set(SSELastEventIDHeader, id)
+	}
+	return nil
+}
+
+// SubscribeSSE reads the events of a stream opened with connect, and calls fn
+// with each of them. When the stream ends, or the connection fails, it
+// reconnects after the delay sent by the server, passing the ID of the last
+// event received. It returns when ctx is done, when fn returns an error, when
+// the server responds with 204 No Content, which tells clients to stop, or
+// with anything else than an event stream, or when it fails to reconnect as
+// many times as set with WithSSEMaxRetries.
+func SubscribeSSE(ctx context.Context, connect SSEConnector, fn func(SSEEvent) error, opts ...SSEOption) error {
+	o := sseOptions{retry: 3 * time.Second, maxRetries: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	lastID := o.lastEventID
+	delay := o.retry
+	failures := 0
+	for {
+		rsp, err := connect(context.WithValue(ctx, sseLastEventIDKey{}, lastID))
+		if err == nil {
+			if rsp.StatusCode == http.StatusNoContent {
+				rsp.Body.Close()
+				return nil
+			}
+			if rsp.StatusCode != http.StatusOK || !strings.HasPrefix(rsp.Header.Get("Content-Type"), "text/event-stream") {
+				rsp.Body.Close()
+				return fmt.Errorf("unexpected event stream response: %s, %s", rsp.Status, rsp.Header.Get("Content-Type"))
+			}
+
+			reader := NewSSEReader(rsp.Body, lastID)
+			for {
+				var event SSEEvent
+				event, err = reader.Next()
+				if err != nil {
+					break
+				}
+				failures = 0
+				if err = fn(event); err != nil {
+					rsp.Body.Close()
+					return err
+				}
+			}
+			rsp.Body.Close()
+			lastID = reader.LastEventID
+			if reader.Retry > 0 {
+				delay = reader.Retry
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		failures++
+		if o.maxRetries >= 0 && failures > o.maxRetries {
+			return fmt.Errorf("event stream failed after %d retries: %v", o.maxRetries, err)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
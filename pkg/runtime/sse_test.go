@@ -0,0 +1,170 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEReader(t *testing.T) {
+	stream := ": heartbeat\n\n" +
+		"retry: 1500\n\n" +
+		"id: 1\nevent: created\ndata: first\ndata: second\n\n" +
+		"data:no space\r\n\r\n" +
+		"id\ndata: reset\n\n" +
+		"data: incomplete"
+	r := NewSSEReader(strings.NewReader(stream), "0")
+
+	event, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, SSEEvent{ID: "1", Event: "created", Data: "first\nsecond"}, event)
+	assert.Equal(t, 1500*time.Millisecond, r.Retry)
+
+	event, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, SSEEvent{ID: "1", Data: "no space"}, event)
+
+	event, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, SSEEvent{Data: "reset"}, event)
+	assert.Equal(t, "", r.LastEventID)
+
+	_, err = r.Next()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestSSEStream(t *testing.T) {
+	events := []SSEEvent{
+		{ID: "1", Data: "one"},
+		{ID: "2", Event: "update", Data: "two\nlines"},
+	}
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream, err := NewSSEStream(w, r, WithSSEHeartbeat(10*time.Millisecond), WithSSERetry(time.Millisecond))
+		require.NoError(t, err)
+		lastEventIDs = append(lastEventIDs, stream.LastEventID())
+
+		start := 0
+		if id := stream.LastEventID(); id != "" {
+			start, _ = strconv.Atoi(id)
+		}
+		go func() {
+			// Leave time for a heartbeat before the first event.
+			time.Sleep(30 * time.Millisecond)
+			if start < len(events) {
+				assert.NoError(t, stream.Send(context.Background(), events[start]))
+			}
+			// The first connection ends after one event.
+			stream.Close()
+		}()
+		assert.NoError(t, stream.Serve())
+	}))
+	defer server.Close()
+
+	t.Run("Serve", func(t *testing.T) {
+		rsp, err := http.Get(server.URL)
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+		assert.Equal(t, "text/event-stream", rsp.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(rsp.Body)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(body), "retry: 1\n\n:\n\n"), "no heartbeat in %q", body)
+		assert.True(t, strings.HasSuffix(string(body), "\n\nid: 1\ndata: one\n\n"), "no event in %q", body)
+	})
+
+	t.Run("SubscribeSSE", func(t *testing.T) {
+		lastEventIDs = nil
+		connect := func(ctx context.Context) (*http.Response, error) {
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				return nil, err
+			}
+			req = req.WithContext(ctx)
+			if err := SSELastEventIDEditor(ctx, req); err != nil {
+				return nil, err
+			}
+			return http.DefaultClient.Do(req)
+		}
+		var received []SSEEvent
+		done := errors.New("done")
+		err := SubscribeSSE(context.Background(), connect, func(event SSEEvent) error {
+			received = append(received, event)
+			if len(received) == len(events) {
+				return done
+			}
+			return nil
+		}, WithSSERetry(time.Hour))
+		assert.Equal(t, done, err)
+		assert.Equal(t, events, received)
+		assert.Equal(t, []string{"", "1"}, lastEventIDs)
+	})
+}
+
+func TestSubscribeSSEStops(t *testing.T) {
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	connect := func(ctx context.Context) (*http.Response, error) {
+		return http.Get(server.URL)
+	}
+	noEvents := func(SSEEvent) error {
+		t.Error("unexpected event")
+		return nil
+	}
+
+	assert.NoError(t, SubscribeSSE(context.Background(), connect, noEvents))
+
+	status = http.StatusNotFound
+	assert.Error(t, SubscribeSSE(context.Background(), connect, noEvents))
+
+	failing := func(ctx context.Context) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+	err := SubscribeSSE(context.Background(), failing, noEvents, WithSSERetry(time.Millisecond), WithSSEMaxRetries(2))
+	assert.EqualError(t, err, "event stream failed after 2 retries: connection refused")
+}
+
+func TestSSEStreamSlowConsumer(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+	r.Header.Set(SSELastEventIDHeader, "41")
+	stream, err := NewSSEStream(httptest.NewRecorder(), r, WithSSEBuffer(1), WithSSESendTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, "41", stream.LastEventID())
+
+	// Nothing writes the queued events, as if the client stopped reading.
+	require.NoError(t, stream.Send(context.Background(), SSEEvent{Data: "queued"}))
+	assert.Equal(t, ErrSSESlowConsumer, stream.Send(context.Background(), SSEEvent{Data: "dropped"}))
+	assert.Equal(t, ErrSSEClosed, stream.Send(context.Background(), SSEEvent{Data: "closed"}))
+	select {
+	case <-stream.Done():
+	default:
+		t.Error("the stream of a slow consumer isn't closed")
+	}
+
+	assert.Error(t, stream.Send(context.Background(), SSEEvent{ID: "1\n2"}))
+}
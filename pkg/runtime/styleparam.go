@@ -14,6 +14,7 @@
 package runtime
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
@@ -21,11 +22,23 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/shawnhankim/oapi-codegen/pkg/types"
 )
 
 // Given an input value, such as a primitive type, array or object, turn it
 // into a parameter based on style/explode definition.
 func StyleParam(style string, explode bool, paramName string, value interface{}) (string, error) {
+	// Types which know how to marshal themselves as text, such as generated
+	// date-time types with a custom layout, are styled like strings.
+	if m, ok := textMarshaler(value); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("error marshaling '%s' as text: %s", paramName, err)
+		}
+		return stylePrimitive(style, explode, paramName, string(text))
+	}
+
 	t := reflect.TypeOf(value)
 	v := reflect.ValueOf(value)
 
@@ -56,6 +69,17 @@ func StyleParam(style string, explode bool, paramName string, value interface{})
 	}
 }
 
+// textMarshaler returns value as an encoding.TextMarshaler, unless it's one of
+// the types which StyleParam already knows how to format.
+func textMarshaler(value interface{}) (encoding.TextMarshaler, bool) {
+	switch value.(type) {
+	case time.Time, *time.Time, types.Date, *types.Date:
+		return nil, false
+	}
+	m, ok := value.(encoding.TextMarshaler)
+	return m, ok
+}
+
 func styleSlice(style string, explode bool, paramName string, values []interface{}) (string, error) {
 	var prefix string
 	var separator string
@@ -110,6 +134,9 @@ func styleSlice(style string, explode bool, paramName string, values []interface
 		if err != nil {
 			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
 		}
+		if isQueryStyle(style) {
+			parts[i] = formValueEscaper.Replace(parts[i])
+		}
 	}
 	return prefix + strings.Join(parts, separator), nil
 }
@@ -188,6 +215,14 @@ func styleMap(style string, explode bool, paramName string, value interface{}) (
 func processFieldDict(style string, explode bool, paramName string, fieldDict map[string]string) (string, error) {
 	var parts []string
 
+	if isQueryStyle(style) {
+		escaped := make(map[string]string, len(fieldDict))
+		for k, v := range fieldDict {
+			escaped[formValueEscaper.Replace(k)] = formValueEscaper.Replace(v)
+		}
+		fieldDict = escaped
+	}
+
 	// This works for everything except deepObject. We'll handle that one
 	// separately.
 	if style != "deepObject" {
@@ -267,12 +302,28 @@ func stylePrimitive(style string, explode bool, paramName string, value interfac
 		prefix = fmt.Sprintf(";%s=", paramName)
 	case "form":
 		prefix = fmt.Sprintf("%s=", paramName)
+		strVal = formValueEscaper.Replace(strVal)
 	default:
 		return "", fmt.Errorf("unsupported style '%s'", style)
 	}
 	return prefix + strVal, nil
 }
 
+// isQueryStyle returns whether parameters of the given style are sent in the
+// query, where their values and keys are escaped with formValueEscaper.
+func isQueryStyle(style string) bool {
+	switch style {
+	case "form", "spaceDelimited", "pipeDelimited", "deepObject":
+		return true
+	}
+	return false
+}
+
+// formValueEscaper escapes the characters of query values which would be
+// taken for separators or escapes when the query is parsed, such as those of
+// filter expressions.
+var formValueEscaper = strings.NewReplacer("%", "%25", "&", "%26", "+", "%2B", "#", "%23", ";", "%3B")
+
 // Converts a primitive value to a string. We need to do this based on the
 // Kind of an interface, not the Type to work with aliased types.
 func primitiveToString(value interface{}) (string, error) {
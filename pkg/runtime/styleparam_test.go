@@ -14,7 +14,9 @@
 package runtime
 
 import (
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -286,3 +288,82 @@ func TestStyleParam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, "firstName,Alex", result)
 }
+
+// secondsTime is a time which is marshaled as text without sub-second
+// precision, like the date-time types generated with a custom layout.
+type secondsTime struct {
+	time.Time
+}
+
+func (t secondsTime) MarshalText() ([]byte, error) {
+	return []byte(t.Time.UTC().Format(time.RFC3339)), nil
+}
+
+func (t *secondsTime) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse(time.RFC3339, string(data))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+func TestStyleParamTextMarshaler(t *testing.T) {
+	value := secondsTime{time.Date(2020, 1, 2, 3, 4, 5, 600, time.FixedZone("CET", 3600))}
+
+	result, err := StyleParam("simple", false, "at", value)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "2020-01-02T02:04:05Z", result)
+
+	result, err = StyleParam("form", true, "at", &value)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "at=2020-01-02T02:04:05Z", result)
+
+	result, err = StyleParam("label", false, "at", value)
+	assert.NoError(t, err)
+	assert.EqualValues(t, ".2020-01-02T02:04:05Z", result)
+}
+
+func TestStyleParamFormEscaping(t *testing.T) {
+	result, err := StyleParam("form", true, "q", "a==1;b=gt=2+3&c#d%")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "q=a==1%3Bb=gt=2%2B3%26c%23d%25", result)
+
+	parsed, err := url.ParseQuery(result)
+	assert.NoError(t, err)
+	assert.Equal(t, "a==1;b=gt=2+3&c#d%", parsed.Get("q"))
+
+	// Arrays, objects and maps are escaped too.
+	result, err = StyleParam("form", true, "q", []string{"a&b", "c;d"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "q=a%26b&q=c%3Bd", result)
+	parsed, err = url.ParseQuery(result)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a&b", "c;d"}, parsed["q"])
+
+	result, err = StyleParam("pipeDelimited", false, "q", []string{"a+b", "c%"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "q=a%2Bb|c%25", result)
+
+	object := struct {
+		Filter string `json:"filter"`
+	}{"a&b=1"}
+	result, err = StyleParam("form", true, "q", object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "filter=a%26b=1", result)
+	result, err = StyleParam("deepObject", true, "q", object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "q[filter]=a%26b=1", result)
+
+	result, err = StyleParam("form", false, "q", map[string]interface{}{"a;b": "c#d"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "q=a%3Bb,c%23d", result)
+	parsed, err = url.ParseQuery(result)
+	assert.NoError(t, err)
+	assert.Equal(t, "a;b,c#d", parsed.Get("q"))
+
+	// Other styles aren't.
+	result, err = StyleParam("simple", false, "q", []string{"a&b"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "a&b", result)
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// WarmupRequester sends a request with the given method to the given path,
+// which is resolved against the URL of the server, as the Warmup method of
+// generated clients does.
+type WarmupRequester func(ctx context.Context, method, path string) (*http.Response, error)
+
+// WarmupOption configures Warmup.
+type WarmupOption func(*warmupOptions)
+
+type warmupOptions struct {
+	method string
+	path   string
+}
+
+// WithWarmupRequest makes Warmup send requests with method to path, which is
+// resolved against the URL of the server, eg, OPTIONS requests, or HEAD
+// requests to a cheap operation, such as a health check. By default, HEAD
+// requests are sent to the URL of the server.
+func WithWarmupRequest(method, path string) WarmupOption {
+	return func(o *warmupOptions) {
+		o.method = method
+		o.path = path
+	}
+}
+
+// maxWarmupDrain is how much of the body of a warmup response is read, so
+// that its connection can be reused. Connections whose responses are longer
+// are closed instead.
+const maxWarmupDrain = 64 << 10
+
+// Warmup establishes n connections to a server ahead of the first requests,
+// so that these don't pay for the TCP and TLS handshakes. It sends n requests
+// concurrently, and holds their responses until all of them have arrived, so
+// that each takes a connection of its own, which then goes back to the idle
+// pool of the transport. Any response will do, whatever its status, so only
+// the errors of send are returned. The transport has to be allowed to keep n
+// idle connections to the server: the MaxIdleConnsPerHost of http.Transport
+// is only 2 by default. It fails when n is negative.
+func Warmup(ctx context.Context, n int, send WarmupRequester, opts ...WarmupOption) error {
+	if n < 0 {
+		return fmt.Errorf("invalid number of warmup connections: %d", n)
+	}
+	o := warmupOptions{method: http.MethodHead}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	responses := make([]*http.Response, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], errs[i] = send(ctx, o.method, o.path)
+		}(i)
+	}
+	wg.Wait()
+
+	var failed int
+	var firstErr error
+	for i, rsp := range responses {
+		if errs[i] != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		if rsp != nil && rsp.Body != nil {
+			_, _ = io.Copy(ioutil.Discard, io.LimitReader(rsp.Body, maxWarmupDrain))
+			rsp.Body.Close()
+		}
+	}
+	if firstErr != nil {
+		return fmt.Errorf("failed to establish %d of %d connections: %s", failed, n, firstErr)
+	}
+	return nil
+}
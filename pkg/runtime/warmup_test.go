@@ -0,0 +1,88 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmup(t *testing.T) {
+	var mu sync.Mutex
+	var conns int
+	var methods []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: 10}}
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return client.Do(req.WithContext(ctx))
+	}
+
+	require.NoError(t, Warmup(context.Background(), 5, send))
+	assert.Equal(t, 5, conns)
+	assert.Len(t, methods, 5)
+	assert.Equal(t, "HEAD /", methods[0])
+
+	// The connections are reused.
+	require.NoError(t, Warmup(context.Background(), 5, send, WithWarmupRequest(http.MethodOptions, "/health")))
+	assert.Equal(t, 5, conns)
+	assert.Equal(t, "OPTIONS /health", methods[9])
+}
+
+func TestWarmupErrors(t *testing.T) {
+	var calls int
+	var mu sync.Mutex
+	send := func(ctx context.Context, method, path string) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls%2 == 0 {
+			return nil, errors.New("connection refused")
+		}
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusNotFound)
+		return rec.Result(), nil
+	}
+	err := Warmup(context.Background(), 4, send)
+	assert.EqualError(t, err, "failed to establish 2 of 4 connections: connection refused")
+
+	err = Warmup(context.Background(), -1, send)
+	assert.EqualError(t, err, "invalid number of warmup connections: -1")
+}
@@ -0,0 +1,226 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemainfo describes the schemas of an OpenAPI spec at runtime, in a
+// form which is easy to walk: their fields, types and validation rules. The
+// schema-export target of oapi-codegen generates a Registry of the component
+// schemas of a spec, so that Terraform providers, admin UIs and the like can
+// be built over the same API without parsing the spec again.
+package schemainfo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Schema describes a single schema. Nested schemas which refer to a
+// component schema only have Ref set; Registry.Resolve returns the schema
+// which they refer to.
+type Schema struct {
+	Name   string // The name of a component schema, empty for nested schemas
+	Ref    string // The name of the component schema which this one refers to
+	GoType string // The generated Go type of a component schema, or of the schema referred to
+
+	Type        string // "object", "array", "string", "integer", "number", "boolean", or empty for any value
+	Format      string
+	Description string
+	Nullable    bool
+	ReadOnly    bool
+	WriteOnly   bool
+	Deprecated  bool
+	Validation  Validation
+
+	Fields               []Field   // The properties of an object, including those of its allOf schemas
+	Items                *Schema   // The items of an array
+	AdditionalProperties *Schema   // The type of additional properties, when an object allows them
+	OneOf                []*Schema // The alternatives of a oneOf schema
+	AnyOf                []*Schema // The alternatives of an anyOf schema
+}
+
+// Field is a property of an object schema.
+type Field struct {
+	Name     string // The name of the property in JSON
+	GoName   string // The name of the field of the generated Go type
+	Required bool
+	Schema   *Schema
+}
+
+// Validation holds the validation rules of a schema. Values are JSON encoded,
+// as they can be of any type.
+type Validation struct {
+	Enum    []string // The allowed values, JSON encoded
+	Default string   // The default value, JSON encoded, or empty when there's none
+	Pattern string
+
+	MinLength uint64
+	MaxLength *uint64
+
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MultipleOf       *float64
+
+	MinItems    uint64
+	MaxItems    *uint64
+	UniqueItems bool
+
+	MinProperties uint64
+	MaxProperties *uint64
+}
+
+// Uint64 returns a pointer to v, for the optional limits of Validation.
+func Uint64(v uint64) *uint64 {
+	return &v
+}
+
+// Float64 returns a pointer to v, for the optional limits of Validation.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Field returns the field with the given JSON name.
+func (s *Schema) Field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Registry holds the component schemas of a spec.
+type Registry struct {
+	schemas map[string]*Schema
+	names   []string
+}
+
+// NewRegistry creates a Registry of the given component schemas.
+func NewRegistry(schemas ...*Schema) *Registry {
+	r := &Registry{schemas: make(map[string]*Schema, len(schemas))}
+	for _, s := range schemas {
+		if _, found := r.schemas[s.Name]; !found {
+			r.names = append(r.names, s.Name)
+		}
+		r.schemas[s.Name] = s
+	}
+	sort.Strings(r.names)
+	return r
+}
+
+// Names returns the names of the component schemas, in order.
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// Lookup returns the component schema with the given name.
+func (r *Registry) Lookup(name string) (*Schema, bool) {
+	s, found := r.schemas[name]
+	return s, found
+}
+
+// Resolve follows the references of a schema to the component schema which
+// they lead to. Schemas without a reference are returned as they are.
+func (r *Registry) Resolve(s *Schema) (*Schema, error) {
+	seen := make(map[string]bool)
+	for s.Ref != "" {
+		if seen[s.Ref] {
+			return nil, fmt.Errorf("circular reference to %s", s.Ref)
+		}
+		seen[s.Ref] = true
+		target, found := r.schemas[s.Ref]
+		if !found {
+			return nil, fmt.Errorf("unknown schema %s", s.Ref)
+		}
+		s = target
+	}
+	return s, nil
+}
+
+// SkipSchema can be returned by a WalkFunc to skip the schemas nested in the
+// one which it was called for.
+var SkipSchema = errors.New("skip this schema")
+
+// WalkFunc is called by Walk for every schema. The path holds the names of
+// the fields leading to the schema from the one the walk started from, with
+// "[]" for array items, "{}" for additional properties, and "oneOf[i]" or
+// "anyOf[i]" for alternatives. References are resolved before fn is called.
+type WalkFunc func(path []string, s *Schema) error
+
+// Walk calls fn for the component schema with the given name, and for every
+// schema nested in it, depth first. A schema which refers to one of the
+// schemas it's nested in is passed to fn, but not walked again, so that
+// recursive schemas don't loop. An error returned by fn, other than
+// SkipSchema, stops the walk and is returned.
+func (r *Registry) Walk(name string, fn WalkFunc) error {
+	s, found := r.schemas[name]
+	if !found {
+		return fmt.Errorf("unknown schema %s", name)
+	}
+	return r.walk(nil, s, map[string]bool{}, fn)
+}
+
+func (r *Registry) walk(path []string, s *Schema, walking map[string]bool, fn WalkFunc) error {
+	s, err := r.Resolve(s)
+	if err != nil {
+		return err
+	}
+	err = fn(path, s)
+	if err == SkipSchema {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if walking[s.Name] {
+		return nil
+	}
+	if s.Name != "" {
+		walking[s.Name] = true
+		defer delete(walking, s.Name)
+	}
+
+	child := func(name string, nested *Schema) error {
+		// The path is copied, so that fn can keep it.
+		childPath := append(append([]string(nil), path...), name)
+		return r.walk(childPath, nested, walking, fn)
+	}
+	for _, f := range s.Fields {
+		if err := child(f.Name, f.Schema); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := child("[]", s.Items); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil {
+		if err := child("{}", s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+	for i, alternative := range s.OneOf {
+		if err := child(fmt.Sprintf("oneOf[%d]", i), alternative); err != nil {
+			return err
+		}
+	}
+	for i, alternative := range s.AnyOf {
+		if err := child(fmt.Sprintf("anyOf[%d]", i), alternative); err != nil {
+			return err
+		}
+	}
+	return nil
+}
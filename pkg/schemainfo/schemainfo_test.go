@@ -0,0 +1,103 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package schemainfo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistry() *Registry {
+	return NewRegistry(
+		&Schema{Name: "Tree", GoType: "Tree", Type: "object", Fields: []Field{
+			{Name: "label", GoName: "Label", Required: true, Schema: &Schema{Type: "string", Validation: Validation{MaxLength: Uint64(10)}}},
+			{Name: "children", GoName: "Children", Schema: &Schema{Type: "array", Items: &Schema{Ref: "Tree", GoType: "Tree"}}},
+			{Name: "meta", GoName: "Meta", Schema: &Schema{Ref: "Meta", GoType: "Meta"}},
+		}},
+		&Schema{Name: "Meta", GoType: "Meta", Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		&Schema{Name: "Alias", GoType: "Alias", Ref: "Meta"},
+		&Schema{Name: "Loop", GoType: "Loop", Ref: "Loop"},
+	)
+}
+
+func TestRegistryLookup(t *testing.T) {
+	r := testRegistry()
+	assert.Equal(t, []string{"Alias", "Loop", "Meta", "Tree"}, r.Names())
+
+	tree, found := r.Lookup("Tree")
+	require.True(t, found)
+	label, found := tree.Field("label")
+	require.True(t, found)
+	assert.True(t, label.Required)
+	assert.Equal(t, uint64(10), *label.Schema.Validation.MaxLength)
+	_, found = tree.Field("missing")
+	assert.False(t, found)
+
+	alias, _ := r.Lookup("Alias")
+	meta, err := r.Resolve(alias)
+	require.NoError(t, err)
+	assert.Equal(t, "Meta", meta.Name)
+
+	loop, _ := r.Lookup("Loop")
+	_, err = r.Resolve(loop)
+	assert.Error(t, err)
+	_, err = r.Resolve(&Schema{Ref: "Missing"})
+	assert.Error(t, err)
+}
+
+func TestRegistryWalk(t *testing.T) {
+	r := testRegistry()
+
+	var paths []string
+	err := r.Walk("Tree", func(path []string, s *Schema) error {
+		paths = append(paths, strings.Join(path, ".")+"="+s.Type)
+		return nil
+	})
+	require.NoError(t, err)
+	// The recursive reference to Tree is visited, but not walked again.
+	assert.Equal(t, []string{
+		"=object",
+		"label=string",
+		"children=array",
+		"children.[]=object",
+		"meta=object",
+		"meta.{}=string",
+	}, paths)
+
+	paths = nil
+	err = r.Walk("Tree", func(path []string, s *Schema) error {
+		paths = append(paths, strings.Join(path, "."))
+		if s.Type == "array" {
+			return SkipSchema
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "label", "children", "meta", "meta.{}"}, paths)
+
+	stop := errors.New("stop")
+	err = r.Walk("Tree", func(path []string, s *Schema) error {
+		if len(path) == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+
+	assert.Error(t, r.Walk("Missing", func([]string, *Schema) error { return nil }))
+}
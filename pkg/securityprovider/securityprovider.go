@@ -23,6 +23,31 @@ func (e SecurityProviderError) Error() string {
 	return string(e)
 }
 
+// SecurityProvider attaches credentials to the requests of a client. Its
+// Intercept method has the signature of the generated RequestEditorFn, and
+// receives the context passed to the client method, so that it can read
+// per-request values, like the identity of the user a call is made for.
+type SecurityProvider interface {
+	Intercept(ctx context.Context, req *http.Request) error
+}
+
+// InterceptorFn adapts a function to a SecurityProvider, the same way that
+// http.HandlerFunc adapts a function to a http.Handler.
+type InterceptorFn func(ctx context.Context, req *http.Request) error
+
+// Intercept calls f(ctx, req).
+func (f InterceptorFn) Intercept(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+var (
+	_ SecurityProvider = (*SecurityProviderBasicAuth)(nil)
+	_ SecurityProvider = (*SecurityProviderBearerToken)(nil)
+	_ SecurityProvider = (*SecurityProviderBearerTokenFromContext)(nil)
+	_ SecurityProvider = (*SecurityProviderApiKey)(nil)
+	_ SecurityProvider = InterceptorFn(nil)
+)
+
 // NewSecurityProviderBasicAuth provides a SecurityProvider, which can solve
 // the BasicAuth challenge for api-calls.
 func NewSecurityProviderBasicAuth(username, password string) (*SecurityProviderBasicAuth, error) {
@@ -41,7 +66,7 @@ type SecurityProviderBasicAuth struct {
 
 // Intercept will attach an Authorization header to the request and ensures that
 // the username, password are base64 encoded and attached to the header.
-func (s *SecurityProviderBasicAuth) Intercept(req *http.Request, ctx context.Context) error {
+func (s *SecurityProviderBasicAuth) Intercept(ctx context.Context, req *http.Request) error {
 	req.SetBasicAuth(s.username, s.password)
 	return nil
 }
@@ -62,24 +87,53 @@ type SecurityProviderBearerToken struct {
 
 // Intercept will attach an Authorization header to the request
 // and ensures that the bearer token is attached to the header.
-func (s *SecurityProviderBearerToken) Intercept(req *http.Request, ctx context.Context) error {
+func (s *SecurityProviderBearerToken) Intercept(ctx context.Context, req *http.Request) error {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.token))
 	return nil
 }
 
+// NewSecurityProviderBearerTokenFromContext provides a SecurityProvider which
+// sends a bearer token looked up for every request, typically from values of
+// its context, eg, for calls made on behalf of the user of a request being
+// served. No Authorization header is sent when token returns "".
+func NewSecurityProviderBearerTokenFromContext(token func(ctx context.Context) (string, error)) (*SecurityProviderBearerTokenFromContext, error) {
+	return &SecurityProviderBearerTokenFromContext{
+		token: token,
+	}, nil
+}
+
+// SecurityProviderBearerTokenFromContext sends a token, looked up from the
+// context of each request, as part of an Authorization: Bearer header.
+type SecurityProviderBearerTokenFromContext struct {
+	token func(ctx context.Context) (string, error)
+}
+
+// Intercept will look up the token for the request, and attach it to an
+// Authorization header.
+func (s *SecurityProviderBearerTokenFromContext) Intercept(ctx context.Context, req *http.Request) error {
+	token, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	return nil
+}
+
 // NewSecurityProviderApiKey will attach a generic apiKey for a given name
 // either to a cookie, header or as a query parameter.
 func NewSecurityProviderApiKey(in, name, apiKey string) (*SecurityProviderApiKey, error) {
-	interceptors := map[string]func(req *http.Request, ctx context.Context) error{
-		"cookie": func(req *http.Request, ctx context.Context) error {
+	interceptors := map[string]InterceptorFn{
+		"cookie": func(ctx context.Context, req *http.Request) error {
 			req.AddCookie(&http.Cookie{Name: name, Value: apiKey})
 			return nil
 		},
-		"header": func(req *http.Request, ctx context.Context) error {
+		"header": func(ctx context.Context, req *http.Request) error {
 			req.Header.Add(name, apiKey)
 			return nil
 		},
-		"query": func(req *http.Request, ctx context.Context) error {
+		"query": func(ctx context.Context, req *http.Request) error {
 			query := req.URL.Query()
 			query.Add(name, apiKey)
 			req.URL.RawQuery = query.Encode()
@@ -100,11 +154,11 @@ func NewSecurityProviderApiKey(in, name, apiKey string) (*SecurityProviderApiKey
 // SecurityProviderApiKey will attach an apiKey either to a
 // cookie, header or query.
 type SecurityProviderApiKey struct {
-	interceptor func(req *http.Request, ctx context.Context) error
+	interceptor InterceptorFn
 }
 
 // Intercept will attach a cookie, header or query param for the configured
 // name and apiKey.
-func (s *SecurityProviderApiKey) Intercept(req *http.Request, ctx context.Context) error {
-	return s.interceptor(req, ctx)
+func (s *SecurityProviderApiKey) Intercept(ctx context.Context, req *http.Request) error {
+	return s.interceptor(ctx, req)
 }
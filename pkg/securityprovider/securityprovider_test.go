@@ -0,0 +1,48 @@
+package securityprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userTokenKey struct{}
+
+func TestSecurityProviderBearerTokenFromContext(t *testing.T) {
+	provider, err := NewSecurityProviderBearerTokenFromContext(func(ctx context.Context) (string, error) {
+		token, _ := ctx.Value(userTokenKey{}).(string)
+		if token == "invalid" {
+			return "", errors.New("invalid token")
+		}
+		return token, nil
+	})
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), userTokenKey{}, "alice-token")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, provider.Intercept(ctx, req))
+	assert.Equal(t, "Bearer alice-token", req.Header.Get("Authorization"))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, provider.Intercept(context.Background(), req))
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	ctx = context.WithValue(context.Background(), userTokenKey{}, "invalid")
+	assert.Error(t, provider.Intercept(ctx, req))
+}
+
+func TestSecurityProviderApiKey(t *testing.T) {
+	provider, err := NewSecurityProviderApiKey("query", "key", "secret")
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/?a=b", nil)
+	require.NoError(t, provider.Intercept(context.Background(), req))
+	assert.Equal(t, "secret", req.URL.Query().Get("key"))
+
+	_, err = NewSecurityProviderApiKey("body", "key", "secret")
+	assert.Equal(t, ErrSecurityProviderApiKeyInvalidIn, err)
+}